@@ -0,0 +1,177 @@
+package structure
+
+import (
+	"github.com/df-mc/dragonfly/server/block/model"
+	"github.com/df-mc/dragonfly/server/world"
+)
+
+// agingRule is a rule used by Structure.Age that replaces one block with a decayed variant of it.
+type agingRule struct {
+	from, to           string
+	fromProps, toProps map[string]interface{}
+	chance             float64
+}
+
+// agingRules are the rules used by Structure.Age to crack and overgrow blocks. Their chances are multiplied by
+// the intensity passed to Age.
+var agingRules = []agingRule{
+	{from: "minecraft:stonebrick", fromProps: map[string]interface{}{"stone_brick_type": "default"}, to: "minecraft:stonebrick", toProps: map[string]interface{}{"stone_brick_type": "cracked"}, chance: 0.25},
+	{from: "minecraft:stonebrick", fromProps: map[string]interface{}{"stone_brick_type": "default"}, to: "minecraft:stonebrick", toProps: map[string]interface{}{"stone_brick_type": "mossy"}, chance: 0.25},
+	{from: "minecraft:cobblestone", to: "minecraft:mossy_cobblestone", chance: 0.3},
+	{from: "minecraft:deepslate_bricks", to: "minecraft:cracked_deepslate_bricks", chance: 0.3},
+	{from: "minecraft:deepslate_tiles", to: "minecraft:cracked_deepslate_tiles", chance: 0.3},
+	{from: "minecraft:polished_blackstone_bricks", to: "minecraft:cracked_polished_blackstone_bricks", chance: 0.3},
+	{from: "minecraft:nether_brick", to: "minecraft:cracked_nether_bricks", chance: 0.3},
+}
+
+// Age returns a copy of the Structure that looks weathered, turning a clean template into a ruined variant of
+// it. Age cracks bricks, grows moss on cobblestone and stone bricks, removes blocks at exposed edges and corners
+// and grows vines on the sides of exposed solid blocks. intensity, between 0 and 1, controls how strongly the
+// Structure is aged: an intensity of 0 leaves the Structure unchanged. As with Vary, the result depends only on the
+// seed and the Structure, so aging the same Structure twice with the same seed results in the same Structure.
+// Blocks removed are replaced with air, so that building the result removes the blocks from the world too.
+func (s Structure) Age(intensity float64, seed int64) Structure {
+	if intensity <= 0 {
+		return s.clone()
+	}
+	if intensity > 1 {
+		intensity = 1
+	}
+	variants := make([]Variant, 0, len(agingRules))
+	for _, rule := range agingRules {
+		from, ok := world.BlockByName(rule.from, rule.fromProps)
+		if !ok {
+			continue
+		}
+		to, ok := world.BlockByName(rule.to, rule.toProps)
+		if !ok {
+			continue
+		}
+		variants = append(variants, Variant{From: from, To: to, Chance: rule.chance * intensity})
+	}
+	c := s.Vary(seed, variants...)
+	c.erode(intensity, seed+1)
+	c.growVines(intensity, seed+2)
+	return c
+}
+
+// erode removes blocks from the structure that have at least three exposed faces, with a chance that grows with
+// the number of exposed faces and the intensity passed. Faces touching the boundary of the structure are exposed,
+// except for the bottom, which is assumed to rest on the ground.
+func (s Structure) erode(intensity float64, seed int64) {
+	dims := s.Dimensions()
+	air := s.airEntries()
+	open := func(index int32) bool {
+		return index == -1 || air[index]
+	}
+	var removed []int
+	for x := 0; x < dims[0]; x++ {
+		for y := 0; y < dims[1]; y++ {
+			for z := 0; z < dims[2]; z++ {
+				offset := (x * s.l * s.h) + (y * s.l) + z
+				if open(s.blocks[offset]) {
+					continue
+				}
+				exposed := 0
+				if x == 0 || open(s.blocks[offset-s.l*s.h]) {
+					exposed++
+				}
+				if x == dims[0]-1 || open(s.blocks[offset+s.l*s.h]) {
+					exposed++
+				}
+				if y != 0 && open(s.blocks[offset-s.l]) {
+					exposed++
+				}
+				if y == dims[1]-1 || open(s.blocks[offset+s.l]) {
+					exposed++
+				}
+				if z == 0 || open(s.blocks[offset-1]) {
+					exposed++
+				}
+				if z == dims[2]-1 || open(s.blocks[offset+1]) {
+					exposed++
+				}
+				if exposed < 3 {
+					continue
+				}
+				if positionRand(seed, x, y, z) < float64(exposed-2)*0.15*intensity {
+					// Blocks are only removed after all blocks have been checked, so that removing a block does
+					// not expose the blocks around it.
+					removed = append(removed, offset)
+				}
+			}
+		}
+	}
+	if len(removed) == 0 {
+		return
+	}
+	ptr := s.ptrFor(airBlock())
+	for _, offset := range removed {
+		s.setIndex(offset, ptr, nil)
+	}
+}
+
+// growVines places vines in air next to the sides of solid blocks in the structure, with a chance for every side
+// that depends on the intensity passed. Positions without a block are left untouched.
+func (s Structure) growVines(intensity float64, seed int64) {
+	s.ensureParsed()
+	dims := s.Dimensions()
+	air := s.airEntries()
+	solid := make([]bool, len(s.parsedPalette))
+	for i, entry := range s.parsedPalette {
+		if entry.b != nil {
+			_, solid[i] = entry.b.Model().(model.Solid)
+		}
+	}
+	// sides holds the offsets of the four horizontal neighbours of a position, along with the vine direction bit
+	// of a vine attached to a block at that neighbour.
+	sides := [4]struct {
+		dx, dz int
+		bit    int32
+	}{{0, 1, 1}, {-1, 0, 2}, {0, -1, 4}, {1, 0, 8}}
+
+	type vine struct {
+		offset int
+		bits   int32
+	}
+	var vines []vine
+	for x := 0; x < dims[0]; x++ {
+		for y := 0; y < dims[1]; y++ {
+			for z := 0; z < dims[2]; z++ {
+				offset := (x * s.l * s.h) + (y * s.l) + z
+				if index := s.blocks[offset]; index == -1 || !air[index] || s.liquids[offset] != -1 {
+					continue
+				}
+				var bits int32
+				for i, side := range sides {
+					nx, nz := x+side.dx, z+side.dz
+					if nx < 0 || nz < 0 || nx >= dims[0] || nz >= dims[2] {
+						continue
+					}
+					if index := s.blocks[(nx*s.l*s.h)+(y*s.l)+nz]; index == -1 || !solid[index] {
+						continue
+					}
+					if positionRand(seed+int64(i), x, y, z) < 0.2*intensity {
+						bits |= side.bit
+					}
+				}
+				if bits != 0 {
+					vines = append(vines, vine{offset: offset, bits: bits})
+				}
+			}
+		}
+	}
+	cache := map[int32]int32{}
+	for _, v := range vines {
+		ptr, ok := cache[v.bits]
+		if !ok {
+			b, found := world.BlockByName("minecraft:vine", map[string]interface{}{"vine_direction_bits": v.bits})
+			if !found {
+				return
+			}
+			ptr = s.ptrFor(b)
+			cache[v.bits] = ptr
+		}
+		s.setIndex(v.offset, ptr, nil)
+	}
+}
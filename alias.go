@@ -0,0 +1,68 @@
+package structure
+
+import (
+	"github.com/df-mc/dragonfly/server/world"
+	"sync"
+)
+
+// AliasRegistry is a BlockRegistry that resolves blocks using another BlockRegistry, but falls back to a table of
+// aliases for blocks that BlockRegistry cannot resolve. Aliases allow structures to be loaded meaningfully when they
+// reference blocks that no longer exist, such as vanilla blocks renamed in a way the block upgrader does not know
+// of, or blocks of addons that are no longer installed, by replacing these blocks with blocks that do exist.
+// An AliasRegistry is used by passing it to Structure.UseRegistry. It is safe for concurrent use.
+type AliasRegistry struct {
+	r BlockRegistry
+
+	mu      sync.RWMutex
+	aliases map[string][]alias
+}
+
+// alias is a replacement for blocks with a specific name and, optionally, specific block states.
+type alias struct {
+	states      map[string]interface{}
+	replacement world.Block
+}
+
+// NewAliasRegistry returns an AliasRegistry that resolves blocks using the BlockRegistry passed, before looking
+// them up in its aliases. If nil is passed, blocks are resolved using Dragonfly's registered blocks.
+func NewAliasRegistry(r BlockRegistry) *AliasRegistry {
+	if r == nil {
+		r = worldRegistry{}
+	}
+	return &AliasRegistry{r: r, aliases: map[string][]alias{}}
+}
+
+// Alias registers the world.Block passed as the replacement for blocks with the name passed that cannot otherwise be
+// resolved. If states is nil, the alias applies to blocks with the name passed regardless of their block states.
+// Otherwise it applies only to blocks that have all the states passed with the same values, ignoring any other
+// states they have. Aliases are tried in the order they were registered, so more specific aliases for a name
+// should be registered before less specific ones.
+// Palette entries are upgraded to the current version of Minecraft before being resolved, so the name and states
+// passed must be those of the upgraded entry. For blocks unknown to the block upgrader, such as addon blocks, these
+// are the same as the name and states in the palette.
+func (a *AliasRegistry) Alias(name string, states map[string]interface{}, replacement world.Block) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.aliases[name] = append(a.aliases[name], alias{states: canonicalStates(states), replacement: replacement})
+}
+
+// BlockByName returns the block with the name and properties passed from the BlockRegistry of the AliasRegistry.
+// If it has no such block, the replacement of the first matching alias is returned instead.
+func (a *AliasRegistry) BlockByName(name string, properties map[string]interface{}) (world.Block, bool) {
+	if b, ok := a.r.BlockByName(name, properties); ok {
+		return b, true
+	}
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+	aliases := a.aliases[name]
+	if len(aliases) == 0 {
+		return nil, false
+	}
+	properties = canonicalStates(properties)
+	for _, al := range aliases {
+		if al.states == nil || statesSubset(al.states, properties) {
+			return al.replacement, true
+		}
+	}
+	return nil, false
+}
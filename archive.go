@@ -0,0 +1,192 @@
+package structure
+
+import (
+	"archive/zip"
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+)
+
+// Archive is a zip-based container that holds many named structures and a manifest listing them. Structures
+// in an Archive are only read when they are first loaded using Archive.Load.
+// Users must ensure an Archive is only accessed from one goroutine at a time.
+type Archive struct {
+	closer io.Closer
+
+	files  map[string]*zip.File
+	loaded map[string]Structure
+}
+
+// archiveManifest is the manifest stored in an Archive. It lists the names of all structures in the Archive and
+// the files in which they are stored.
+type archiveManifest struct {
+	FormatVersion int                      `json:"format_version"`
+	Structures    []archiveManifestElement `json:"structures"`
+}
+
+// archiveManifestElement is a single structure listed in the manifest of an Archive.
+type archiveManifestElement struct {
+	Name string `json:"name"`
+	File string `json:"file"`
+}
+
+const (
+	// archiveManifestFile is the name of the file in an Archive that holds its manifest.
+	archiveManifestFile = "manifest.json"
+	// archiveVersion is the format version of the manifest of an Archive.
+	archiveVersion = 1
+)
+
+// OpenArchive opens the Archive at the path passed. If successful, the error returned is nil. The Archive
+// returned must be closed using Archive.Close once it is no longer used.
+func OpenArchive(file string) (*Archive, error) {
+	f, err := os.Open(file)
+	if err != nil {
+		return nil, fmt.Errorf("open file: %w", err)
+	}
+	stat, err := f.Stat()
+	if err != nil {
+		_ = f.Close()
+		return nil, fmt.Errorf("stat file: %w", err)
+	}
+	a, err := ReadArchive(f, stat.Size())
+	if err != nil {
+		_ = f.Close()
+		return nil, err
+	}
+	a.closer = f
+	return a, nil
+}
+
+// ReadArchive reads an Archive from the io.ReaderAt passed, which holds size bytes. If successful, the error
+// returned is nil. The io.ReaderAt must remain valid for as long as structures are loaded from the Archive.
+func ReadArchive(r io.ReaderAt, size int64) (*Archive, error) {
+	zr, err := zip.NewReader(r, size)
+	if err != nil {
+		return nil, fmt.Errorf("open zip: %w", err)
+	}
+	a := &Archive{files: map[string]*zip.File{}, loaded: map[string]Structure{}}
+
+	f, err := zr.Open(archiveManifestFile)
+	if err != nil {
+		return nil, fmt.Errorf("open manifest: %w", err)
+	}
+	defer f.Close()
+	var m archiveManifest
+	if err := json.NewDecoder(f).Decode(&m); err != nil {
+		return nil, fmt.Errorf("decode manifest: %w", err)
+	}
+	if m.FormatVersion != archiveVersion {
+		return nil, fmt.Errorf("unsupported archive format version %v: expected version %v", m.FormatVersion, archiveVersion)
+	}
+
+	entries := make(map[string]*zip.File, len(zr.File))
+	for _, file := range zr.File {
+		entries[file.Name] = file
+	}
+	for _, e := range m.Structures {
+		file, ok := entries[e.File]
+		if !ok {
+			return nil, fmt.Errorf("structure %v refers to file %v which is not in the archive", e.Name, e.File)
+		}
+		a.files[e.Name] = file
+	}
+	return a, nil
+}
+
+// Names returns the names of all structures in the Archive, sorted alphabetically.
+func (a *Archive) Names() []string {
+	names := make([]string, 0, len(a.files))
+	for name := range a.files {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Load loads the structure with the name passed from the Archive. The structure is read the first time it is
+// loaded and is kept in memory for subsequent calls. Every call returns a separate copy of the structure, so that
+// modifying a Structure returned does not affect the structures returned later. If no structure with the name
+// exists, an error is returned.
+func (a *Archive) Load(name string) (Structure, error) {
+	if s, ok := a.loaded[name]; ok {
+		return s.clone(), nil
+	}
+	file, ok := a.files[name]
+	if !ok {
+		return Structure{}, fmt.Errorf("no structure named %v in archive", name)
+	}
+	f, err := file.Open()
+	if err != nil {
+		return Structure{}, fmt.Errorf("open structure %v: %w", name, err)
+	}
+	defer f.Close()
+	s, err := Read(bufio.NewReader(f))
+	if err != nil {
+		return Structure{}, fmt.Errorf("read structure %v: %w", name, err)
+	}
+	a.loaded[name] = s
+	return s.clone(), nil
+}
+
+// Close closes the file of the Archive if it was opened using OpenArchive.
+func (a *Archive) Close() error {
+	if a.closer != nil {
+		return a.closer.Close()
+	}
+	return nil
+}
+
+// WriteArchive writes an Archive holding the structures passed, keyed by their names, to the io.Writer passed.
+// If successful, the error returned is nil.
+func WriteArchive(w io.Writer, structures map[string]Structure) error {
+	names := make([]string, 0, len(structures))
+	for name := range structures {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	zw := zip.NewWriter(w)
+	m := archiveManifest{FormatVersion: archiveVersion}
+	for i, name := range names {
+		e := archiveManifestElement{Name: name, File: fmt.Sprintf("structures/%v.mcstructure", i)}
+		f, err := zw.Create(e.File)
+		if err != nil {
+			return fmt.Errorf("create structure %v: %w", name, err)
+		}
+		if err := Write(f, structures[name]); err != nil {
+			return fmt.Errorf("write structure %v: %w", name, err)
+		}
+		m.Structures = append(m.Structures, e)
+	}
+	f, err := zw.Create(archiveManifestFile)
+	if err != nil {
+		return fmt.Errorf("create manifest: %w", err)
+	}
+	if err := json.NewEncoder(f).Encode(m); err != nil {
+		return fmt.Errorf("encode manifest: %w", err)
+	}
+	if err := zw.Close(); err != nil {
+		return fmt.Errorf("close zip: %w", err)
+	}
+	return nil
+}
+
+// WriteArchiveFile writes an Archive holding the structures passed, keyed by their names, to the file passed.
+// If successful, the error returned is nil. WriteArchiveFile creates a file if it doesn't yet exist and
+// truncates it if one does exist.
+func WriteArchiveFile(file string, structures map[string]Structure) error {
+	f, err := os.OpenFile(file, os.O_TRUNC|os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		return fmt.Errorf("open file: %w", err)
+	}
+	w := bufio.NewWriter(f)
+	defer func() {
+		_ = w.Flush()
+		_ = f.Close()
+	}()
+	return WriteArchive(w, structures)
+}
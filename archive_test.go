@@ -0,0 +1,33 @@
+package structure
+
+import (
+	"bytes"
+	df "github.com/df-mc/dragonfly/server/block"
+	"testing"
+)
+
+func TestArchiveLoadReturnsCopy(t *testing.T) {
+	s := New([3]int{2, 2, 2})
+	s.Set(0, 0, 0, df.Stone{}, nil)
+	var buf bytes.Buffer
+	if err := WriteArchive(&buf, map[string]Structure{"house": s}); err != nil {
+		t.Fatalf("write archive: %v", err)
+	}
+	a, err := ReadArchive(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	if err != nil {
+		t.Fatalf("read archive: %v", err)
+	}
+	first, err := a.Load("house")
+	if err != nil {
+		t.Fatalf("load structure: %v", err)
+	}
+	first.Set(0, 0, 0, df.Dirt{}, nil)
+
+	second, err := a.Load("house")
+	if err != nil {
+		t.Fatalf("load structure: %v", err)
+	}
+	if got, _ := second.At(0, 0, 0, nil); !sameBlock(got, df.Stone{}) {
+		t.Fatalf("block at [0 0 0] = %v after modifying an earlier Load, want stone", got)
+	}
+}
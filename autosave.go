@@ -0,0 +1,196 @@
+package structure
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+// AutosaveOption is an option that changes the way an Autosaver saves its Structure.
+type AutosaveOption func(conf *autosaveConfig)
+
+// autosaveConfig holds the configuration of an Autosaver, as changed by AutosaveOptions.
+type autosaveConfig struct {
+	interval  time.Duration
+	after     int
+	writeOpts []WriteOption
+}
+
+// WithSaveInterval makes an Autosaver save its Structure every interval passed if it was changed since it was last
+// saved. The default interval is one minute. An interval of 0 or less disables saving periodically, so that the
+// Structure is only saved after a number of changes as set using WithSaveAfter, or when calling Save or Close.
+func WithSaveInterval(interval time.Duration) AutosaveOption {
+	return func(conf *autosaveConfig) {
+		conf.interval = interval
+	}
+}
+
+// WithSaveAfter makes an Autosaver save its Structure as soon as at least n positions in it were changed since it
+// was last saved, in addition to saving it periodically. Positions changed multiple times are counted once, and any
+// changes to the entities of the Structure are counted as a single change.
+func WithSaveAfter(n int) AutosaveOption {
+	return func(conf *autosaveConfig) {
+		conf.after = n
+	}
+}
+
+// WithWriteOptions makes an Autosaver pass the WriteOptions passed to Write when saving its Structure.
+func WithWriteOptions(opts ...WriteOption) AutosaveOption {
+	return func(conf *autosaveConfig) {
+		conf.writeOpts = opts
+	}
+}
+
+// Autosaver saves a Structure that is edited over a long time, such as by an in-game structure editor, to a file
+// periodically, so that the changes made are not lost if the server crashes. The Structure is written to a
+// temporary file first, which then replaces the file, so that a crash while saving never leaves behind a corrupt
+// file. Whether the Structure needs saving is decided using Structure.Dirty and Structure.EntitiesDirty: the dirty
+// state of the Structure is reset every time it is saved.
+// Because a Structure is not safe for concurrent use, it may only be changed in a function passed to Edit while
+// the Autosaver is running. An Autosaver must be closed using Close once editing is done.
+type Autosaver struct {
+	conf autosaveConfig
+	file string
+
+	mu  sync.Mutex
+	s   Structure
+	err error
+
+	closing chan struct{}
+	done    chan struct{}
+	once    sync.Once
+}
+
+// NewAutosaver creates an Autosaver that saves the Structure passed to the file passed, as configured by the
+// AutosaveOptions passed, and starts saving it in the background.
+func NewAutosaver(s Structure, file string, opts ...AutosaveOption) *Autosaver {
+	conf := autosaveConfig{interval: time.Minute}
+	for _, opt := range opts {
+		opt(&conf)
+	}
+	a := &Autosaver{conf: conf, file: file, s: s, closing: make(chan struct{}), done: make(chan struct{})}
+	go a.run()
+	return a
+}
+
+// run saves the Structure every interval until the Autosaver is closed.
+func (a *Autosaver) run() {
+	defer close(a.done)
+	if a.conf.interval <= 0 {
+		<-a.closing
+		return
+	}
+	t := time.NewTicker(a.conf.interval)
+	defer t.Stop()
+	for {
+		select {
+		case <-t.C:
+			a.mu.Lock()
+			if a.s.modified() {
+				a.save()
+			}
+			a.mu.Unlock()
+		case <-a.closing:
+			return
+		}
+	}
+}
+
+// Edit calls fn with the Structure of the Autosaver, which fn may change freely. The Structure is never saved while
+// fn runs. If the Autosaver was configured using WithSaveAfter and enough positions were changed, the Structure is
+// saved before Edit returns. fn must not hold on to the Structure after it returns.
+func (a *Autosaver) Edit(fn func(s Structure)) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	fn(a.s)
+	if a.conf.after > 0 && a.changes() >= a.conf.after {
+		a.save()
+	}
+}
+
+// Save saves the Structure immediately, regardless of whether it was changed since it was last saved, and returns
+// the error that occurred saving it, if any.
+func (a *Autosaver) Save() error {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.save()
+	return a.err
+}
+
+// Err returns the error that occurred the last time the Structure was saved, or nil if it was saved successfully.
+// As the Structure is saved in the background, Err should be checked periodically to notice failing saves.
+func (a *Autosaver) Err() error {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	return a.err
+}
+
+// Close stops saving the Structure in the background and saves it a final time if it was changed since it was last
+// saved. The error that occurred saving it is returned, if any. Close may be called multiple times.
+func (a *Autosaver) Close() error {
+	a.once.Do(func() {
+		close(a.closing)
+	})
+	<-a.done
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	if a.s.modified() {
+		a.save()
+	}
+	return a.err
+}
+
+// changes returns the number of changes made to the Structure since it was last saved, counting every changed
+// position once and any changes to its entities as a single change. changes must be called while holding the lock
+// of the Autosaver.
+func (a *Autosaver) changes() int {
+	n := a.s.dirtyCount()
+	if a.s.EntitiesDirty() {
+		n++
+	}
+	return n
+}
+
+// save writes the Structure to the file of the Autosaver and resets its dirty positions if successful. The error
+// that occurred, if any, is stored so that it may be returned by Err. save must be called while holding the lock
+// of the Autosaver.
+func (a *Autosaver) save() {
+	if a.err = writeFileAtomic(a.file, a.s, a.conf.writeOpts...); a.err == nil {
+		a.s.ResetDirty()
+	}
+}
+
+// writeFileAtomic writes a Structure to the file passed like WriteFile, but writes it to a temporary file in the
+// same directory first and renames it to the file passed once written completely. If writing fails, the file
+// passed is left unchanged.
+func writeFileAtomic(file string, s Structure, opts ...WriteOption) error {
+	f, err := os.CreateTemp(filepath.Dir(file), filepath.Base(file)+".*.tmp")
+	if err != nil {
+		return fmt.Errorf("create temporary file: %w", err)
+	}
+	tmp := f.Name()
+	w := bufio.NewWriter(f)
+	if err = Write(w, s, opts...); err == nil {
+		if err = w.Flush(); err != nil {
+			err = fmt.Errorf("flush file: %w", err)
+		} else if err = f.Sync(); err != nil {
+			err = fmt.Errorf("sync file: %w", err)
+		}
+	}
+	if closeErr := f.Close(); err == nil && closeErr != nil {
+		err = fmt.Errorf("close file: %w", closeErr)
+	}
+	if err == nil {
+		if err = os.Rename(tmp, file); err != nil {
+			err = fmt.Errorf("rename file: %w", err)
+		}
+	}
+	if err != nil {
+		_ = os.Remove(tmp)
+	}
+	return err
+}
@@ -0,0 +1,49 @@
+package structure
+
+import (
+	df "github.com/df-mc/dragonfly/server/block"
+	"github.com/go-gl/mathgl/mgl64"
+	"path/filepath"
+	"testing"
+)
+
+func TestAutosaverSaveAfter(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "house.mcstructure")
+	a := NewAutosaver(New([3]int{2, 1, 1}), file, WithSaveInterval(0), WithSaveAfter(2))
+	defer a.Close()
+
+	a.Edit(func(s Structure) {
+		s.Set(0, 0, 0, df.Stone{}, nil)
+	})
+	if _, err := ReadFile(file); err == nil {
+		t.Fatalf("structure saved after a single change, want it saved after two")
+	}
+	a.Edit(func(s Structure) {
+		s.Set(1, 0, 0, df.Stone{}, nil)
+	})
+	r, err := ReadFile(file)
+	if err != nil {
+		t.Fatalf("read saved structure: %v", err)
+	}
+	if got, _ := r.At(1, 0, 0, nil); !sameBlock(got, df.Stone{}) {
+		t.Fatalf("block saved at [1 0 0] = %v, want stone", got)
+	}
+}
+
+func TestAutosaverCloseSavesEntities(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "farm.mcstructure")
+	a := NewAutosaver(New([3]int{1, 1, 1}), file, WithSaveInterval(0))
+	a.Edit(func(s Structure) {
+		s.AddEntity("minecraft:cow", mgl64.Vec3{0.5, 0, 0.5}, nil)
+	})
+	if err := a.Close(); err != nil {
+		t.Fatalf("close autosaver: %v", err)
+	}
+	r, err := ReadFile(file)
+	if err != nil {
+		t.Fatalf("read saved structure: %v", err)
+	}
+	if got := len(r.Structure.Entities); got != 1 {
+		t.Fatalf("entities saved = %v, want 1", got)
+	}
+}
@@ -0,0 +1,202 @@
+package structure
+
+import (
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/df-mc/dragonfly/server/world/chunk"
+	"math"
+	"strconv"
+)
+
+// noRuntimeID is the runtime ID stored in a BakedStructure for positions that do not hold a block or liquid.
+const noRuntimeID = math.MaxUint32
+
+// BakedStructure is a Structure of which the palette is resolved to block runtime IDs in advance, split up into the
+// chunks it occupies when placed at a fixed origin. A BakedStructure is obtained using Structure.Bake. Because
+// every block is resolved only once, pasting a BakedStructure is considerably cheaper than pasting the Structure it
+// was baked from, which pays off when the same Structure is pasted many times, such as by a world generator.
+// A BakedStructure implements world.Structure, but it may only be built at the origin passed to Bake. It is not
+// affected by changes to the Structure it was baked from and is safe to use from multiple goroutines at the same
+// time.
+type BakedStructure struct {
+	origin cube.Pos
+	dims   [3]int
+	// minChunk is the lowest chunk position the structure occupies, and chunksZ the number of chunks it occupies
+	// along the Z axis. chunks holds the part of the structure within every chunk, ordered by X and then Z.
+	minChunk world.ChunkPos
+	chunksZ  int
+	chunks   []*bakedChunk
+	// positionData holds the block entity data of blocks in the structure, by their world position.
+	positionData map[cube.Pos]map[string]interface{}
+}
+
+// bakedChunk is the part of a BakedStructure that lies within a single chunk.
+type bakedChunk struct {
+	// min is the world position of the lowest corner of the part of the structure in the chunk, and w and l the
+	// width and length of the part. The part spans the full height of the structure.
+	min     cube.Pos
+	w, h, l int
+	// blocks and liquids hold the runtime IDs of the blocks and liquids in the part, ordered by X, Y and then Z.
+	// Positions without a block or liquid hold noRuntimeID.
+	blocks, liquids []uint32
+}
+
+// Check to ensure that *BakedStructure implements the world.Structure interface.
+var _ world.Structure = (*BakedStructure)(nil)
+
+// Bake returns a BakedStructure holding the blocks and liquids of the Structure, resolved to block runtime IDs and
+// split up into the chunks the Structure occupies when placed with its lowest corner at the origin passed. Palette
+// entries that cannot be resolved to a block are baked as positions without a block.
+func (s Structure) Bake(origin cube.Pos) *BakedStructure {
+	s.ensureParsed()
+	rids := make([]uint32, len(s.parsedPalette))
+	for i, entry := range s.parsedPalette {
+		rids[i] = noRuntimeID
+		if entry.b != nil {
+			rids[i] = world.BlockRuntimeID(entry.b)
+		}
+	}
+	runtimeID := func(index int32) uint32 {
+		if index == -1 {
+			return noRuntimeID
+		}
+		return rids[index]
+	}
+
+	dims := s.Dimensions()
+	minChunk := world.ChunkPos{int32(origin[0] >> 4), int32(origin[2] >> 4)}
+	maxChunk := world.ChunkPos{int32((origin[0] + dims[0] - 1) >> 4), int32((origin[2] + dims[2] - 1) >> 4)}
+	b := &BakedStructure{
+		origin:   origin,
+		dims:     dims,
+		minChunk: minChunk,
+		chunksZ:  int(maxChunk[1]-minChunk[1]) + 1,
+	}
+	for chunkX := minChunk[0]; chunkX <= maxChunk[0]; chunkX++ {
+		for chunkZ := minChunk[1]; chunkZ <= maxChunk[1]; chunkZ++ {
+			min := [3]int{int(chunkX)<<4 - origin[0], 0, int(chunkZ)<<4 - origin[2]}
+			max := [3]int{min[0] + 16, dims[1], min[2] + 16}
+			for _, i := range [2]int{0, 2} {
+				if min[i] < 0 {
+					min[i] = 0
+				}
+				if max[i] > dims[i] {
+					max[i] = dims[i]
+				}
+			}
+			c := &bakedChunk{min: origin.Add(cube.Pos{min[0], 0, min[2]}), w: max[0] - min[0], h: dims[1], l: max[2] - min[2]}
+			c.blocks, c.liquids = make([]uint32, 0, c.w*c.h*c.l), make([]uint32, 0, c.w*c.h*c.l)
+			for x := min[0]; x < max[0]; x++ {
+				for y := 0; y < dims[1]; y++ {
+					for z := min[2]; z < max[2]; z++ {
+						offset := (x * s.l * s.h) + (y * s.l) + z
+						c.blocks = append(c.blocks, runtimeID(s.blocks[offset]))
+						c.liquids = append(c.liquids, runtimeID(s.liquids[offset]))
+					}
+				}
+			}
+			b.chunks = append(b.chunks, c)
+		}
+	}
+	for k, data := range s.palette.BlockPositionData {
+		offset, err := strconv.Atoi(k)
+		if err != nil || offset < 0 || offset >= len(s.blocks) || data.BlockEntityData == nil {
+			continue
+		}
+		if index := s.blocks[offset]; index == -1 || !s.parsedPalette[index].hasNBT {
+			continue
+		}
+		if b.positionData == nil {
+			b.positionData = make(map[cube.Pos]map[string]interface{})
+		}
+		b.positionData[origin.Add(s.posOf(offset))] = data.BlockEntityData
+	}
+	return b
+}
+
+// Origin returns the world position of the lowest corner of the BakedStructure, as passed to Structure.Bake.
+func (b *BakedStructure) Origin() cube.Pos {
+	return b.origin
+}
+
+// Dimensions returns the dimensions of the structure.
+func (b *BakedStructure) Dimensions() [3]int {
+	return b.dims
+}
+
+// Chunks returns the positions of all chunks that the BakedStructure occupies.
+func (b *BakedStructure) Chunks() []world.ChunkPos {
+	positions := make([]world.ChunkPos, 0, len(b.chunks))
+	for i := range b.chunks {
+		positions = append(positions, world.ChunkPos{b.minChunk[0] + int32(i/b.chunksZ), b.minChunk[1] + int32(i%b.chunksZ)})
+	}
+	return positions
+}
+
+// chunk returns the part of the structure within the chunk at the position passed, or nil if the structure does
+// not occupy the chunk.
+func (b *BakedStructure) chunk(pos world.ChunkPos) *bakedChunk {
+	x, z := int(pos[0]-b.minChunk[0]), int(pos[1]-b.minChunk[1])
+	if x < 0 || z < 0 || z >= b.chunksZ || x*b.chunksZ+z >= len(b.chunks) {
+		return nil
+	}
+	return b.chunks[x*b.chunksZ+z]
+}
+
+// Blit writes the part of the BakedStructure within the chunk at the position passed directly into the
+// chunk.Chunk passed, without resolving any blocks. Blit is meant to be used by world.Generator implementations,
+// which are passed chunks to generate. As chunks do not hold block entities, blocks with block entity data, such as
+// chests, are placed without their data. Positions without a block in the structure and positions outside the
+// height range of the chunk are left untouched.
+func (b *BakedStructure) Blit(pos world.ChunkPos, c *chunk.Chunk) {
+	part := b.chunk(pos)
+	if part == nil {
+		return
+	}
+	r, air := c.Range(), world.BlockRuntimeID(nil)
+	i := 0
+	for x := 0; x < part.w; x++ {
+		for y := 0; y < part.h; y++ {
+			worldY := part.min[1] + y
+			if worldY < r[0] || worldY > r[1] {
+				i += part.l
+				continue
+			}
+			for z := 0; z < part.l; z++ {
+				localX, localZ := uint8(part.min[0]+x), uint8(part.min[2]+z)
+				if rid := part.blocks[i]; rid != noRuntimeID {
+					c.SetBlock(localX, int16(worldY), localZ, 0, rid)
+				}
+				if rid := part.liquids[i]; rid != noRuntimeID {
+					c.SetBlock(localX, int16(worldY), localZ, 1, rid)
+				} else if part.blocks[i] != noRuntimeID && len(c.SubChunk(int16(worldY)).Layers()) > 1 {
+					// Like world.World.BuildStructure, a block without a liquid removes any liquid present.
+					c.SetBlock(localX, int16(worldY), localZ, 1, air)
+				}
+				i++
+			}
+		}
+	}
+}
+
+// At returns the block and liquid at the x, y and z passed in the structure, relative to its origin. Blocks with
+// block entity data are decoded with their data every time At is called for them.
+func (b *BakedStructure) At(x, y, z int, _ func(x int, y int, z int) world.Block) (world.Block, world.Liquid) {
+	pos := b.origin.Add(cube.Pos{x, y, z})
+	part := b.chunk(world.ChunkPos{int32(pos[0] >> 4), int32(pos[2] >> 4)})
+	i := ((pos[0]-part.min[0])*part.h+y)*part.l + (pos[2] - part.min[2])
+
+	var bl world.Block
+	if rid := part.blocks[i]; rid != noRuntimeID {
+		bl, _ = world.BlockByRuntimeID(rid)
+		if data, ok := b.positionData[pos]; ok {
+			bl = bl.(world.NBTer).DecodeNBT(data).(world.Block)
+		}
+	}
+	if rid := part.liquids[i]; rid != noRuntimeID {
+		l, _ := world.BlockByRuntimeID(rid)
+		liq, _ := l.(world.Liquid)
+		return bl, liq
+	}
+	return bl, nil
+}
@@ -0,0 +1,286 @@
+// Package blockcolour maps blocks to the colours with which they are drawn in rendered images and matched
+// against when importing images. The built-in colours of vanilla blocks may be overridden, and colours may be
+// added for custom blocks, either globally using Default or per resource pack using a Table created with
+// NewTable.
+package blockcolour
+
+import (
+	"fmt"
+	"image/color"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// Table maps blocks to colours. Colours set in a Table take precedence over those of its parent, so that a
+// Table may override the colours of only some blocks, for example those changed by a resource pack. A Table
+// is safe for concurrent use.
+type Table struct {
+	parent *Table
+
+	mu     sync.RWMutex
+	blocks map[string]color.RGBA
+	states map[string]color.RGBA
+}
+
+// Default is the Table used by default. Colours not set in Default are looked up in the built-in colours of
+// vanilla blocks, so colours set in Default override the built-in colours.
+var Default = NewTable(nil)
+
+// NewTable creates a new, empty Table that looks up the colours of blocks not set in it in the parent Table
+// passed. If parent is nil, the built-in colours of vanilla blocks are used instead.
+func NewTable(parent *Table) *Table {
+	return &Table{parent: parent, blocks: map[string]color.RGBA{}, states: map[string]color.RGBA{}}
+}
+
+// Set sets the colour of all states of the block with the name passed, such as 'minecraft:stone' or
+// 'mypack:ruby_block'.
+func (t *Table) Set(name string, c color.RGBA) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.blocks[name] = c
+}
+
+// SetState sets the colour of the block with the name and states passed. A colour set using SetState takes
+// precedence over a colour set for the same block using Set.
+func (t *Table) SetState(name string, states map[string]interface{}, c color.RGBA) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.states[stateKey(name, states)] = c
+}
+
+// Of returns the colour of a block with the name and states passed. If the block has no known colour, false is
+// returned.
+func (t *Table) Of(name string, states map[string]interface{}) (color.RGBA, bool) {
+	t.mu.RLock()
+	c, ok := t.states[stateKey(name, states)]
+	if !ok {
+		c, ok = t.blocks[name]
+	}
+	t.mu.RUnlock()
+	if ok {
+		return c, true
+	}
+	if t.parent != nil {
+		return t.parent.Of(name, states)
+	}
+	return builtin(name, states)
+}
+
+// Of returns the colour of a block with the name and states passed using the Default Table. If the block has no
+// known colour, false is returned.
+func Of(name string, states map[string]interface{}) (color.RGBA, bool) {
+	return Default.Of(name, states)
+}
+
+// stateKey returns a key that uniquely identifies the block with the name and states passed.
+func stateKey(name string, states map[string]interface{}) string {
+	keys := make([]string, 0, len(states))
+	for k := range states {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	var sb strings.Builder
+	sb.WriteString(name)
+	for _, k := range keys {
+		_, _ = fmt.Fprintf(&sb, ",%v=%v", k, states[k])
+	}
+	return sb.String()
+}
+
+// builtin returns the built-in colour of a vanilla block with the name and states passed. If the block has no
+// built-in colour, false is returned.
+func builtin(name string, states map[string]interface{}) (color.RGBA, bool) {
+	name = strings.TrimPrefix(name, "minecraft:")
+	if colours, ok := dyedBlocks[name]; ok {
+		if c, ok := states["color"].(string); ok {
+			if rgba, ok := colours[c]; ok {
+				return rgba, true
+			}
+		}
+	}
+	// Newer versions of Minecraft have separate blocks for every colour of dyed blocks, such as 'red_wool'.
+	for dye := range dyeColours {
+		if strings.HasPrefix(name, dye+"_") {
+			if colours, ok := dyedBlocks[name[len(dye)+1:]]; ok {
+				return colours[dye], true
+			}
+		}
+	}
+	if c, ok := blockColours[name]; ok {
+		return c, true
+	}
+	// Many blocks share a suffix with a block of a similar colour, such as 'oak_stairs' and 'planks'.
+	for _, suffix := range suffixColours {
+		if strings.HasSuffix(name, suffix.suffix) {
+			return suffix.c, true
+		}
+	}
+	return color.RGBA{}, false
+}
+
+// dyedBlocks maps the names of blocks that have a 'color' state, which determines their colour, to the colours
+// of every value of that state.
+var dyedBlocks = map[string]map[string]color.RGBA{
+	"wool":                  dyeColours,
+	"carpet":                dyeColours,
+	"concrete":              dyeColours,
+	"concrete_powder":       dyeColours,
+	"stained_glass":         dyeColours,
+	"stained_glass_pane":    dyeColours,
+	"shulker_box":           dyeColours,
+	"stained_hardened_clay": terracottaColours,
+	"terracotta":            terracottaColours,
+}
+
+// dyeColours maps the values of the 'color' state of dyed blocks to their colour.
+var dyeColours = map[string]color.RGBA{
+	"white":      {R: 0xe9, G: 0xec, B: 0xec, A: 0xff},
+	"orange":     {R: 0xf0, G: 0x76, B: 0x13, A: 0xff},
+	"magenta":    {R: 0xbd, G: 0x44, B: 0xb3, A: 0xff},
+	"light_blue": {R: 0x3a, G: 0xaf, B: 0xd9, A: 0xff},
+	"yellow":     {R: 0xf8, G: 0xc6, B: 0x27, A: 0xff},
+	"lime":       {R: 0x70, G: 0xb9, B: 0x19, A: 0xff},
+	"pink":       {R: 0xed, G: 0x8d, B: 0xac, A: 0xff},
+	"gray":       {R: 0x3e, G: 0x44, B: 0x47, A: 0xff},
+	"silver":     {R: 0x8e, G: 0x8e, B: 0x86, A: 0xff},
+	"light_gray": {R: 0x8e, G: 0x8e, B: 0x86, A: 0xff},
+	"cyan":       {R: 0x15, G: 0x89, B: 0x91, A: 0xff},
+	"purple":     {R: 0x79, G: 0x2a, B: 0xac, A: 0xff},
+	"blue":       {R: 0x35, G: 0x39, B: 0x9d, A: 0xff},
+	"brown":      {R: 0x72, G: 0x47, B: 0x28, A: 0xff},
+	"green":      {R: 0x54, G: 0x6d, B: 0x1b, A: 0xff},
+	"red":        {R: 0xa1, G: 0x27, B: 0x22, A: 0xff},
+	"black":      {R: 0x14, G: 0x15, B: 0x19, A: 0xff},
+}
+
+// terracottaColours maps the values of the 'color' state of stained terracotta to their colour, which is more
+// muted than that of other dyed blocks.
+var terracottaColours = map[string]color.RGBA{
+	"white":      {R: 0xd1, G: 0xb1, B: 0xa1, A: 0xff},
+	"orange":     {R: 0x9f, G: 0x52, B: 0x24, A: 0xff},
+	"magenta":    {R: 0x95, G: 0x57, B: 0x6c, A: 0xff},
+	"light_blue": {R: 0x70, G: 0x6c, B: 0x8a, A: 0xff},
+	"yellow":     {R: 0xba, G: 0x85, B: 0x24, A: 0xff},
+	"lime":       {R: 0x67, G: 0x75, B: 0x35, A: 0xff},
+	"pink":       {R: 0xa0, G: 0x4d, B: 0x4e, A: 0xff},
+	"gray":       {R: 0x39, G: 0x29, B: 0x23, A: 0xff},
+	"silver":     {R: 0x87, G: 0x6b, B: 0x62, A: 0xff},
+	"light_gray": {R: 0x87, G: 0x6b, B: 0x62, A: 0xff},
+	"cyan":       {R: 0x57, G: 0x5c, B: 0x5c, A: 0xff},
+	"purple":     {R: 0x7a, G: 0x49, B: 0x58, A: 0xff},
+	"blue":       {R: 0x4c, G: 0x3e, B: 0x5c, A: 0xff},
+	"brown":      {R: 0x4c, G: 0x32, B: 0x23, A: 0xff},
+	"green":      {R: 0x4c, G: 0x52, B: 0x2a, A: 0xff},
+	"red":        {R: 0x8e, G: 0x3c, B: 0x2e, A: 0xff},
+	"black":      {R: 0x25, G: 0x16, B: 0x10, A: 0xff},
+}
+
+// blockColours maps the names of blocks, without namespace, to their colour.
+var blockColours = map[string]color.RGBA{
+	"stone":             {R: 0x7d, G: 0x7d, B: 0x7d, A: 0xff},
+	"cobblestone":       {R: 0x7a, G: 0x7a, B: 0x7a, A: 0xff},
+	"mossy_cobblestone": {R: 0x6e, G: 0x76, B: 0x5e, A: 0xff},
+	"stonebrick":        {R: 0x7a, G: 0x79, B: 0x7a, A: 0xff},
+	"deepslate":         {R: 0x50, G: 0x50, B: 0x52, A: 0xff},
+	"cobbled_deepslate": {R: 0x4d, G: 0x4d, B: 0x50, A: 0xff},
+	"andesite":          {R: 0x88, G: 0x88, B: 0x88, A: 0xff},
+	"diorite":           {R: 0xbc, G: 0xbc, B: 0xbc, A: 0xff},
+	"granite":           {R: 0x95, G: 0x67, B: 0x55, A: 0xff},
+	"bedrock":           {R: 0x55, G: 0x55, B: 0x55, A: 0xff},
+	"grass":             {R: 0x7f, G: 0xb2, B: 0x38, A: 0xff},
+	"dirt":              {R: 0x86, G: 0x60, B: 0x43, A: 0xff},
+	"grass_path":        {R: 0x94, G: 0x7a, B: 0x41, A: 0xff},
+	"farmland":          {R: 0x8f, G: 0x66, B: 0x46, A: 0xff},
+	"podzol":            {R: 0x5b, G: 0x3f, B: 0x18, A: 0xff},
+	"mycelium":          {R: 0x6f, G: 0x63, B: 0x69, A: 0xff},
+	"mud":               {R: 0x3c, G: 0x39, B: 0x3d, A: 0xff},
+	"clay":              {R: 0xa0, G: 0xa6, B: 0xb3, A: 0xff},
+	"sand":              {R: 0xdb, G: 0xcf, B: 0xa3, A: 0xff},
+	"sandstone":         {R: 0xd8, G: 0xcb, B: 0x9b, A: 0xff},
+	"red_sandstone":     {R: 0xba, G: 0x63, B: 0x1d, A: 0xff},
+	"gravel":            {R: 0x83, G: 0x7f, B: 0x7e, A: 0xff},
+	"snow":              {R: 0xf9, G: 0xfe, B: 0xfe, A: 0xff},
+	"snow_layer":        {R: 0xf9, G: 0xfe, B: 0xfe, A: 0xff},
+	"ice":               {R: 0x91, G: 0xb7, B: 0xfd, A: 0xff},
+	"packed_ice":        {R: 0x8d, G: 0xb4, B: 0xfa, A: 0xff},
+	"blue_ice":          {R: 0x74, G: 0xa8, B: 0xfd, A: 0xff},
+	"water":             {R: 0x40, G: 0x40, B: 0xff, A: 0xff},
+	"flowing_water":     {R: 0x40, G: 0x40, B: 0xff, A: 0xff},
+	"lava":              {R: 0xcf, G: 0x5b, B: 0x13, A: 0xff},
+	"flowing_lava":      {R: 0xcf, G: 0x5b, B: 0x13, A: 0xff},
+	"planks":            {R: 0xa2, G: 0x82, B: 0x4e, A: 0xff},
+	"log":               {R: 0x6d, G: 0x55, B: 0x32, A: 0xff},
+	"log2":              {R: 0x6d, G: 0x55, B: 0x32, A: 0xff},
+	"leaves":            {R: 0x3b, G: 0x7a, B: 0x1f, A: 0xff},
+	"leaves2":           {R: 0x3b, G: 0x7a, B: 0x1f, A: 0xff},
+	"glass":             {R: 0xc0, G: 0xe0, B: 0xe8, A: 0xff},
+	"glass_pane":        {R: 0xc0, G: 0xe0, B: 0xe8, A: 0xff},
+	"brick_block":       {R: 0x96, G: 0x61, B: 0x53, A: 0xff},
+	"hardened_clay":     {R: 0x98, G: 0x5e, B: 0x43, A: 0xff},
+	"obsidian":          {R: 0x0f, G: 0x0a, B: 0x18, A: 0xff},
+	"netherrack":        {R: 0x61, G: 0x26, B: 0x26, A: 0xff},
+	"nether_brick":      {R: 0x2c, G: 0x15, B: 0x1a, A: 0xff},
+	"soul_sand":         {R: 0x51, G: 0x3e, B: 0x32, A: 0xff},
+	"end_stone":         {R: 0xdb, G: 0xde, B: 0x9e, A: 0xff},
+	"quartz_block":      {R: 0xec, G: 0xe6, B: 0xdf, A: 0xff},
+	"prismarine":        {R: 0x63, G: 0x9f, B: 0x93, A: 0xff},
+	"purpur_block":      {R: 0xa9, G: 0x7d, B: 0xa9, A: 0xff},
+	"bookshelf":         {R: 0x75, G: 0x5e, B: 0x3b, A: 0xff},
+	"crafting_table":    {R: 0x77, G: 0x5a, B: 0x38, A: 0xff},
+	"chest":             {R: 0xa2, G: 0x7a, B: 0x3a, A: 0xff},
+	"furnace":           {R: 0x6e, G: 0x6e, B: 0x6e, A: 0xff},
+	"hay_block":         {R: 0xa6, G: 0x88, B: 0x0c, A: 0xff},
+	"pumpkin":           {R: 0xc6, G: 0x76, B: 0x18, A: 0xff},
+	"melon_block":       {R: 0x6f, G: 0x91, B: 0x1e, A: 0xff},
+	"cactus":            {R: 0x55, G: 0x7f, B: 0x2b, A: 0xff},
+	"tallgrass":         {R: 0x6b, G: 0x9c, B: 0x34, A: 0xff},
+	"gold_block":        {R: 0xf6, G: 0xd0, B: 0x3d, A: 0xff},
+	"iron_block":        {R: 0xdc, G: 0xdc, B: 0xdc, A: 0xff},
+	"diamond_block":     {R: 0x62, G: 0xed, B: 0xe4, A: 0xff},
+	"emerald_block":     {R: 0x2a, G: 0xcb, B: 0x57, A: 0xff},
+	"lapis_block":       {R: 0x1f, G: 0x43, B: 0x8c, A: 0xff},
+	"redstone_block":    {R: 0xaf, G: 0x18, B: 0x05, A: 0xff},
+	"coal_block":        {R: 0x10, G: 0x10, B: 0x10, A: 0xff},
+	"glowstone":         {R: 0xfb, G: 0xda, B: 0x74, A: 0xff},
+	"sea_lantern":       {R: 0xac, G: 0xc8, B: 0xbe, A: 0xff},
+	"tnt":               {R: 0xdb, G: 0x44, B: 0x1a, A: 0xff},
+	"blackstone":        {R: 0x2a, G: 0x23, B: 0x28, A: 0xff},
+	"basalt":            {R: 0x50, G: 0x51, B: 0x56, A: 0xff},
+	"calcite":           {R: 0xdf, G: 0xe0, B: 0xdc, A: 0xff},
+	"tuff":              {R: 0x6c, G: 0x6d, B: 0x66, A: 0xff},
+	"amethyst_block":    {R: 0x85, G: 0x62, B: 0xbf, A: 0xff},
+	"copper_block":      {R: 0xc0, G: 0x6b, B: 0x4f, A: 0xff},
+	"moss_block":        {R: 0x59, G: 0x6e, B: 0x2d, A: 0xff},
+	"honey_block":       {R: 0xfb, G: 0xb9, B: 0x34, A: 0xff},
+	"slime":             {R: 0x6f, G: 0xc0, B: 0x5b, A: 0xff},
+	"kelp":              {R: 0x57, G: 0x82, B: 0x2b, A: 0xff},
+	"seagrass":          {R: 0x33, G: 0x7a, B: 0x1c, A: 0xff},
+	"waterlily":         {R: 0x20, G: 0x80, B: 0x30, A: 0xff},
+	"torch":             {R: 0xff, G: 0xd8, B: 0x6a, A: 0xff},
+	"lantern":           {R: 0x48, G: 0x4a, B: 0x4f, A: 0xff},
+}
+
+// suffixColours holds the colours of blocks by the suffix of their name. It is used for blocks that are not
+// present in blockColours, such as stairs, slabs, fences and walls, which typically share the colour of the
+// block they are made of.
+var suffixColours = []struct {
+	suffix string
+	c      color.RGBA
+}{
+	{suffix: "_planks", c: color.RGBA{R: 0xa2, G: 0x82, B: 0x4e, A: 0xff}},
+	{suffix: "_log", c: color.RGBA{R: 0x6d, G: 0x55, B: 0x32, A: 0xff}},
+	{suffix: "_leaves", c: color.RGBA{R: 0x3b, G: 0x7a, B: 0x1f, A: 0xff}},
+	{suffix: "_wool", c: color.RGBA{R: 0xe9, G: 0xec, B: 0xec, A: 0xff}},
+	{suffix: "_ore", c: color.RGBA{R: 0x7d, G: 0x7d, B: 0x7d, A: 0xff}},
+	{suffix: "_stairs", c: color.RGBA{R: 0x8f, G: 0x7f, B: 0x6a, A: 0xff}},
+	{suffix: "_slab", c: color.RGBA{R: 0x8f, G: 0x7f, B: 0x6a, A: 0xff}},
+	{suffix: "_fence", c: color.RGBA{R: 0xa2, G: 0x82, B: 0x4e, A: 0xff}},
+	{suffix: "_fence_gate", c: color.RGBA{R: 0xa2, G: 0x82, B: 0x4e, A: 0xff}},
+	{suffix: "_wall", c: color.RGBA{R: 0x7a, G: 0x7a, B: 0x7a, A: 0xff}},
+	{suffix: "_door", c: color.RGBA{R: 0x9c, G: 0x7c, B: 0x4a, A: 0xff}},
+	{suffix: "_trapdoor", c: color.RGBA{R: 0x9c, G: 0x7c, B: 0x4a, A: 0xff}},
+	{suffix: "_copper", c: color.RGBA{R: 0x6a, G: 0xa6, B: 0x8c, A: 0xff}},
+	{suffix: "_terracotta", c: color.RGBA{R: 0x98, G: 0x5e, B: 0x43, A: 0xff}},
+	{suffix: "_flower", c: color.RGBA{R: 0xd0, G: 0x4a, B: 0x4a, A: 0xff}},
+}
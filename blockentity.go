@@ -0,0 +1,121 @@
+package structure
+
+import (
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/world"
+	"sort"
+	"strconv"
+)
+
+// BlockEntity is a block with block entity data found in a Structure using BlockEntities.
+type BlockEntity struct {
+	// Position is the position of the block relative to the origin of the Structure.
+	Position cube.Pos
+	// Name is the name of the block, such as 'minecraft:chest'.
+	Name string
+	// Block is the block with its block entity data decoded into it. It is nil if the block is not registered, as
+	// is the case for command blocks, for example.
+	Block world.Block
+	// Data is the raw block entity data of the block. It must not be modified.
+	Data map[string]interface{}
+}
+
+// BlockEntities returns all blocks in the structure that have block entity data, ordered by their position, x
+// first, then y, then z. Unlike CommandBlocks and Spawners, BlockEntities finds blocks using the block entity data
+// of the structure, rather than by scanning every block in it, so it is cheap for large structures. Servers may use
+// BlockEntities to audit structures from untrusted sources, for example to count their command blocks or list their
+// chests. Block entity data left at positions without a block is not returned.
+func (s *structure) BlockEntities() []BlockEntity {
+	s.ensureParsed()
+	offsets := make([]int, 0, len(s.palette.BlockPositionData))
+	for k, data := range s.palette.BlockPositionData {
+		offset, err := strconv.Atoi(k)
+		if err != nil || offset < 0 || offset >= len(s.blocks) || data.BlockEntityData == nil || s.blocks[offset] == -1 {
+			continue
+		}
+		offsets = append(offsets, offset)
+	}
+	sort.Ints(offsets)
+
+	entities := make([]BlockEntity, 0, len(offsets))
+	for _, offset := range offsets {
+		index := s.blocks[offset]
+		data := s.palette.BlockPositionData[strconv.Itoa(offset)].BlockEntityData
+		e := BlockEntity{Position: s.posOf(offset), Name: s.palette.BlockPalette[index].Name, Data: data}
+		if entry := s.parsedPalette[index]; entry.b != nil {
+			e.Block = entry.b
+			if entry.hasNBT {
+				e.Block = entry.b.(world.NBTer).DecodeNBT(data).(world.Block)
+			}
+		}
+		entities = append(entities, e)
+	}
+	return entities
+}
+
+// RewriteNBT calls fn for every entry of block entity data in the structure, with the position of the entry
+// relative to the origin of the structure, and replaces the data with the data returned by fn. If fn
+// returns nil, the block entity data is removed. RewriteNBT may be used to change block entity data throughout a
+// structure at once, such as to rewrite the text on signs, clear the items in chests or change the commands of
+// command blocks. The data passed to fn is a copy, which fn may modify and return. Entries are visited ordered by
+// their position, x first, then y, then z.
+func (s *structure) RewriteNBT(fn func(pos cube.Pos, data map[string]interface{}) map[string]interface{}) {
+	offsets := make([]int, 0, len(s.palette.BlockPositionData))
+	for k, data := range s.palette.BlockPositionData {
+		offset, err := strconv.Atoi(k)
+		if err != nil || offset < 0 || offset >= len(s.blocks) || data.BlockEntityData == nil {
+			continue
+		}
+		offsets = append(offsets, offset)
+	}
+	sort.Ints(offsets)
+
+	for _, offset := range offsets {
+		k := strconv.Itoa(offset)
+		data := s.palette.BlockPositionData[k]
+		rewritten := fn(s.posOf(offset), copyNBT(data.BlockEntityData).(map[string]interface{}))
+		s.markDirty(offset)
+		if rewritten == nil {
+			delete(s.palette.BlockPositionData, k)
+			continue
+		}
+		data.BlockEntityData = rewritten
+		s.palette.BlockPositionData[k] = data
+	}
+}
+
+// copyNBT returns a deep copy of the NBT value passed, copying all maps and slices in it, so that the copy may be
+// modified without affecting the original.
+func copyNBT(v interface{}) interface{} {
+	switch v := v.(type) {
+	case map[string]interface{}:
+		m := make(map[string]interface{}, len(v))
+		for k, val := range v {
+			m[k] = copyNBT(val)
+		}
+		return m
+	case []interface{}:
+		s := make([]interface{}, len(v))
+		for i, val := range v {
+			s[i] = copyNBT(val)
+		}
+		return s
+	case []map[string]interface{}:
+		s := make([]map[string]interface{}, len(v))
+		for i, val := range v {
+			s[i] = copyNBT(val).(map[string]interface{})
+		}
+		return s
+	case []byte:
+		return append([]byte(nil), v...)
+	case []int32:
+		return append([]int32(nil), v...)
+	case []int64:
+		return append([]int64(nil), v...)
+	case []string:
+		return append([]string(nil), v...)
+	case []float32:
+		return append([]float32(nil), v...)
+	}
+	return v
+}
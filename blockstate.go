@@ -0,0 +1,163 @@
+package structure
+
+import (
+	"fmt"
+	"github.com/df-mc/dragonfly/server/world/chunk"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// BlockState is an edition-neutral representation of a block, made up of its name and properties, with all
+// property values stored as strings. Formats other than .mcstructure, such as Sponge schematics or Litematica
+// files, store their palettes in the same way, so converters for such formats only need to convert their own
+// palettes to and from BlockStates rather than to and from every other format.
+// A BlockState may be written as a string in the common 'name[property=value,...]' notation using String and
+// parsed again using ParseBlockState.
+type BlockState struct {
+	// Name is the name of the block, including its namespace, such as 'minecraft:stone'.
+	Name string
+	// Properties holds the properties of the block, such as 'stone_type' for stone. Properties may be nil if
+	// the block has no properties.
+	Properties map[string]string
+}
+
+// String returns the BlockState in the 'name[property=value,...]' notation, with its properties sorted by name.
+// If the BlockState has no properties, only its name is returned.
+func (b BlockState) String() string {
+	if len(b.Properties) == 0 {
+		return b.Name
+	}
+	keys := make([]string, 0, len(b.Properties))
+	for k := range b.Properties {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	var sb strings.Builder
+	sb.WriteString(b.Name)
+	sb.WriteByte('[')
+	for i, k := range keys {
+		if i != 0 {
+			sb.WriteByte(',')
+		}
+		sb.WriteString(k)
+		sb.WriteByte('=')
+		sb.WriteString(b.Properties[k])
+	}
+	sb.WriteByte(']')
+	return sb.String()
+}
+
+// ParseBlockState parses a BlockState from a string in the 'name[property=value,...]' notation, such as
+// 'minecraft:oak_stairs[upside_down_bit=false,weirdo_direction=2]'. If the string has no namespace, the
+// 'minecraft' namespace is used.
+func ParseBlockState(str string) (BlockState, error) {
+	name, props := str, ""
+	if i := strings.IndexByte(str, '['); i != -1 {
+		if !strings.HasSuffix(str, "]") {
+			return BlockState{}, fmt.Errorf("parse block state %v: missing closing bracket", str)
+		}
+		name, props = str[:i], str[i+1:len(str)-1]
+	}
+	if name == "" {
+		return BlockState{}, fmt.Errorf("parse block state %v: missing name", str)
+	}
+	if !strings.Contains(name, ":") {
+		name = "minecraft:" + name
+	}
+	b := BlockState{Name: name}
+	if props == "" {
+		return b, nil
+	}
+	b.Properties = map[string]string{}
+	for _, prop := range strings.Split(props, ",") {
+		k, v, ok := strings.Cut(prop, "=")
+		if !ok || k == "" {
+			return BlockState{}, fmt.Errorf("parse block state %v: invalid property %v", str, prop)
+		}
+		b.Properties[strings.TrimSpace(k)] = strings.TrimSpace(v)
+	}
+	return b, nil
+}
+
+// blockStateOf returns the BlockState of the palette entry passed, upgraded to the current block version.
+func blockStateOf(bl block) BlockState {
+	upgraded := upgradeEntry(bl)
+	b := BlockState{Name: upgraded.Name}
+	if len(upgraded.States) != 0 {
+		b.Properties = make(map[string]string, len(upgraded.States))
+		for k, v := range upgraded.States {
+			switch v := v.(type) {
+			case uint8:
+				// Boolean states are stored as bytes.
+				b.Properties[k] = strconv.FormatBool(v != 0)
+			default:
+				b.Properties[k] = fmt.Sprint(v)
+			}
+		}
+	}
+	return b
+}
+
+// entry returns the palette entry of the BlockState. Because BlockStates do not store the types of their
+// property values, the types are derived from the values: 'true' and 'false' are stored as bytes, integers
+// as int32s and all other values as strings, which matches the types Bedrock Edition uses for block states.
+func (b BlockState) entry() block {
+	states := make(map[string]interface{}, len(b.Properties))
+	for k, v := range b.Properties {
+		if v == "true" || v == "false" {
+			states[k] = boolByte(v == "true")
+		} else if n, err := strconv.ParseInt(v, 10, 32); err == nil {
+			states[k] = int32(n)
+		} else {
+			states[k] = v
+		}
+	}
+	return block{Name: b.Name, States: states, Version: chunk.CurrentBlockVersion}
+}
+
+// boolByte returns 1 if b is true and 0 otherwise.
+func boolByte(b bool) uint8 {
+	if b {
+		return 1
+	}
+	return 0
+}
+
+// BlockStates returns the BlockState of every entry in the palette currently in use by the structure, upgraded to
+// the current block version. The index of a BlockState in the slice returned corresponds to the palette index
+// returned by IndexAt.
+func (s *structure) BlockStates() []BlockState {
+	states := make([]BlockState, len(s.palette.BlockPalette))
+	for i, bl := range s.palette.BlockPalette {
+		states[i] = blockStateOf(bl)
+	}
+	return states
+}
+
+// NewFromBlockStates creates a new Structure with the dimensions passed from a palette of BlockStates and the
+// palette indices of every position. The indices are ordered the same way as in a Structure: x first, then y,
+// then z, so the index of position (x, y, z) is found at indices[(x*height+y)*length+z]. An index of -1 results
+// in a position that does not hold a block. NewFromBlockStates returns an error if the number of indices does
+// not match the dimensions or if an index is out of range of the palette.
+func NewFromBlockStates(dimensions [3]int, palette []BlockState, indices []int32) (Structure, error) {
+	if n := dimensions[0] * dimensions[1] * dimensions[2]; len(indices) != n {
+		return Structure{}, fmt.Errorf("expected %v indices for dimensions %v, got %v", n, dimensions, len(indices))
+	}
+	s := New(dimensions)
+	ptrs := make([]int32, len(palette))
+	for i, b := range palette {
+		ptrs[i] = s.ptrForEntry(b.entry())
+	}
+	for offset, index := range indices {
+		if index < -1 || int(index) >= len(palette) {
+			return Structure{}, fmt.Errorf("index %v at offset %v out of range of palette with %v entries", index, offset, len(palette))
+		}
+		s.blocks[offset] = -1
+		if index != -1 {
+			s.blocks[offset] = ptrs[index]
+		}
+	}
+	return s, nil
+}
@@ -0,0 +1,117 @@
+// Package blueprint exports structure.Structures as layer-by-layer blueprints, which may be used to generate
+// step-by-step build guides for players.
+package blueprint
+
+import (
+	"github.com/df-mc/structure"
+	"sort"
+)
+
+// Blueprint describes a structure.Structure layer by layer, from the bottom of the structure to the top. Every
+// distinct block in the structure is represented by a single symbol. A Blueprint may be encoded using
+// encoding/json.
+type Blueprint struct {
+	// Width and Length are the sizes of the structure along the x and z axes, which are the sizes of every
+	// layer of the Blueprint.
+	Width  int `json:"width"`
+	Length int `json:"length"`
+	// Legend maps every symbol used in the Blueprint to the name of the block it represents, such as
+	// 'minecraft:stone'.
+	Legend map[string]string `json:"legend"`
+	// Layers holds one Layer for every y in the structure, ordered from the bottom of the structure to the top.
+	Layers []Layer `json:"layers"`
+}
+
+// Layer is a single horizontal slice of a Blueprint.
+type Layer struct {
+	// Y is the y of the Layer, relative to the bottom of the structure.
+	Y int `json:"y"`
+	// Rows holds one row of symbols for every z in the structure, ordered from north to south. Every row holds
+	// one symbol for every x in the structure, ordered from west to east. Positions without a block, or
+	// holding air, are represented by Empty.
+	Rows []string `json:"rows"`
+	// Counts holds the number of blocks of every name in the Layer, excluding air.
+	Counts map[string]int `json:"counts"`
+}
+
+// Empty is the symbol used for positions that do not hold a block or that hold air.
+const Empty = '.'
+
+// symbols holds the symbols assigned to blocks, in the order in which they are assigned.
+const symbols = "#ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz0123456789@$%&*+=?!~^"
+
+// New creates a Blueprint of the Structure passed. Symbols are assigned to blocks in order of how often they
+// occur in the Structure, so that the most common block is assigned '#'. Blocks are identified by their name
+// only, so blocks such as stairs facing different directions share a symbol.
+func New(s structure.Structure) Blueprint {
+	dims := s.Dimensions()
+	names, counts := blockNames(s), map[string]int{}
+	for x := 0; x < dims[0]; x++ {
+		for y := 0; y < dims[1]; y++ {
+			for z := 0; z < dims[2]; z++ {
+				if index := s.IndexAt(x, y, z, 0); index != -1 && names[index] != "" {
+					counts[names[index]]++
+				}
+			}
+		}
+	}
+	legend, symbolOf := assignSymbols(counts)
+
+	b := Blueprint{Width: dims[0], Length: dims[2], Legend: legend, Layers: make([]Layer, dims[1])}
+	for y := 0; y < dims[1]; y++ {
+		layer := Layer{Y: y, Rows: make([]string, dims[2]), Counts: map[string]int{}}
+		for z := 0; z < dims[2]; z++ {
+			row := make([]rune, dims[0])
+			for x := 0; x < dims[0]; x++ {
+				row[x] = Empty
+				if index := s.IndexAt(x, y, z, 0); index != -1 && names[index] != "" {
+					row[x] = symbolOf[names[index]]
+					layer.Counts[names[index]]++
+				}
+			}
+			layer.Rows[z] = string(row)
+		}
+		b.Layers[y] = layer
+	}
+	return b
+}
+
+// blockNames returns the name of the block of every entry in the palette of the Structure passed. Air has an
+// empty name.
+func blockNames(s structure.Structure) []string {
+	names := make([]string, s.PaletteSize())
+	for i := range names {
+		if name, _, _ := s.PaletteEntry(i); name != "minecraft:air" {
+			names[i] = name
+		}
+	}
+	return names
+}
+
+// assignSymbols assigns a symbol to every block name in the counts passed, assigning symbols to the most common
+// blocks first. It returns the legend mapping symbols to names and a map from names to symbols.
+func assignSymbols(counts map[string]int) (map[string]string, map[string]rune) {
+	names := make([]string, 0, len(counts))
+	for name := range counts {
+		names = append(names, name)
+	}
+	sort.Slice(names, func(i, j int) bool {
+		if counts[names[i]] != counts[names[j]] {
+			return counts[names[i]] > counts[names[j]]
+		}
+		return names[i] < names[j]
+	})
+	legend, symbolOf := make(map[string]string, len(names)), make(map[string]rune, len(names))
+	available := []rune(symbols)
+	for i, name := range names {
+		var r rune
+		if i < len(available) {
+			r = available[i]
+		} else {
+			// We ran out of ASCII symbols, so continue with Latin Extended-A characters.
+			r = rune(0x100 + i - len(available))
+		}
+		legend[string(r)], symbolOf[name] = name, r
+	}
+	return legend, symbolOf
+}
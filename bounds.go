@@ -0,0 +1,107 @@
+package structure
+
+import (
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/go-gl/mathgl/mgl64"
+	"math"
+)
+
+// BBox returns the cube.BBox that the Structure occupies in the world when built at the position passed using
+// Build with the BuildOptions passed. Of these options, only WithRotation, WithMirror and WithAnchor affect the box
+// returned. BBox does not transform the Structure itself, so it is cheap to call for large Structures, for example
+// to check if a Structure may be built somewhere before building it.
+func (s Structure) BBox(pos cube.Pos, opts ...BuildOption) cube.BBox {
+	conf := &buildConfig{}
+	for _, opt := range opts {
+		opt(conf)
+	}
+	pos, dims := conf.place(s.Dimensions(), pos)
+	return cube.Box(float64(pos[0]), float64(pos[1]), float64(pos[2]), float64(pos[0]+dims[0]), float64(pos[1]+dims[1]), float64(pos[2]+dims[2]))
+}
+
+// Overlaps checks if the Structure, when built at the position passed using Build with the BuildOptions passed,
+// would overlap any of the boxes passed. Boxes that only touch the Structure do not overlap it. Overlaps may be
+// used to prevent builds from intersecting protected regions or other builds.
+func (s Structure) Overlaps(pos cube.Pos, boxes []cube.BBox, opts ...BuildOption) bool {
+	return cube.AnyIntersections(boxes, s.BBox(pos, opts...))
+}
+
+// BlockBox returns the cube.BBox covering all blocks from the position min to the position max, both inclusive,
+// such as the corners passed to the function of WithRegionCheck. The order of the two positions does not matter.
+func BlockBox(min, max cube.Pos) cube.BBox {
+	return cube.Box(float64(min[0]), float64(min[1]), float64(min[2]), float64(max[0]), float64(max[1]), float64(max[2])).
+		Extend(mgl64.Vec3{1, 1, 1})
+}
+
+// Outline returns the positions of all blocks on the edges of the area that the Structure occupies in the world
+// when built at the position passed using Build with the BuildOptions passed, as with BBox. Plugins may use
+// Outline to show a player where a Structure would be built before confirming the build, for example by sending
+// fake blocks at the positions returned. Every position is returned once.
+func (s Structure) Outline(pos cube.Pos, opts ...BuildOption) []cube.Pos {
+	conf := &buildConfig{}
+	for _, opt := range opts {
+		opt(conf)
+	}
+	min, dims := conf.place(s.Dimensions(), pos)
+	max := min.Add(cube.Pos{dims[0] - 1, dims[1] - 1, dims[2] - 1})
+
+	var outline []cube.Pos
+	for a := 0; a < 3; a++ {
+		b, c := (a+1)%3, (a+2)%3
+		start, end := min[a], max[a]
+		if a != 0 {
+			// Corners are already part of the edges along the X axis, so only the positions between the corners
+			// are added for the other axes.
+			start, end = start+1, end-1
+		}
+		for _, vb := range edgeValues(min[b], max[b]) {
+			for _, vc := range edgeValues(min[c], max[c]) {
+				var p cube.Pos
+				p[b], p[c] = vb, vc
+				for p[a] = start; p[a] <= end; p[a]++ {
+					outline = append(outline, p)
+				}
+			}
+		}
+	}
+	return outline
+}
+
+// edgeValues returns the coordinates of the two sides of an area from min to max along one axis. If min and max
+// are equal, it is only returned once.
+func edgeValues(min, max int) []int {
+	if min == max {
+		return []int{min}
+	}
+	return []int{min, max}
+}
+
+// OutlinePoints returns points along the twelve edges of the cube.BBox passed, spaced at most spacing apart, such as
+// the box returned by BBox. The points may be used to show the box to a player using particles. Every corner of the
+// box is returned once. If spacing is 0 or less, only the corners are returned.
+func OutlinePoints(box cube.BBox, spacing float64) []mgl64.Vec3 {
+	min, max := box.Min(), box.Max()
+	var points []mgl64.Vec3
+	for a := 0; a < 3; a++ {
+		b, c := (a+1)%3, (a+2)%3
+		n := 1
+		if length := max[a] - min[a]; spacing > 0 && length > spacing {
+			n = int(math.Ceil(length / spacing))
+		}
+		for _, vb := range []float64{min[b], max[b]} {
+			for _, vc := range []float64{min[c], max[c]} {
+				var p mgl64.Vec3
+				p[b], p[c] = vb, vc
+				for i := 0; i <= n; i++ {
+					if a != 0 && (i == 0 || i == n) {
+						// Corners are already part of the edges along the X axis.
+						continue
+					}
+					p[a] = min[a] + (max[a]-min[a])*float64(i)/float64(n)
+					points = append(points, p)
+				}
+			}
+		}
+	}
+	return points
+}
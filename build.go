@@ -0,0 +1,318 @@
+package structure
+
+import (
+	"context"
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/go-gl/mathgl/mgl64"
+	"time"
+)
+
+// BuildOption is an option that changes the way a Structure is built by Build.
+type BuildOption func(conf *buildConfig)
+
+// buildConfig holds the configuration of a single call to Build, as changed by BuildOptions.
+type buildConfig struct {
+	snapshot      *Structure
+	settleCtx     context.Context
+	settlePerTick int
+	entities      bool
+
+	turns    int
+	pivot    cube.Pos
+	mirror   bool
+	plane    Plane
+	anchored bool
+	anchor   cube.Pos
+
+	allowBlock  func(pos cube.Pos) bool
+	allowRegion func(min, max cube.Pos) bool
+
+	variantSeed int64
+	variants    []Variant
+
+	hook          func(pos cube.Pos, b world.Block) world.Block
+	markers       []marker
+	entityMarkers []entityMarker
+	loot          *lootConfig
+}
+
+// marker is a marker block registered using WithMarker or WithSpawnMarker, along with the function called for
+// every occurrence.
+type marker struct {
+	b       world.Block
+	replace func(w *world.World, pos cube.Pos) world.Block
+}
+
+// entityMarker is an entity tag registered using WithEntityMarker, along with the function called for every entity
+// with the tag.
+type entityMarker struct {
+	tag   string
+	spawn func(w *world.World, e Entity)
+}
+
+// WithSnapshot makes Build capture the blocks and liquids that are replaced by the Structure into a new
+// Structure, which is stored in the pointer passed. Building this snapshot at the same position afterwards
+// undoes the build. Positions that the Structure does not place any block at are left empty in the snapshot,
+// so that building the snapshot does not affect them either.
+func WithSnapshot(snapshot *Structure) BuildOption {
+	return func(conf *buildConfig) {
+		conf.snapshot = snapshot
+	}
+}
+
+// WithSettling makes Build settle the Structure after it has been built completely. Settling makes liquids in the
+// Structure start flowing and blocks affected by gravity, such as sand, start falling. Positions are settled from
+// the bottom of the Structure to the top, with at most perTick positions settled every tick, so that settling
+// large Structures does not overwhelm the world. Settling happens in the background, so Build returns before
+// settling is complete. Settling stops as soon as the context.Context passed is cancelled: the context must be
+// cancelled before the world is closed, so that no positions are settled in a closed world.
+func WithSettling(ctx context.Context, perTick int) BuildOption {
+	return func(conf *buildConfig) {
+		if perTick < 1 {
+			perTick = 1
+		}
+		conf.settleCtx, conf.settlePerTick = ctx, perTick
+	}
+}
+
+// WithEntities makes Build spawn the entities held by the Structure in the world, at their position relative to
+// the Structure. Entities of which the type is not registered in the EntityRegistry of the world are skipped.
+func WithEntities() BuildOption {
+	return func(conf *buildConfig) {
+		conf.entities = true
+	}
+}
+
+// WithRotation makes Build rotate the Structure by 90 degrees clockwise for every turn passed before building
+// it. A negative number of turns rotates the Structure anti-clockwise. The Structure is rotated around the pivot
+// passed, which is a position relative to the Structure, such as the position of an anchor block: the block at
+// the pivot ends up at the same world position as it would without rotation, and the rest of the Structure is
+// rotated around it.
+func WithRotation(turns int, pivot cube.Pos) BuildOption {
+	return func(conf *buildConfig) {
+		conf.turns, conf.pivot = turns, pivot
+	}
+}
+
+// WithMirror makes Build mirror the Structure across the plane passed before building it, like Symmetrize does for
+// one half of a Structure. Blocks facing a direction are flipped as they are mirrored. If combined with
+// WithRotation, the Structure is mirrored before it is rotated. The Structure is mirrored around the pivot passed
+// to WithRotation, or the anchor passed to WithAnchor, so that the block at that position ends up at the same
+// world position as it would without mirroring.
+func WithMirror(plane Plane) BuildOption {
+	return func(conf *buildConfig) {
+		conf.mirror, conf.plane = true, plane
+	}
+}
+
+// WithAnchor makes Build place the block at the anchor passed, a position relative to the Structure, at the
+// position passed to Build, rather than the lowest corner of the Structure. A door in the wall of a house may be
+// used as anchor, for example, so that the house may be built with its door at a specific position. The Structure
+// is rotated and mirrored around the anchor, which overrides the pivot passed to WithRotation.
+func WithAnchor(anchor cube.Pos) BuildOption {
+	return func(conf *buildConfig) {
+		conf.anchored, conf.anchor = true, anchor
+	}
+}
+
+// WithPlacementCheck makes Build call allow for every position in the world at which the Structure places a
+// block or liquid before building it. Positions for which allow returns false are left untouched, so that builds
+// may respect land claims and other protected areas. If WithEntities is also passed, entities are only spawned
+// if allow returns true for the block position they are in.
+func WithPlacementCheck(allow func(pos cube.Pos) bool) BuildOption {
+	return func(conf *buildConfig) {
+		conf.allowBlock = allow
+	}
+}
+
+// WithRegionCheck makes Build call allow once for every chunk that the Structure overlaps before building it,
+// with the inclusive corners of the part of the Structure within that chunk, in world coordinates. If allow
+// returns false, nothing is placed in that part of the Structure. WithRegionCheck is cheaper than
+// WithPlacementCheck for protection systems that protect whole chunks or regions, and may be combined with it.
+func WithRegionCheck(allow func(min, max cube.Pos) bool) BuildOption {
+	return func(conf *buildConfig) {
+		conf.allowRegion = allow
+	}
+}
+
+// WithVariants makes Build replace blocks of the Structure with the Variants passed at random before building it,
+// like Structure.Vary. Blocks are varied based on their position in the Structure before it is rotated, so the
+// same blocks are replaced for a seed regardless of WithRotation. The Structure passed to Build is not modified.
+func WithVariants(seed int64, variants ...Variant) BuildOption {
+	return func(conf *buildConfig) {
+		conf.variantSeed, conf.variants = seed, variants
+	}
+}
+
+// WithBlockHook makes Build call hook for every block of the Structure as it is placed, with the world position of
+// the block and the block, after any other transformations. The block returned by hook is placed instead, which
+// makes it possible to change blocks at the last moment without modifying the Structure, such as to give crops a
+// random growth stage. If hook returns nil, nothing is placed at the position. hook is not called for positions
+// without a block. It is called while the chunk that the block is placed in is being built, so it must not access
+// the world.World.
+func WithBlockHook(hook func(pos cube.Pos, b world.Block) world.Block) BuildOption {
+	return func(conf *buildConfig) {
+		conf.hook = hook
+	}
+}
+
+// WithMarker makes Build treat all blocks in the Structure equal to the marker block passed, including its
+// properties, as markers. Markers are placeholders put in a Structure to designate positions that need special
+// treatment when the Structure is built, such as loot chests, spawn points or the positions of entities. Positions
+// holding a marker are left untouched while building the Structure. Once the Structure is built, replace is called
+// for each of them with its world position, and the block returned is placed at the position, unless it is nil.
+// Because replace is called after building, it may access the world.World freely, for example to spawn an entity
+// at the position. WithMarker may be passed multiple times to register multiple markers.
+func WithMarker(m world.Block, replace func(pos cube.Pos) world.Block) BuildOption {
+	return func(conf *buildConfig) {
+		conf.markers = append(conf.markers, marker{b: m, replace: func(w *world.World, pos cube.Pos) world.Block {
+			return replace(pos)
+		}})
+	}
+}
+
+// WithSpawnMarker makes Build treat all blocks in the Structure equal to the marker block passed as spawn markers,
+// like WithMarker. Once the Structure is built, spawn is called for each of them with the world.World and the
+// centre of the block at the world position of the marker, so that server-side entities such as NPCs or holograms
+// may be spawned there. The marker is replaced with air afterwards. WithSpawnMarker may be passed multiple times
+// to register multiple markers, such as one for every kind of entity.
+func WithSpawnMarker(m world.Block, spawn func(w *world.World, pos mgl64.Vec3)) BuildOption {
+	return func(conf *buildConfig) {
+		conf.markers = append(conf.markers, marker{b: m, replace: func(w *world.World, pos cube.Pos) world.Block {
+			spawn(w, pos.Vec3Middle())
+			return airBlock()
+		}})
+	}
+}
+
+// WithEntityMarker makes Build treat all entities in the Structure with the tag passed in their 'Tags' list as
+// markers. Once the Structure is built, spawn is called with the world.World for every such entity, rather than
+// spawning the entity itself, even if WithEntities is passed. The Position of the Entity passed to spawn is its
+// position in the world, with the Structure rotated and mirrored as specified by the other BuildOptions. A tagged
+// armour stand may be put in a Structure to mark the spawn point of an NPC, for example. WithEntityMarker may be
+// passed multiple times to register multiple tags. If an entity has multiple tags registered, only the function of
+// the first tag registered is called.
+func WithEntityMarker(tag string, spawn func(w *world.World, e Entity)) BuildOption {
+	return func(conf *buildConfig) {
+		conf.entityMarkers = append(conf.entityMarkers, entityMarker{tag: tag, spawn: spawn})
+	}
+}
+
+// Build builds the Structure passed in the world.World passed, with its lowest corner at the position passed.
+// Without any BuildOptions, Build is equivalent to calling w.BuildStructure(pos, s).
+// Build places the blocks of the Structure chunk by chunk without triggering any block updates or liquid
+// flow, so that a partially built Structure does not collapse or flood before it is completely placed.
+// Rotating, mirroring and masking the Structure using BuildOptions does not copy it: these transformations are
+// applied to every block as it is placed, so large Structures may be built in any orientation cheaply.
+func Build(w *world.World, pos cube.Pos, s Structure, opts ...BuildOption) {
+	conf := &buildConfig{}
+	for _, opt := range opts {
+		opt(conf)
+	}
+	if len(conf.variants) != 0 {
+		s = s.Vary(conf.variantSeed, conf.variants...)
+	}
+	t := newTransformed(s, pos, conf)
+	if conf.snapshot != nil {
+		*conf.snapshot = t.snapshot(w)
+	}
+	if t.identity() {
+		w.BuildStructure(t.pos, s)
+	} else {
+		w.BuildStructure(t.pos, t)
+	}
+	t.replaceMarkers(w)
+	t.fillContainers(w)
+	if conf.entities || len(conf.entityMarkers) != 0 {
+		entities := t.entities()
+		if len(conf.entityMarkers) != 0 {
+			entities = spawnMarked(w, t.pos, entities, conf.entityMarkers)
+		}
+		if conf.entities {
+			spawnEntities(w, t.pos, entities)
+		}
+	}
+	if conf.settlePerTick > 0 {
+		go settle(conf.settleCtx, w, t.settlePositions(), conf.settlePerTick)
+	}
+}
+
+// place returns the position at which the lowest corner of a structure with the dimensions passed ends up when
+// built at the position passed with the buildConfig, and the dimensions of the structure once rotated. The
+// structure is mirrored and rotated around the pivot, or the anchor if set, so that the block at that position
+// ends up at the same world position as without mirroring and rotation.
+func (conf *buildConfig) place(dims [3]int, pos cube.Pos) (cube.Pos, [3]int) {
+	pivot := conf.pivot
+	if conf.anchored {
+		pos, pivot = pos.Sub(conf.anchor), conf.anchor
+	}
+	origin := pos.Add(pivot)
+	if conf.mirror {
+		a := conf.plane.axis()
+		pivot[a] = dims[a] - 1 - pivot[a]
+	}
+	for n := conf.turns % 4; n != 0; {
+		// Rotating the structure moves the pivot: a position at (x, z) ends up at (l-1-z, x) when rotating
+		// clockwise and at (z, w-1-x) when rotating anti-clockwise.
+		if n > 0 {
+			pivot = cube.Pos{dims[2] - 1 - pivot[2], pivot[1], pivot[0]}
+			n--
+		} else {
+			pivot = cube.Pos{pivot[2], pivot[1], dims[0] - 1 - pivot[0]}
+			n++
+		}
+		dims[0], dims[2] = dims[2], dims[0]
+	}
+	return origin.Sub(pivot), dims
+}
+
+// nextChunk returns the offset in the structure, along one horizontal axis, at which the next chunk starts after
+// the offset passed, for a structure built at the world coordinate start along that axis. The offset returned is
+// at most the size of the structure along the axis.
+func nextChunk(start, offset, size int) int {
+	if next := ((start+offset)>>4+1)<<4 - start; next < size {
+		return next
+	}
+	return size
+}
+
+// gravityAffected is a block that falls when the block below it is removed, such as sand.
+type gravityAffected interface {
+	world.NeighbourUpdateTicker
+	Solidifies(pos cube.Pos, w *world.World) bool
+}
+
+// settle settles the positions passed in the world.World passed, settling at most perTick positions every tick.
+// Liquids are settled by scheduling a block update, which makes them start flowing. Blocks affected by gravity
+// are settled by ticking them as if a neighbouring block was updated, which makes them fall if not supported.
+// settle returns once all positions are settled or the context.Context passed is cancelled.
+func settle(ctx context.Context, w *world.World, positions []cube.Pos, perTick int) {
+	t := time.NewTicker(time.Second / 20)
+	defer t.Stop()
+	for len(positions) > 0 {
+		select {
+		case <-ctx.Done():
+			return
+		default:
+		}
+		n := perTick
+		if n > len(positions) {
+			n = len(positions)
+		}
+		for _, pos := range positions[:n] {
+			if b, ok := w.Block(pos).(gravityAffected); ok {
+				b.NeighbourUpdateTick(pos, pos, w)
+			}
+			if _, ok := w.Liquid(pos); ok {
+				w.ScheduleBlockUpdate(pos, 0)
+			}
+		}
+		positions = positions[n:]
+		select {
+		case <-ctx.Done():
+			return
+		case <-t.C:
+		}
+	}
+}
@@ -0,0 +1,44 @@
+package structure
+
+import (
+	"context"
+	df "github.com/df-mc/dragonfly/server/block"
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/world"
+	"testing"
+	"time"
+)
+
+func TestSettleCancelled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	done := make(chan struct{})
+	go func() {
+		// The world is never touched once the context is cancelled, so a nil world may be passed.
+		settle(ctx, nil, []cube.Pos{{}, {1, 0, 0}}, 1)
+		close(done)
+	}()
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatalf("settle did not return after its context was cancelled")
+	}
+}
+
+func TestMarkersMatchDuplicateEntries(t *testing.T) {
+	s := New([3]int{2, 1, 1})
+	// Two palette entries for the same block, as found in structures pasted together from files.
+	name, states := df.Sponge{}.EncodeBlock()
+	s.palette.BlockPalette = append(s.palette.BlockPalette, block{Name: name, States: states}, block{Name: name, States: states})
+	s.parsedPalette = nil
+	s.blocks[0], s.blocks[1] = 1, 2
+
+	replace := func(w *world.World, pos cube.Pos) world.Block { return nil }
+	tr := newTransformed(s, cube.Pos{}, &buildConfig{markers: []marker{{b: df.Sponge{}, replace: replace}}})
+	for _, index := range []int32{1, 2} {
+		if tr.markers == nil || tr.markers[index] == nil {
+			t.Errorf("palette entry %v is not treated as a marker", index)
+		}
+	}
+}
@@ -0,0 +1,306 @@
+package structure
+
+import (
+	"github.com/df-mc/dragonfly/server/world"
+	"strconv"
+)
+
+// Builder builds a Structure using a sequence of operations such as Fill and Paste. Operations added to a
+// Builder are not applied until Build is called, at which point they are applied in a single pass over one
+// Structure: every block used by the operations is resolved to a palette entry only once, and consecutive
+// rotations are combined into one.
+// Builder methods return the Builder itself, so that operations may be chained:
+//
+//	s := structure.NewBuilder([3]int{16, 8, 16}).
+//		Fill([3]int{0, 0, 0}, [3]int{16, 1, 16}, block.Stone{}).
+//		Walls([3]int{0, 1, 0}, [3]int{16, 8, 16}, block.Planks{}).
+//		Build()
+//
+// Operations may span positions outside the structure being built: these positions are ignored.
+type Builder struct {
+	dimensions [3]int
+	ops        []builderOp
+}
+
+// builderOp is an operation added to a Builder. Either apply is non-nil and changes the structure in place, using
+// the cache passed to look up palette pointers of blocks, or turns holds the number of turns the structure is
+// rotated by.
+type builderOp struct {
+	apply func(s Structure, cache map[uint64]int32)
+	turns int
+}
+
+// NewBuilder creates a new Builder that builds a Structure with the dimensions passed. The Structure is
+// initially filled with air.
+func NewBuilder(dimensions [3]int) *Builder {
+	return &Builder{dimensions: dimensions}
+}
+
+// Fill fills the cuboid spanning from min (inclusive) to max (exclusive) with the world.Block passed.
+func (b *Builder) Fill(min, max [3]int, bl world.Block) *Builder {
+	b.ops = append(b.ops, builderOp{apply: func(s Structure, cache map[uint64]int32) {
+		ptr, data := s.cachedPtrFor(cache, bl), encodeNBT(bl)
+		from, to := s.clip(min, max)
+		for x := from[0]; x < to[0]; x++ {
+			for y := from[1]; y < to[1]; y++ {
+				for z := from[2]; z < to[2]; z++ {
+					s.setIndex((x*s.l*s.h)+(y*s.l)+z, ptr, cloneData(data))
+				}
+			}
+		}
+	}})
+	return b
+}
+
+// FillPattern fills the cuboid spanning from min (inclusive) to max (exclusive) with the blocks returned by the
+// Pattern passed for every position. Positions for which the Pattern returns nil are left untouched.
+func (b *Builder) FillPattern(min, max [3]int, p Pattern) *Builder {
+	b.ops = append(b.ops, builderOp{apply: func(s Structure, cache map[uint64]int32) {
+		from, to := s.clip(min, max)
+		for x := from[0]; x < to[0]; x++ {
+			for y := from[1]; y < to[1]; y++ {
+				for z := from[2]; z < to[2]; z++ {
+					bl := p.At(x, y, z)
+					if bl == nil {
+						continue
+					}
+					s.setIndex((x*s.l*s.h)+(y*s.l)+z, s.cachedPtrFor(cache, bl), encodeNBT(bl))
+				}
+			}
+		}
+	}})
+	return b
+}
+
+// Walls fills the four vertical sides of the cuboid spanning from min (inclusive) to max (exclusive) with
+// the world.Block passed. The top and bottom of the cuboid are left untouched.
+func (b *Builder) Walls(min, max [3]int, bl world.Block) *Builder {
+	b.ops = append(b.ops, builderOp{apply: func(s Structure, cache map[uint64]int32) {
+		ptr, data := s.cachedPtrFor(cache, bl), encodeNBT(bl)
+		// The sides are those of the cuboid passed, not of the cuboid clipped to the bounds of the structure, so
+		// that sides outside the structure are not moved inwards.
+		from, to := s.clip(min, max)
+		for x := from[0]; x < to[0]; x++ {
+			for y := from[1]; y < to[1]; y++ {
+				for z := from[2]; z < to[2]; z++ {
+					if x != min[0] && x != max[0]-1 && z != min[2] && z != max[2]-1 {
+						// Skip straight to the other side of the cuboid.
+						if z < max[2]-2 {
+							z = max[2] - 2
+						}
+						continue
+					}
+					s.setIndex((x*s.l*s.h)+(y*s.l)+z, ptr, cloneData(data))
+				}
+			}
+		}
+	}})
+	return b
+}
+
+// Outline fills the twelve edges of the cuboid spanning from min (inclusive) to max (exclusive) with the
+// world.Block passed, leaving its faces and inside untouched. Only the positions on the edges are visited, so
+// outlining large cuboids is cheap.
+func (b *Builder) Outline(min, max [3]int, bl world.Block) *Builder {
+	b.ops = append(b.ops, builderOp{apply: func(s Structure, cache map[uint64]int32) {
+		if max[0] <= min[0] || max[1] <= min[1] || max[2] <= min[2] {
+			return
+		}
+		ptr, data := s.cachedPtrFor(cache, bl), encodeNBT(bl)
+		from, to := s.clip(min, max)
+		// For every axis, the four edges along that axis are filled. The other two axes of these edges are at
+		// either their minimum or maximum, and edges outside the structure are skipped.
+		for axis := 0; axis < 3; axis++ {
+			a, c := (axis+1)%3, (axis+2)%3
+			for _, pa := range [2]int{min[a], max[a] - 1} {
+				if pa < from[a] || pa >= to[a] {
+					continue
+				}
+				for _, pc := range [2]int{min[c], max[c] - 1} {
+					if pc < from[c] || pc >= to[c] {
+						continue
+					}
+					var pos [3]int
+					pos[a], pos[c] = pa, pc
+					for pos[axis] = from[axis]; pos[axis] < to[axis]; pos[axis]++ {
+						s.setIndex((pos[0]*s.l*s.h)+(pos[1]*s.l)+pos[2], ptr, cloneData(data))
+					}
+				}
+			}
+		}
+	}})
+	return b
+}
+
+// clip returns the cuboid spanning from min (inclusive) to max (exclusive) clipped to the bounds of the
+// structure. If the cuboid lies outside the structure entirely, the cuboid returned is empty.
+func (s Structure) clip(min, max [3]int) (from, to [3]int) {
+	dims := s.Dimensions()
+	for i := 0; i < 3; i++ {
+		from[i], to[i] = min[i], max[i]
+		if from[i] < 0 {
+			from[i] = 0
+		}
+		if to[i] > dims[i] {
+			to[i] = dims[i]
+		}
+	}
+	return from, to
+}
+
+// cloneData returns a deep copy of the block entity data passed, or nil if data is nil, so that every position
+// filled with a block carrying block entity data holds its own copy of the data.
+func cloneData(data map[string]interface{}) map[string]interface{} {
+	if data == nil {
+		return nil
+	}
+	return copyNBT(data).(map[string]interface{})
+}
+
+// Paste copies all blocks, liquids and block entity data of the Structure passed into the structure being
+// built, so that its origin is at the position passed. Positions in the Structure passed that do not hold a
+// block are left untouched. Parts of the Structure that exceed the bounds of the structure being built are
+// discarded.
+func (b *Builder) Paste(pos [3]int, src Structure) *Builder {
+	b.ops = append(b.ops, builderOp{apply: func(s Structure, _ map[uint64]int32) {
+		s.paste(pos, src)
+	}})
+	return b
+}
+
+// paste copies all blocks, liquids and block entity data of the Structure passed into the structure, so that its
+// origin is at the position passed, as described in Builder.Paste.
+func (s Structure) paste(pos [3]int, src Structure) {
+	ptrs := make(map[int32]int32, len(src.palette.BlockPalette))
+	ptrFor := func(index int32) int32 {
+		ptr, ok := ptrs[index]
+		if !ok {
+			ptr = s.ptrForEntry(src.palette.BlockPalette[index])
+			ptrs[index] = ptr
+		}
+		return ptr
+	}
+
+	dims, srcDims := s.Dimensions(), src.Dimensions()
+	for x := 0; x < srcDims[0]; x++ {
+		for y := 0; y < srcDims[1]; y++ {
+			for z := 0; z < srcDims[2]; z++ {
+				dx, dy, dz := pos[0]+x, pos[1]+y, pos[2]+z
+				if dx < 0 || dy < 0 || dz < 0 || dx >= dims[0] || dy >= dims[1] || dz >= dims[2] {
+					continue
+				}
+				srcOffset := (x * src.l * src.h) + (y * src.l) + z
+				index := src.blocks[srcOffset]
+				if index == -1 {
+					continue
+				}
+				var data map[string]interface{}
+				if d, ok := src.positionData(srcOffset); ok {
+					data = cloneData(d.BlockEntityData)
+				}
+				offset := (dx * s.l * s.h) + (dy * s.l) + dz
+				s.setIndex(offset, ptrFor(index), data)
+				if liq := src.liquids[srcOffset]; liq != -1 {
+					s.liquids[offset] = ptrFor(liq)
+				}
+			}
+		}
+	}
+}
+
+// Replace replaces all blocks in the structure being built that are equal to the world.Block from with the
+// world.Block to.
+func (b *Builder) Replace(from, to world.Block) *Builder {
+	b.ops = append(b.ops, builderOp{apply: func(s Structure, cache map[uint64]int32) {
+		// The palette may hold multiple entries for the same block, such as after pasting structures read from
+		// files, so every entry with the same name and states is replaced.
+		name, properties := from.EncodeBlock()
+		properties = canonicalStates(properties)
+		match := make([]bool, len(s.palette.BlockPalette))
+		found := false
+		for i, bl := range s.palette.BlockPalette {
+			if bl.Name == name && statesEqual(canonicalStates(bl.States), properties) {
+				match[i], found = true, true
+			}
+		}
+		if !found {
+			return
+		}
+		ptr, data := s.cachedPtrFor(cache, to), encodeNBT(to)
+		for offset, index := range s.blocks {
+			if index != -1 && int(index) < len(match) && match[index] {
+				s.setIndex(offset, ptr, cloneData(data))
+			}
+		}
+	}})
+	return b
+}
+
+// Rotate rotates the structure being built by 90 degrees clockwise for every turn passed. A negative number
+// of turns rotates the structure anti-clockwise. Rotating the structure by an odd number of turns swaps its
+// width and length, which affects the positions passed to operations added after Rotate.
+func (b *Builder) Rotate(turns int) *Builder {
+	b.ops = append(b.ops, builderOp{turns: turns})
+	return b
+}
+
+// Build applies all operations added to the Builder in the order they were added and returns the resulting
+// Structure. Build may be called multiple times to produce multiple Structures.
+func (b *Builder) Build() Structure {
+	s := New(b.dimensions)
+	cache := make(map[uint64]int32)
+	turns := 0
+	for _, op := range b.ops {
+		if op.apply == nil {
+			turns += op.turns
+			continue
+		}
+		if turns%4 != 0 {
+			s = s.rotateTurns(turns)
+			// Rotating creates a new palette, so palette pointers looked up before no longer apply.
+			cache = make(map[uint64]int32)
+		}
+		turns = 0
+		op.apply(s, cache)
+	}
+	if turns%4 != 0 {
+		s = s.rotateTurns(turns)
+	}
+	return s
+}
+
+// rotateTurns returns the structure rotated by 90 degrees clockwise for every turn passed, or anti-clockwise if
+// turns is negative.
+func (s Structure) rotateTurns(turns int) Structure {
+	switch turns % 4 {
+	case 1, -3:
+		return s.RotateRight()
+	case 2, -2:
+		return s.RotateRight().RotateRight()
+	case 3, -1:
+		return s.RotateLeft()
+	}
+	return s
+}
+
+// setIndex sets the block index at an offset in the structure to the palette pointer passed and removes any
+// liquid present at that offset. If data is non-nil, it is stored as the block entity data of the offset.
+// Otherwise, any block entity data previously stored for the offset is removed.
+func (s *structure) setIndex(offset int, ptr int32, data map[string]interface{}) {
+	s.markDirty(offset)
+	s.blocks[offset] = ptr
+	s.liquids[offset] = -1
+	if data != nil {
+		s.palette.BlockPositionData[strconv.Itoa(offset)] = blockPositionData{BlockEntityData: data}
+	} else if len(s.palette.BlockPositionData) != 0 {
+		delete(s.palette.BlockPositionData, strconv.Itoa(offset))
+	}
+}
+
+// encodeNBT returns the block entity data of the world.Block passed, or nil if the block does not carry any.
+func encodeNBT(b world.Block) map[string]interface{} {
+	if nbtBlock, ok := b.(world.NBTer); ok {
+		return nbtBlock.EncodeNBT()
+	}
+	return nil
+}
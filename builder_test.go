@@ -0,0 +1,97 @@
+package structure
+
+import (
+	df "github.com/df-mc/dragonfly/server/block"
+	"github.com/df-mc/dragonfly/server/world"
+	"strconv"
+	"testing"
+)
+
+func TestBuilderClipsOperations(t *testing.T) {
+	s := NewBuilder([3]int{4, 4, 4}).
+		Fill([3]int{-2, -2, -2}, [3]int{2, 1, 2}, df.Stone{}).
+		FillPattern([3]int{3, -1, 3}, [3]int{8, 2, 8}, PatternFunc(func(x, y, z int) world.Block { return df.Dirt{} })).
+		Walls([3]int{-1, 2, -1}, [3]int{6, 3, 6}, df.Planks{}).
+		Outline([3]int{2, 3, 2}, [3]int{9, 9, 9}, df.Glass{}).
+		Build()
+
+	tests := []struct {
+		pos  [3]int
+		want world.Block
+	}{
+		{[3]int{0, 0, 0}, df.Stone{}},
+		{[3]int{1, 0, 1}, df.Stone{}},
+		{[3]int{2, 0, 2}, air()},
+		{[3]int{3, 1, 3}, df.Dirt{}},
+		// The walls of the cuboid lie outside the structure, so no walls are placed inside it.
+		{[3]int{0, 2, 0}, air()},
+		{[3]int{3, 2, 3}, air()},
+		{[3]int{2, 3, 2}, df.Glass{}},
+		{[3]int{3, 3, 2}, df.Glass{}},
+		{[3]int{3, 3, 3}, air()},
+	}
+	for _, test := range tests {
+		got, _ := s.At(test.pos[0], test.pos[1], test.pos[2], nil)
+		if !sameBlock(got, test.want) {
+			t.Errorf("block at %v = %v, want %v", test.pos, got, test.want)
+		}
+	}
+}
+
+func TestBuilderReplaceDuplicateEntries(t *testing.T) {
+	src := New([3]int{2, 1, 1})
+	// Two palette entries for the same block, which Paste would have merged into one.
+	name, states := df.Stone{}.EncodeBlock()
+	src.palette.BlockPalette = append(src.palette.BlockPalette, block{Name: name, States: states}, block{Name: name, States: states})
+	src.blocks[0], src.blocks[1] = 1, 2
+
+	b := NewBuilder([3]int{2, 1, 1}).Replace(df.Stone{}, df.Dirt{})
+	b.ops[0].apply(src, map[uint64]int32{})
+	s := src
+	for x := 0; x < 2; x++ {
+		if got, _ := s.At(x, 0, 0, nil); !sameBlock(got, df.Dirt{}) {
+			t.Errorf("block at %v = %v, want dirt", x, got)
+		}
+	}
+}
+
+func TestBuilderFillCopiesBlockEntityData(t *testing.T) {
+	s := NewBuilder([3]int{2, 1, 1}).Fill([3]int{}, [3]int{2, 1, 1}, df.NewChest()).Build()
+	a, b := s.palette.BlockPositionData[strconv.Itoa(0)], s.palette.BlockPositionData[strconv.Itoa(1)]
+	if a.BlockEntityData == nil || b.BlockEntityData == nil {
+		t.Fatalf("expected block entity data at both positions")
+	}
+	a.BlockEntityData["CustomName"] = "changed"
+	if _, ok := b.BlockEntityData["CustomName"]; ok {
+		t.Fatalf("block entity data is shared between positions")
+	}
+}
+
+func TestBuilderRotate(t *testing.T) {
+	s := NewBuilder([3]int{3, 1, 1}).
+		Fill([3]int{}, [3]int{1, 1, 1}, df.Stone{}).
+		Rotate(1).Rotate(1).Rotate(-1).
+		Build()
+
+	want := New([3]int{3, 1, 1})
+	want.Set(0, 0, 0, df.Stone{}, nil)
+	want = want.RotateRight()
+	if s.Hash() != want.Hash() {
+		t.Fatalf("rotating three times by a net turn differs from rotating once")
+	}
+}
+
+// air returns the air block.
+func air() world.Block {
+	return df.Air{}
+}
+
+// sameBlock checks if the two blocks passed encode to the same name and states.
+func sameBlock(a, b world.Block) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	an, as := a.EncodeBlock()
+	bn, bs := b.EncodeBlock()
+	return an == bn && statesEqual(canonicalStates(as), canonicalStates(bs))
+}
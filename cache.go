@@ -0,0 +1,293 @@
+package structure
+
+import (
+	"bufio"
+	"compress/gzip"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/df-mc/dragonfly/server/world/chunk"
+	"github.com/sandertv/gophertunnel/minecraft/nbt"
+	"io"
+	"math"
+	"os"
+)
+
+// cacheMagic is written at the start of every cache to identify it.
+var cacheMagic = [4]byte{'D', 'F', 'S', 'C'}
+
+// cacheVersion is the version of the cache encoding. It must be increased whenever the encoding changes.
+const cacheVersion = 1
+
+// maxInitialPaletteLen is the maximum number of palette entries DecodeCache allocates room for before reading them.
+const maxInitialPaletteLen = 1024
+
+// ErrStaleCache is returned by DecodeCache when decoding a cache that was written by a different version of the
+// package or with a different set of registered blocks. A stale cache should be discarded and re-encoded from
+// the original structure.
+var ErrStaleCache = errors.New("cache is stale")
+
+// ReadFileCached reads a Structure from the file at the path passed, like ReadFile. Additionally,
+// ReadFileCached maintains a cache file next to the structure file, with the same name suffixed by '.cache'.
+// If this cache is present and up-to-date, the Structure is read from it instead, which skips decoding the
+// NBT of the structure and resolving its palette. If the cache is missing or outdated, the structure file is
+// read and the cache is (re)written.
+// The Structure returned always resolves its palette using Dragonfly's registered blocks.
+func ReadFileCached(file string) (Structure, error) {
+	cacheFile := file + ".cache"
+	if stat, err := os.Stat(file); err == nil {
+		if cacheStat, err := os.Stat(cacheFile); err == nil && !cacheStat.ModTime().Before(stat.ModTime()) {
+			// Any error reading the cache, not only ErrStaleCache, is treated as a stale cache, so that a truncated
+			// or corrupt cache file is replaced by reading the structure file instead.
+			if s, err := readCacheFile(cacheFile); err == nil {
+				return s, nil
+			}
+		}
+	}
+	s, err := ReadFile(file)
+	if err != nil {
+		return Structure{}, err
+	}
+	// The cache is purely an optimisation, so failing to write it should not result in an error.
+	_ = writeCacheFile(cacheFile, s)
+	return s, nil
+}
+
+// readCacheFile reads a Structure from the cache file at the path passed.
+func readCacheFile(file string) (Structure, error) {
+	f, err := os.Open(file)
+	if err != nil {
+		return Structure{}, fmt.Errorf("open file: %w", err)
+	}
+	defer f.Close()
+	return DecodeCache(bufio.NewReader(f))
+}
+
+// writeCacheFile writes a Structure to the cache file at the path passed.
+func writeCacheFile(file string, s Structure) error {
+	f, err := os.OpenFile(file, os.O_TRUNC|os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		return fmt.Errorf("open file: %w", err)
+	}
+	w := bufio.NewWriter(f)
+	defer func() {
+		_ = w.Flush()
+		_ = f.Close()
+	}()
+	return EncodeCache(w, s)
+}
+
+// cacheData holds the parts of a structure that are stored as NBT in a cache.
+type cacheData struct {
+	Entities []map[string]interface{} `nbt:"entities"`
+	Palettes map[string]palette       `nbt:"palette"`
+}
+
+// cacheHeader is the header written at the start of a cache, directly after the gzip header.
+type cacheHeader struct {
+	Magic         [4]byte
+	Version       uint8
+	BlockVersion  int32
+	FormatVersion int32
+	Size, Origin  [3]int32
+	Layers        uint8
+}
+
+// EncodeCache encodes a Structure to the io.Writer passed using a compressed, versioned binary encoding that is
+// considerably faster to decode than the .mcstructure format. Alongside the block indices of the structure, the
+// runtime IDs of its resolved palette are written so that the palette does not need to be resolved again when
+// decoding. The encoding is only valid for the version of Dragonfly and the set of registered blocks it was
+// written with, so it is suited for caches, such as in Redis or BoltDB, rather than for long-term storage.
+func EncodeCache(w io.Writer, s Structure) error {
+	s.ensureParsed()
+	s.Structure.Palettes[s.paletteName] = *s.palette
+
+	zw := gzip.NewWriter(w)
+	le := binary.LittleEndian
+	header := cacheHeader{
+		Magic:         cacheMagic,
+		Version:       cacheVersion,
+		BlockVersion:  chunk.CurrentBlockVersion,
+		FormatVersion: s.FormatVersion,
+		Size:          [3]int32{s.Size[0], s.Size[1], s.Size[2]},
+		Origin:        [3]int32{s.Origin[0], s.Origin[1], s.Origin[2]},
+		Layers:        uint8(len(s.Structure.BlockIndices)),
+	}
+	if err := binary.Write(zw, le, header); err != nil {
+		return fmt.Errorf("encode header: %w", err)
+	}
+	for _, indices := range s.Structure.BlockIndices {
+		if err := binary.Write(zw, le, indices); err != nil {
+			return fmt.Errorf("encode block indices: %w", err)
+		}
+	}
+	if err := writeString(zw, s.paletteName); err != nil {
+		return fmt.Errorf("encode palette name: %w", err)
+	}
+	if err := binary.Write(zw, le, uint32(len(s.parsedPalette))); err != nil {
+		return fmt.Errorf("encode palette length: %w", err)
+	}
+	for _, entry := range s.parsedPalette {
+		rid, name := uint32(math.MaxUint32), ""
+		if entry.b != nil {
+			if r, ok := runtimeID(entry.b); ok {
+				rid = r
+				name, _ = entry.b.EncodeBlock()
+			}
+		}
+		if err := binary.Write(zw, le, rid); err != nil {
+			return fmt.Errorf("encode runtime ID: %w", err)
+		}
+		if err := writeString(zw, name); err != nil {
+			return fmt.Errorf("encode block name: %w", err)
+		}
+	}
+	data := cacheData{Entities: s.Structure.Entities, Palettes: s.Structure.Palettes}
+	if err := nbt.NewEncoderWithEncoding(zw, nbt.LittleEndian).Encode(data); err != nil {
+		return fmt.Errorf("encode structure data: %w", err)
+	}
+	if err := zw.Close(); err != nil {
+		return fmt.Errorf("close gzip: %w", err)
+	}
+	return nil
+}
+
+// DecodeCache decodes a Structure from the io.Reader passed, which must hold a structure encoded using
+// EncodeCache. If the cache was written by a different version of this package or Dragonfly, or with a
+// different set of registered blocks, ErrStaleCache is returned.
+// The Structure returned always resolves its palette using Dragonfly's registered blocks.
+func DecodeCache(r io.Reader) (Structure, error) {
+	zr, err := gzip.NewReader(r)
+	if err != nil {
+		return Structure{}, fmt.Errorf("open gzip: %w", err)
+	}
+	le := binary.LittleEndian
+	var header cacheHeader
+	if err := binary.Read(zr, le, &header); err != nil {
+		return Structure{}, fmt.Errorf("decode header: %w", err)
+	}
+	if header.Magic != cacheMagic {
+		return Structure{}, fmt.Errorf("invalid cache magic %v", header.Magic)
+	}
+	if header.Version != cacheVersion || header.BlockVersion != chunk.CurrentBlockVersion {
+		return Structure{}, ErrStaleCache
+	}
+	s := &structure{
+		FormatVersion: header.FormatVersion,
+		Size:          header.Size[:],
+		Origin:        header.Origin[:],
+	}
+	n := int64(header.Size[0]) * int64(header.Size[1]) * int64(header.Size[2])
+	if n <= 0 {
+		return Structure{}, fmt.Errorf("structure has a total size of 0 blocks or less (%v)", n)
+	}
+	if err := checkVolume(int64(header.Size[0]), int64(header.Size[1]), int64(header.Size[2])); err != nil {
+		return Structure{}, err
+	}
+	if header.Layers != 2 {
+		// The layer count is read from the input, so it is verified before allocating a layer for each.
+		return Structure{}, fmt.Errorf("structure has %v block index layers, expected 2", header.Layers)
+	}
+	s.Structure.BlockIndices = make([][]int32, header.Layers)
+	for i := range s.Structure.BlockIndices {
+		s.Structure.BlockIndices[i] = make([]int32, n)
+		if err := binary.Read(zr, le, s.Structure.BlockIndices[i]); err != nil {
+			return Structure{}, fmt.Errorf("decode block indices: %w", err)
+		}
+	}
+	if s.paletteName, err = readString(zr); err != nil {
+		return Structure{}, fmt.Errorf("decode palette name: %w", err)
+	}
+	var paletteLen uint32
+	if err := binary.Read(zr, le, &paletteLen); err != nil {
+		return Structure{}, fmt.Errorf("decode palette length: %w", err)
+	}
+	// The palette length is read from the input, so the slices are grown while reading instead of allocated up
+	// front: a corrupt length then fails once the input runs out, rather than allocating gigabytes first.
+	initial := paletteLen
+	if initial > maxInitialPaletteLen {
+		initial = maxInitialPaletteLen
+	}
+	rids, names := make([]uint32, 0, initial), make([]string, 0, initial)
+	for i := uint32(0); i < paletteLen; i++ {
+		var rid uint32
+		if err := binary.Read(zr, le, &rid); err != nil {
+			return Structure{}, fmt.Errorf("decode runtime ID: %w", err)
+		}
+		name, err := readString(zr)
+		if err != nil {
+			return Structure{}, fmt.Errorf("decode block name: %w", err)
+		}
+		rids, names = append(rids, rid), append(names, name)
+	}
+	var data cacheData
+	if err := nbt.NewDecoderWithEncoding(zr, nbt.LittleEndian).Decode(&data); err != nil {
+		return Structure{}, fmt.Errorf("decode structure data: %w", err)
+	}
+	s.Structure.Entities, s.Structure.Palettes = data.Entities, data.Palettes
+	if err := s.check(); err != nil {
+		return Structure{}, fmt.Errorf("verify structure: %w", err)
+	}
+
+	p, ok := s.Structure.Palettes[s.paletteName]
+	if !ok || len(p.BlockPalette) != len(rids) {
+		return Structure{}, fmt.Errorf("palette %v does not match the cached palette", s.paletteName)
+	}
+	if p.BlockPositionData == nil {
+		p.BlockPositionData = map[string]blockPositionData{}
+	}
+	s.palette, s.registry = &p, worldRegistry{}
+	s.parsedPalette = make([]parsedBlock, 0, len(rids))
+	for i, rid := range rids {
+		if rid == math.MaxUint32 {
+			s.parsePaletteEntry(p.BlockPalette[i])
+			continue
+		}
+		b, ok := world.BlockByRuntimeID(rid)
+		if !ok {
+			return Structure{}, ErrStaleCache
+		}
+		if name, _ := b.EncodeBlock(); name != names[i] {
+			return Structure{}, ErrStaleCache
+		}
+		s.parsedPalette = append(s.parsedPalette, newParsedBlock(b))
+	}
+	str := Structure{structure: s}
+	str.prepare()
+	return str, nil
+}
+
+// runtimeID returns the runtime ID of the world.Block passed. If the block is not registered, runtimeID returns
+// false.
+func runtimeID(b world.Block) (rid uint32, ok bool) {
+	defer func() {
+		if recover() != nil {
+			// world.BlockRuntimeID panics if the block is not registered.
+			ok = false
+		}
+	}()
+	return world.BlockRuntimeID(b), true
+}
+
+// writeString writes a string prefixed by its length as a uint16 to the io.Writer passed.
+func writeString(w io.Writer, str string) error {
+	if err := binary.Write(w, binary.LittleEndian, uint16(len(str))); err != nil {
+		return err
+	}
+	_, err := io.WriteString(w, str)
+	return err
+}
+
+// readString reads a string prefixed by its length as a uint16 from the io.Reader passed.
+func readString(r io.Reader) (string, error) {
+	var l uint16
+	if err := binary.Read(r, binary.LittleEndian, &l); err != nil {
+		return "", err
+	}
+	b := make([]byte, l)
+	if _, err := io.ReadFull(r, b); err != nil {
+		return "", err
+	}
+	return string(b), nil
+}
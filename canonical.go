@@ -0,0 +1,165 @@
+package structure
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// canonicalValue returns the block state value passed converted to the type Minecraft uses for block states:
+// uint8 for booleans and bytes, int32 for other integers and string for strings. Block states created by hand or
+// by other tools sometimes hold values of other types, such as int or bool, which would otherwise not be equal to
+// the same value stored by Minecraft. If the value already has a canonical type, false is returned.
+func canonicalValue(v interface{}) (interface{}, bool) {
+	switch v := v.(type) {
+	case bool:
+		if v {
+			return uint8(1), true
+		}
+		return uint8(0), true
+	case int8:
+		return uint8(v), true
+	case int:
+		return int32(v), true
+	case int16:
+		return int32(v), true
+	case int64:
+		return int32(v), true
+	case uint:
+		return int32(v), true
+	case uint16:
+		return int32(v), true
+	case uint32:
+		return int32(v), true
+	case uint64:
+		return int32(v), true
+	}
+	return v, false
+}
+
+// canonicalStates returns the block states passed with all values converted to their canonical type using
+// canonicalValue. If all values already have a canonical type, the map passed is returned as is, without copying
+// it.
+func canonicalStates(states map[string]interface{}) map[string]interface{} {
+	var c map[string]interface{}
+	for k, v := range states {
+		cv, changed := canonicalValue(v)
+		if !changed {
+			continue
+		}
+		if c == nil {
+			c = make(map[string]interface{}, len(states))
+			for k, v := range states {
+				c[k] = v
+			}
+		}
+		c[k] = cv
+	}
+	if c == nil {
+		return states
+	}
+	return c
+}
+
+// statesEqual checks if the two sets of canonical block states passed hold the same keys with the same values.
+func statesEqual(a, b map[string]interface{}) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for k, v := range a {
+		if bv, ok := b[k]; !ok || bv != v {
+			return false
+		}
+	}
+	return true
+}
+
+// canonicaliseStates converts the block states of all palette entries of the structure to their canonical types.
+func (s *structure) canonicaliseStates() {
+	for name, p := range s.Structure.Palettes {
+		for i, bl := range p.BlockPalette {
+			p.BlockPalette[i].States = canonicalStates(bl.States)
+		}
+		s.Structure.Palettes[name] = p
+	}
+}
+
+// canonicalisePalettes converts the block states of all palette entries of the structure to their canonical types
+// using canonicaliseStates. If the structure has only a single palette, entries that are identical once
+// canonicalised are merged too, and the block indices of the structure are updated to point to the merged entries.
+// Structures with multiple palettes share their block indices between palettes, so their entries cannot be merged.
+func (s *structure) canonicalisePalettes() {
+	s.canonicaliseStates()
+	if len(s.Structure.Palettes) != 1 {
+		return
+	}
+	for name, p := range s.Structure.Palettes {
+		remap, merged := mergeDuplicates(p.BlockPalette)
+		if remap == nil {
+			return
+		}
+		p.BlockPalette = merged
+		s.Structure.Palettes[name] = p
+		for _, layer := range s.Structure.BlockIndices {
+			for i, index := range layer {
+				if index >= 0 && int(index) < len(remap) {
+					layer[i] = remap[index]
+				}
+			}
+		}
+	}
+}
+
+// mergeDuplicates merges the palette entries passed that have the same name, version and block states. It returns
+// the merged entries and, for every entry passed, the index of the entry it was merged into. If the entries passed
+// hold no duplicates, both are nil.
+func mergeDuplicates(entries []block) (remap []int32, merged []block) {
+	indices := make(map[string]int32, len(entries))
+	for i, bl := range entries {
+		key := entryKey(bl)
+		if index, ok := indices[key]; ok {
+			if remap == nil {
+				remap = make([]int32, len(entries))
+				for j := range remap {
+					remap[j] = int32(j)
+				}
+			}
+			remap[i] = index
+			continue
+		}
+		indices[key] = int32(i)
+	}
+	if remap == nil {
+		return nil, nil
+	}
+	// The entries are compacted, so that the indices of entries merged into others are no longer used.
+	compact := make([]int32, len(entries))
+	for i, bl := range entries {
+		if remap[i] != int32(i) {
+			continue
+		}
+		compact[i] = int32(len(merged))
+		merged = append(merged, bl)
+	}
+	for i := range remap {
+		remap[i] = compact[remap[i]]
+	}
+	return remap, merged
+}
+
+// entryKey returns a string that uniquely identifies the name, version and canonical block states of the palette
+// entry passed.
+func entryKey(bl block) string {
+	keys := make([]string, 0, len(bl.States))
+	for k := range bl.States {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	var sb strings.Builder
+	_, _ = fmt.Fprintf(&sb, "%v@%v", bl.Name, bl.Version)
+	for _, k := range keys {
+		v := bl.States[k]
+		_, _ = fmt.Fprintf(&sb, ";%q=%T:%v", k, v, v)
+	}
+	return sb.String()
+}
@@ -0,0 +1,115 @@
+// Package clipboard implements WorldEdit-like copy, cut and paste primitives for Dragonfly worlds, built on top
+// of structure.Structure.
+package clipboard
+
+import (
+	"errors"
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/df-mc/structure"
+	"github.com/google/uuid"
+	"sync"
+)
+
+// ErrEmpty is returned when pasting a Clipboard that does not yet hold anything.
+var ErrEmpty = errors.New("clipboard is empty")
+
+// Transform describes how the contents of a Clipboard are transformed when pasted.
+type Transform struct {
+	// Rotation is the number of times the contents are rotated by 90 degrees clockwise around their lowest
+	// corner. A negative Rotation rotates the contents anti-clockwise.
+	Rotation int
+}
+
+// Clipboard holds a structure copied from a world so that it may be pasted elsewhere. A Clipboard is safe for
+// concurrent use.
+type Clipboard struct {
+	mu  sync.Mutex
+	s   structure.Structure
+	has bool
+}
+
+// Copy copies the blocks in the cuboid between the two corners passed in the world.World passed to the
+// Clipboard, replacing anything it previously held. Both corners are inclusive and may be passed in any order.
+func (c *Clipboard) Copy(w *world.World, a, b cube.Pos) {
+	s := structure.Capture(w, a, b)
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.s, c.has = s, true
+}
+
+// Cut copies the blocks in the cuboid between the two corners passed like Copy and clears the cuboid in the
+// world.World passed afterwards by setting all of its blocks to air. The cuboid is cleared without triggering
+// block updates, so that surrounding blocks do not react to the cuboid while it is only partially cleared.
+func (c *Clipboard) Cut(w *world.World, a, b cube.Pos) {
+	c.Copy(w, a, b)
+	structure.Clear(w, a, b)
+}
+
+// Paste builds the contents of the Clipboard in the world.World passed, with the lowest corner of the contents
+// at the position passed after applying the Transform. If the Clipboard does not hold anything, ErrEmpty is
+// returned.
+func (c *Clipboard) Paste(w *world.World, pos cube.Pos, t Transform) error {
+	c.mu.Lock()
+	s, has := c.s, c.has
+	c.mu.Unlock()
+	if !has {
+		return ErrEmpty
+	}
+	for n := t.Rotation % 4; n != 0; {
+		if n > 0 {
+			s, n = s.RotateRight(), n-1
+		} else {
+			s, n = s.RotateLeft(), n+1
+		}
+	}
+	w.BuildStructure(pos, s)
+	return nil
+}
+
+// Structure returns the structure held by the Clipboard. If the Clipboard does not hold anything, false is
+// returned.
+func (c *Clipboard) Structure() (structure.Structure, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.s, c.has
+}
+
+// Clear clears the contents of the Clipboard.
+func (c *Clipboard) Clear() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.s, c.has = structure.Structure{}, false
+}
+
+// Store holds a Clipboard for every player, identified by their UUID. A Store is safe for concurrent use.
+type Store struct {
+	mu         sync.Mutex
+	clipboards map[uuid.UUID]*Clipboard
+}
+
+// NewStore creates a new, empty Store.
+func NewStore() *Store {
+	return &Store{clipboards: map[uuid.UUID]*Clipboard{}}
+}
+
+// Clipboard returns the Clipboard of the player with the UUID passed. If the player does not yet have a
+// Clipboard, a new, empty Clipboard is created for them.
+func (s *Store) Clipboard(id uuid.UUID) *Clipboard {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	c, ok := s.clipboards[id]
+	if !ok {
+		c = &Clipboard{}
+		s.clipboards[id] = c
+	}
+	return c
+}
+
+// Remove removes the Clipboard of the player with the UUID passed, for example when the player leaves.
+func (s *Store) Remove(id uuid.UUID) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.clipboards, id)
+}
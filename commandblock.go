@@ -0,0 +1,98 @@
+package structure
+
+import "github.com/df-mc/dragonfly/server/block/cube"
+
+// CommandBlockMode is the mode of a command block, which decides when it runs its command.
+type CommandBlockMode int
+
+const (
+	// CommandBlockImpulse is the mode of command blocks that run their command once every time they are powered.
+	CommandBlockImpulse CommandBlockMode = iota
+	// CommandBlockRepeating is the mode of command blocks that run their command every tick while powered.
+	CommandBlockRepeating
+	// CommandBlockChain is the mode of command blocks that run their command when the command block pointing
+	// into them runs its command.
+	CommandBlockChain
+)
+
+// commandBlockModes maps the names of the command block variants to their CommandBlockMode.
+var commandBlockModes = map[string]CommandBlockMode{
+	"minecraft:command_block":           CommandBlockImpulse,
+	"minecraft:repeating_command_block": CommandBlockRepeating,
+	"minecraft:chain_command_block":     CommandBlockChain,
+}
+
+// CommandBlock is a command block found in a Structure using CommandBlocks.
+type CommandBlock struct {
+	// Position is the position of the command block relative to the origin of the Structure.
+	Position cube.Pos
+	// Mode is the mode of the command block.
+	Mode CommandBlockMode
+	// Command is the command run by the command block, which may be empty.
+	Command string
+	// Conditional specifies if the command block only runs its command if the command block behind it ran its
+	// command successfully.
+	Conditional bool
+	// AlwaysActive specifies if the command block runs without needing to be powered by redstone.
+	AlwaysActive bool
+	// TickDelay is the number of ticks the command block waits before running its command.
+	TickDelay int32
+	// CustomName is the name given to the command block, which is used as the sender of its commands.
+	CustomName string
+	// Data is the full block entity data of the command block, which may be nil. It must not be modified.
+	Data map[string]interface{}
+}
+
+// CommandBlocks returns all command blocks in the structure, ordered by their position, x first, then y, then z.
+// Servers may use CommandBlocks to audit structures from untrusted sources before building them, and use
+// StripCommandBlocks to remove any command blocks found.
+func (s *structure) CommandBlocks() []CommandBlock {
+	modes := s.commandBlockEntries()
+	var commandBlocks []CommandBlock
+	for offset, index := range s.blocks {
+		if index == -1 || modes[index] == -1 {
+			continue
+		}
+		conditional, _ := s.palette.BlockPalette[index].States["conditional_bit"].(uint8)
+		c := CommandBlock{Position: s.posOf(offset), Mode: CommandBlockMode(modes[index]), Conditional: conditional != 0}
+		if data, ok := s.positionData(offset); ok {
+			c.Data = data.BlockEntityData
+			c.Command, _ = c.Data["Command"].(string)
+			c.CustomName, _ = c.Data["CustomName"].(string)
+			c.TickDelay, _ = c.Data["TickDelay"].(int32)
+			auto, _ := c.Data["auto"].(uint8)
+			c.AlwaysActive = auto != 0
+		}
+		commandBlocks = append(commandBlocks, c)
+	}
+	return commandBlocks
+}
+
+// StripCommandBlocks replaces all command blocks in the structure with air and removes their block entity data,
+// including their commands, so that building the structure never places a command block.
+func (s *structure) StripCommandBlocks() {
+	modes := s.commandBlockEntries()
+	air := int32(-1)
+	for offset, index := range s.blocks {
+		if index == -1 || modes[index] == -1 {
+			continue
+		}
+		if air == -1 {
+			air = s.ptrFor(airBlock())
+		}
+		s.setIndex(offset, air, nil)
+	}
+}
+
+// commandBlockEntries returns a slice holding, for every entry in the palette currently used, the
+// CommandBlockMode of the entry if it is a command block, or -1 if it is not.
+func (s *structure) commandBlockEntries() []int {
+	modes := make([]int, len(s.palette.BlockPalette))
+	for i, bl := range s.palette.BlockPalette {
+		modes[i] = -1
+		if mode, ok := commandBlockModes[bl.Name]; ok {
+			modes[i] = int(mode)
+		}
+	}
+	return modes
+}
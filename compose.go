@@ -0,0 +1,117 @@
+package structure
+
+import (
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/go-gl/mathgl/mgl64"
+)
+
+// Concat joins the structures passed end-to-end along the axis passed, in the order they are passed, and returns
+// the result as a new Structure. Concatenating corridor pieces along cube.X, for example, places every piece
+// directly behind the previous one on the X axis.
+// The structures need not have the same cross-section: the Structure returned is as large as the largest of them
+// on the other two axes, and all of them are aligned at their lowest corner on these axes. Positions not covered
+// by any of the structures hold no block, so that building the result leaves them untouched. Entities of the
+// structures are moved along with them.
+// Concat panics if no structures are passed.
+func Concat(axis cube.Axis, parts ...Structure) Structure {
+	if len(parts) == 0 {
+		panic("concat requires at least one structure")
+	}
+	a := axisIndex(axis)
+	var dims [3]int
+	for _, part := range parts {
+		partDims := part.Dimensions()
+		for i := range dims {
+			if i == a {
+				dims[i] += partDims[i]
+			} else if partDims[i] > dims[i] {
+				dims[i] = partDims[i]
+			}
+		}
+	}
+	s := New(dims)
+	for i := range s.blocks {
+		s.blocks[i] = -1
+	}
+	var pos [3]int
+	for _, part := range parts {
+		s.paste(pos, part)
+		var offset mgl64.Vec3
+		offset[a] = float64(pos[a])
+		s.Structure.Entities = append(s.Structure.Entities, translateEntities(part.Structure.Entities, offset)...)
+		pos[a] += part.Dimensions()[a]
+	}
+	return s
+}
+
+// axisIndex returns the index of the cube.Axis passed in positions and dimensions: 0 for cube.X, 1 for cube.Y and
+// 2 for cube.Z.
+func axisIndex(axis cube.Axis) int {
+	switch axis {
+	case cube.X:
+		return 0
+	case cube.Z:
+		return 2
+	}
+	return 1
+}
+
+// TileOption is an option that changes the way a Structure is tiled by Structure.Tile.
+type TileOption func(conf *tileConfig)
+
+// tileConfig holds the configuration of a single call to Structure.Tile, as changed by TileOptions.
+type tileConfig struct {
+	overlap [3]int
+}
+
+// WithOverlap makes Structure.Tile overlap neighbouring copies of the Structure by the number of blocks passed on
+// each axis. Overlapping by one block on the X and Z axes, for example, makes a wall segment with a pillar on
+// both ends share its pillars with the segments next to it. Where copies overlap, the later copy, which is the one
+// further along the axis, takes precedence. The overlap on an axis is limited so that every copy advances by at
+// least one block.
+func WithOverlap(overlap [3]int) TileOption {
+	return func(conf *tileConfig) {
+		conf.overlap = overlap
+	}
+}
+
+// Tile returns a new Structure with the dimensions passed, filled by repeating the Structure on all three axes,
+// starting at its lowest corner. Copies that do not fit in the dimensions passed completely are cut off.
+// Positions in the Structure that do not hold a block do not hold a block in the Structure returned either.
+// Entities are repeated along with the blocks, except for those of which the position is cut off.
+func (s Structure) Tile(dimensions [3]int, opts ...TileOption) Structure {
+	conf := &tileConfig{}
+	for _, opt := range opts {
+		opt(conf)
+	}
+	dims := s.Dimensions()
+	var step [3]int
+	for i := range step {
+		step[i] = dims[i] - conf.overlap[i]
+		if step[i] < 1 {
+			step[i] = 1
+		}
+	}
+
+	t := New(dimensions)
+	for i := range t.blocks {
+		t.blocks[i] = -1
+	}
+	if dims[0] == 0 || dims[1] == 0 || dims[2] == 0 {
+		return t
+	}
+	for x := 0; x < dimensions[0]; x += step[0] {
+		for y := 0; y < dimensions[1]; y += step[1] {
+			for z := 0; z < dimensions[2]; z += step[2] {
+				t.paste([3]int{x, y, z}, s)
+				for _, e := range translateEntities(s.Structure.Entities, mgl64.Vec3{float64(x), float64(y), float64(z)}) {
+					if pos, ok := entityPos(e); !ok || pos[0] >= float64(dimensions[0]) || pos[1] >= float64(dimensions[1]) || pos[2] >= float64(dimensions[2]) {
+						continue
+					}
+					t.Structure.Entities = append(t.Structure.Entities, e)
+				}
+			}
+		}
+	}
+	return t
+}
@@ -0,0 +1,145 @@
+package structure
+
+import (
+	"fmt"
+	"github.com/df-mc/dragonfly/server/world"
+	"sort"
+)
+
+// CompressedStructure is a read-only structure of which the block indices are held in memory run-length encoded.
+// Most structures consist largely of long runs of the same block, or of positions without a block, so that a
+// CompressedStructure often takes several times less memory than the Structure it was created from. In return,
+// looking up a block in a CompressedStructure is slightly slower, as the run holding the position must be
+// searched for. A CompressedStructure is obtained using Structure.Compress.
+// A CompressedStructure implements world.Structure, so it may be built directly using world.World.BuildStructure.
+// Like a FrozenStructure, it is safe to use from multiple goroutines at the same time.
+type CompressedStructure struct {
+	s *structure
+	// layers holds the runs of the block and liquid layers of the structure.
+	layers [2]runs
+}
+
+// runs is a run-length encoded layer of block indices. Run i holds the palette index indices[i] from offset
+// starts[i] up to, but not including, offset starts[i+1], or the end of the layer for the last run.
+type runs struct {
+	starts, indices []int32
+}
+
+// Check to ensure that CompressedStructure implements the world.Structure interface.
+var _ world.Structure = CompressedStructure{}
+
+// Compress returns a copy of the Structure with its block indices run-length encoded, which holds the same blocks,
+// liquids, entities and palettes. Modifying the Structure afterwards does not affect the CompressedStructure
+// returned. Decompress may be used to obtain a mutable Structure again.
+func (s Structure) Compress() CompressedStructure {
+	// The palette is parsed before compressing, so that concurrent reads do not race to parse it lazily.
+	s.ensureParsed()
+	c := s.copyStructure(false)
+	return CompressedStructure{s: c.structure, layers: [2]runs{compressLayer(s.blocks), compressLayer(s.liquids)}}
+}
+
+// compressLayer run-length encodes the layer of block indices passed.
+func compressLayer(layer []int32) runs {
+	var r runs
+	for offset, index := range layer {
+		if n := len(r.indices); n == 0 || r.indices[n-1] != index {
+			r.starts, r.indices = append(r.starts, int32(offset)), append(r.indices, index)
+		}
+	}
+	// The runs are copied so that no capacity left over by appending is kept in memory.
+	r.starts, r.indices = append([]int32(nil), r.starts...), append([]int32(nil), r.indices...)
+	return r
+}
+
+// at returns the palette index at the offset passed in the layer.
+func (r runs) at(offset int) int32 {
+	i := sort.Search(len(r.starts), func(i int) bool {
+		return int(r.starts[i]) > offset
+	})
+	return r.indices[i-1]
+}
+
+// decompress returns the layer of block indices encoded in the runs, which holds n indices.
+func (r runs) decompress(n int) []int32 {
+	layer := make([]int32, n)
+	for i, start := range r.starts {
+		end := n
+		if i+1 < len(r.starts) {
+			end = int(r.starts[i+1])
+		}
+		for offset := int(start); offset < end; offset++ {
+			layer[offset] = r.indices[i]
+		}
+	}
+	return layer
+}
+
+// Decompress returns a mutable copy of the CompressedStructure as a Structure.
+func (c CompressedStructure) Decompress() Structure {
+	s := Structure{structure: c.s}.copyStructure(false)
+	n := s.Size[0] * s.Size[1] * s.Size[2]
+	s.Structure.BlockIndices = [][]int32{c.layers[0].decompress(int(n)), c.layers[1].decompress(int(n))}
+	s.prepare()
+	return s
+}
+
+// Dimensions returns the dimensions of the structure.
+func (c CompressedStructure) Dimensions() [3]int {
+	return c.s.Dimensions()
+}
+
+// At returns the block and liquid at the x, y and z passed in the structure. Like Structure.At, it returns nil
+// for positions that do not hold a block or liquid.
+func (c CompressedStructure) At(x, y, z int, _ func(x int, y int, z int) world.Block) (world.Block, world.Liquid) {
+	offset := (x * c.s.l * c.s.h) + (y * c.s.l) + z
+	b := c.blockAt(offset)
+	if index := c.layers[1].at(offset); index != -1 {
+		return b, c.s.parsedPalette[index].liq
+	}
+	return b, nil
+}
+
+// BlockAt returns the block at the x, y and z passed in the structure, without looking up the liquid at the
+// position.
+func (c CompressedStructure) BlockAt(x, y, z int) world.Block {
+	return c.blockAt((x * c.s.l * c.s.h) + (y * c.s.l) + z)
+}
+
+// blockAt returns the block at the offset passed, decoding its block entity data if it has any.
+func (c CompressedStructure) blockAt(offset int) world.Block {
+	index := c.layers[0].at(offset)
+	if index == -1 {
+		return nil
+	}
+	entry := c.s.parsedPalette[index]
+	if entry.hasNBT {
+		if nbtData, ok := c.s.positionData(offset); ok {
+			return entry.b.(world.NBTer).DecodeNBT(nbtData.BlockEntityData).(world.Block)
+		}
+	}
+	return entry.b
+}
+
+// Runs returns the total number of runs in the block and liquid layers of the structure. The memory taken by the
+// block indices of a CompressedStructure is proportional to the number of runs, rather than to the volume of the
+// structure.
+func (c CompressedStructure) Runs() int {
+	return len(c.layers[0].starts) + len(c.layers[1].starts)
+}
+
+// PaletteSize returns the number of entries in the palette of the structure. See Structure.PaletteSize.
+func (c CompressedStructure) PaletteSize() int {
+	return c.s.PaletteSize()
+}
+
+// Palette returns all distinct blocks in the palette of the structure. See Structure.Palette.
+func (c CompressedStructure) Palette() []world.Block {
+	return c.s.Palette()
+}
+
+// String returns a summary of the structure, holding its dimensions, the size of its palette and the number of
+// runs of its block indices.
+func (c CompressedStructure) String() string {
+	dims := c.Dimensions()
+	return fmt.Sprintf("CompressedStructure(%vx%vx%v, palette: %v, runs: %v)", dims[0], dims[1], dims[2], c.PaletteSize(), c.Runs())
+}
@@ -5,7 +5,12 @@ import (
 	"github.com/df-mc/dragonfly/server/world"
 	"github.com/df-mc/dragonfly/server/world/chunk"
 	"github.com/df-mc/worldupgrader/blockupgrader"
+	"math"
+	"reflect"
+	"runtime"
+	"sort"
 	"strconv"
+	"sync"
 	"unsafe"
 )
 
@@ -20,9 +25,16 @@ type structure struct {
 	palette       *palette
 	paletteName   string
 	parsedPalette []parsedBlock
+	registry      BlockRegistry
+	match         MatchMode
 
 	l, h            int
 	blocks, liquids []int32
+	// dirty is a bitset holding the offsets changed since the structure was created, read or last reset, or nil if
+	// no offset was changed.
+	dirty []uint64
+	// entitiesDirty is true if the entities of the structure were changed since it was created, read or last reset.
+	entitiesDirty bool
 
 	blocksPtr, liquidsPtr, palettePtr unsafe.Pointer
 }
@@ -31,6 +43,17 @@ type structure struct {
 type parsedBlock struct {
 	b      world.Block
 	hasNBT bool
+	// liq is b as a world.Liquid, or nil if b is not a liquid. It is asserted in advance so that looking up
+	// the liquid layer does not need a type assertion for every position.
+	liq world.Liquid
+}
+
+// newParsedBlock returns a parsedBlock for the world.Block passed, which may be nil if the palette entry could not be
+// resolved.
+func newParsedBlock(b world.Block) parsedBlock {
+	_, n := b.(world.NBTer)
+	liq, _ := b.(world.Liquid)
+	return parsedBlock{b: b, hasNBT: n, liq: liq}
 }
 
 const version = 1
@@ -52,7 +75,8 @@ func (s *structure) Dimensions() [3]int {
 func (s *structure) prepare() {
 	s.l, s.h = int(s.Size[2]), int(s.Size[1])
 	n := s.Size[0] * s.Size[1] * s.Size[2]
-	if n == 0 {
+	if n == 0 || len(s.Structure.BlockIndices) == 0 {
+		// Either the structure is empty, or its block indices are not held in memory, as with a MappedStructure.
 		return
 	}
 
@@ -72,7 +96,21 @@ func (s *structure) prepare() {
 	s.liquids = s.Structure.BlockIndices[1]
 	s.liquidsPtr = unsafe.Pointer(&s.liquids[0])
 
-	s.palettePtr = unsafe.Pointer(&s.parsedPalette[0])
+	s.palettePtr = nil
+	if len(s.parsedPalette) != 0 {
+		s.palettePtr = unsafe.Pointer(&s.parsedPalette[0])
+	}
+}
+
+// ensureParsed parses the palette of the structure if it has not yet been parsed. Palettes are parsed lazily, so
+// that structures that are only read for their metadata do not need to resolve every palette entry to a block.
+func (s *structure) ensureParsed() {
+	if s.parsedPalette == nil {
+		s.parsePalette()
+		if len(s.parsedPalette) != 0 {
+			s.palettePtr = unsafe.Pointer(&s.parsedPalette[0])
+		}
+	}
 }
 
 // Set sets the block at a specific position within the structure to the world.Block passed. Set will panic
@@ -80,10 +118,14 @@ func (s *structure) prepare() {
 // block.
 func (s *structure) Set(x, y, z int, b world.Block, liq world.Liquid) {
 	offset := (x * s.l * s.h) + (y * s.l) + z
+	s.markDirty(offset)
 
 	s.blocks[offset] = s.ptrFor(b)
 	if nbtBlock, ok := b.(world.NBTer); ok {
 		s.palette.BlockPositionData[strconv.Itoa(offset)] = blockPositionData{BlockEntityData: nbtBlock.EncodeNBT()}
+	} else if len(s.palette.BlockPositionData) != 0 {
+		// Remove any block entity data left behind by a block previously at this position.
+		delete(s.palette.BlockPositionData, strconv.Itoa(offset))
 	}
 
 	if liq == nil {
@@ -98,27 +140,76 @@ func (s *structure) Set(x, y, z int, b world.Block, liq world.Liquid) {
 // structure and returns a pointer to the new value in the palette.
 func (s *structure) ptrFor(b world.Block) int32 {
 	name, properties := b.EncodeBlock()
-	ptr := s.lookup(name, properties)
+	return s.ptrForEntry(block{
+		Name:    name,
+		States:  properties,
+		Version: chunk.CurrentBlockVersion,
+	})
+}
+
+// ptrForEntry looks up a palette pointer for the palette entry passed. If not found, it adds the entry to the palette
+// of the structure and returns a pointer to the new value in the palette.
+func (s *structure) ptrForEntry(bl block) int32 {
+	bl.States = canonicalStates(bl.States)
+	ptr := s.lookup(bl.Name, bl.States)
 
 	if ptr == -1 {
 		// No pointer found, add a new block to the palette.
 		ptr = int32(len(s.palette.BlockPalette))
-		bl := block{
-			Name:    name,
-			States:  properties,
-			Version: chunk.CurrentBlockVersion,
-		}
 		s.palette.BlockPalette = append(s.palette.BlockPalette, bl)
-		s.parsePaletteEntry(bl)
-		// Update the palette pointer because appending might have changed the
-		// location of the underlying array.
-		s.palettePtr = unsafe.Pointer(&s.parsedPalette[0])
+		if s.parsedPalette != nil {
+			// The palette was already parsed, so we parse the new entry too. Otherwise, it is parsed along with
+			// the rest of the palette once needed.
+			s.parsePaletteEntry(bl)
+			// Update the palette pointer because appending might have changed the
+			// location of the underlying array.
+			s.palettePtr = unsafe.Pointer(&s.parsedPalette[0])
+		}
+	}
+	return ptr
+}
+
+// cachedPtrFor returns a palette pointer for the world.Block passed like ptrFor, but first looks up the block by its
+// hash in the cache passed. This avoids a palette lookup for every block when setting many blocks at once.
+func (s *structure) cachedPtrFor(cache map[uint64]int32, b world.Block) int32 {
+	h := b.Hash()
+	if h == math.MaxUint64 {
+		// The block has no unique hash, so we can't cache its pointer.
+		return s.ptrFor(b)
+	}
+	ptr, ok := cache[h]
+	if !ok {
+		ptr = s.ptrFor(b)
+		cache[h] = ptr
 	}
 	return ptr
 }
 
+// blockIndex returns the palette index of the block at the offset passed, or -1 if no block is placed there.
+func (s *structure) blockIndex(offset int) int32 {
+	return s.blocks[offset]
+}
+
+// liquidIndex returns the palette index of the liquid at the offset passed, or -1 if no liquid is placed there.
+func (s *structure) liquidIndex(offset int) int32 {
+	return s.liquids[offset]
+}
+
+// positionData returns the block position data stored for the offset passed, if any. Most structures hold no
+// block position data at all, in which case the offset is not converted to a key to look it up.
+func (s *structure) positionData(offset int) (blockPositionData, bool) {
+	if len(s.palette.BlockPositionData) == 0 {
+		return blockPositionData{}, false
+	}
+	d, ok := s.palette.BlockPositionData[strconv.Itoa(offset)]
+	return d, ok
+}
+
 // At returns the block at the x, y and z passed in the structure.
 func (s *structure) At(x, y, z int, _ func(x int, y int, z int) world.Block) (world.Block, world.Liquid) {
+	if s.palettePtr == nil {
+		s.ensureParsed()
+	}
 	offset := (x * s.l * s.h) + (y * s.l) + z
 	index := *(*int32)(unsafe.Pointer(uintptr(s.blocksPtr) + uintptr(offset<<2)))
 	if index == -1 {
@@ -129,7 +220,7 @@ func (s *structure) At(x, y, z int, _ func(x int, y int, z int) world.Block) (wo
 
 	b := entry.b
 	if entry.hasNBT {
-		if nbtData, ok := s.palette.BlockPositionData[strconv.Itoa(offset)]; ok {
+		if nbtData, ok := s.positionData(offset); ok {
 			b = entry.b.(world.NBTer).DecodeNBT(nbtData.BlockEntityData).(world.Block)
 		}
 	}
@@ -139,45 +230,191 @@ func (s *structure) At(x, y, z int, _ func(x int, y int, z int) world.Block) (wo
 		return b, nil
 	}
 	en := *(*parsedBlock)(unsafe.Pointer(uintptr(s.palettePtr) + uintptr(index)*sizeOfBlock))
-	return b, en.b.(world.Liquid)
+	return b, en.liq
+}
+
+// BlockAt returns the block at the x, y and z passed in the structure. Unlike At, BlockAt does not look up the
+// liquid at the position, which makes it cheaper for callers that are not interested in liquids.
+func (s *structure) BlockAt(x, y, z int) world.Block {
+	if s.palettePtr == nil {
+		s.ensureParsed()
+	}
+	offset := (x * s.l * s.h) + (y * s.l) + z
+	index := *(*int32)(unsafe.Pointer(uintptr(s.blocksPtr) + uintptr(offset<<2)))
+	if index == -1 {
+		// Minecraft structures use -1 to indicate that there is no block at a position.
+		return nil
+	}
+	entry := *(*parsedBlock)(unsafe.Pointer(uintptr(s.palettePtr) + uintptr(index)*sizeOfBlock))
+	if entry.hasNBT {
+		if nbtData, ok := s.positionData(offset); ok {
+			return entry.b.(world.NBTer).DecodeNBT(nbtData.BlockEntityData).(world.Block)
+		}
+	}
+	return entry.b
+}
+
+// Region returns all blocks and liquids in the cuboid spanning from min (inclusive) to max (exclusive). The
+// slices returned are ordered the same way as the structure itself: x first, then y, then z, so the value at
+// position (x, y, z) is found at index ((x-min[0])*dy+(y-min[1]))*dz+(z-min[2]), where dy and dz are the
+// height and length of the region. Region will panic if the region exceeds the bounds of the structure.
+// Unlike repeated calls to At, Region resolves every palette entry only once.
+func (s *structure) Region(min, max [3]int) ([]world.Block, []world.Liquid) {
+	dx, dy, dz := max[0]-min[0], max[1]-min[1], max[2]-min[2]
+	if dx <= 0 || dy <= 0 || dz <= 0 {
+		return nil, nil
+	}
+	if dims := s.Dimensions(); min[0] < 0 || min[1] < 0 || min[2] < 0 || max[0] > dims[0] || max[1] > dims[1] || max[2] > dims[2] {
+		panic(fmt.Sprintf("region %v-%v exceeds structure dimensions %v", min, max, dims))
+	}
+	s.ensureParsed()
+	blocks, liquids := make([]world.Block, dx*dy*dz), make([]world.Liquid, dx*dy*dz)
+
+	i := 0
+	for x := min[0]; x < max[0]; x++ {
+		for y := min[1]; y < max[1]; y++ {
+			offset := (x * s.l * s.h) + (y * s.l) + min[2]
+			for z := min[2]; z < max[2]; z, offset, i = z+1, offset+1, i+1 {
+				if index := s.blocks[offset]; index != -1 {
+					entry := s.parsedPalette[index]
+					blocks[i] = entry.b
+					if entry.hasNBT {
+						if nbtData, ok := s.positionData(offset); ok {
+							blocks[i] = entry.b.(world.NBTer).DecodeNBT(nbtData.BlockEntityData).(world.Block)
+						}
+					}
+				}
+				if index := s.liquids[offset]; index != -1 {
+					liquids[i] = s.parsedPalette[index].liq
+				}
+			}
+		}
+	}
+	return blocks, liquids
+}
+
+// IndexAt returns the raw palette index at the x, y and z passed in the structure. Layer 0 holds the blocks of
+// the structure and layer 1 holds the liquids. An index of -1 indicates that nothing is present at the
+// position. IndexAt will panic if the position exceeds the bounds of the structure or if the layer is not 0
+// or 1.
+func (s *structure) IndexAt(x, y, z, layer int) int32 {
+	offset := (x * s.l * s.h) + (y * s.l) + z
+	switch layer {
+	case 0:
+		return s.blocks[offset]
+	case 1:
+		return s.liquids[offset]
+	}
+	panic(fmt.Sprintf("invalid layer %v: must be 0 or 1", layer))
+}
+
+// PaletteEntry returns the name, states and block version of the palette entry at the index passed, as
+// found in the palette currently in use. The index passed is typically obtained using IndexAt. PaletteEntry
+// will panic if the index is out of range. The states returned must not be modified.
+func (s *structure) PaletteEntry(i int) (name string, states map[string]interface{}, version int32) {
+	bl := s.palette.BlockPalette[i]
+	return bl.Name, bl.States, bl.Version
+}
+
+// PaletteSize returns the number of entries in the palette currently in use by the structure. Unlike Palette,
+// PaletteSize does not resolve the entries to blocks.
+func (s *structure) PaletteSize() int {
+	return len(s.palette.BlockPalette)
+}
+
+// Palette returns all distinct blocks in the palette currently in use by the structure. The index of a block
+// in the slice returned corresponds to the palette index returned by IndexAt. Palette entries that could
+// not be resolved to a registered block are nil. The slice returned is a copy, so modifying it does not
+// affect the structure.
+func (s *structure) Palette() []world.Block {
+	s.ensureParsed()
+	blocks := make([]world.Block, len(s.parsedPalette))
+	for i, entry := range s.parsedPalette {
+		blocks[i] = entry.b
+	}
+	return blocks
+}
+
+// sharePalette makes the structure use the block palette and parsed palette of the structure passed, without
+// copying them. The capacity of the shared slices is capped, so that adding a palette entry to either structure
+// copies the palette rather than overwriting entries of the other.
+func (s *structure) sharePalette(from *structure) {
+	s.palette.BlockPalette = from.palette.BlockPalette[:len(from.palette.BlockPalette):len(from.palette.BlockPalette)]
+	s.parsedPalette = from.parsedPalette[:len(from.parsedPalette):len(from.parsedPalette)]
 }
 
+// parallelPaletteThreshold is the palette size from which parsePalette resolves palette entries across multiple
+// goroutines. Smaller palettes are resolved faster on a single goroutine.
+const parallelPaletteThreshold = 512
+
 // parsePalette parses the palette of the structure so that blocks can be looked up more quickly using At.
+// Large palettes, such as those of structures converted from Java Edition, are parsed across multiple
+// goroutines.
 func (s *structure) parsePalette() {
-	s.parsedPalette = make([]parsedBlock, 0, len(s.palette.BlockPalette))
-	for _, bl := range s.palette.BlockPalette {
-		s.parsePaletteEntry(bl)
+	if s.registry == nil {
+		s.registry = worldRegistry{}
+	}
+	entries := s.palette.BlockPalette
+	s.parsedPalette = make([]parsedBlock, len(entries))
+
+	workers := runtime.GOMAXPROCS(0)
+	if len(entries) < parallelPaletteThreshold || workers == 1 {
+		for i, bl := range entries {
+			s.parsedPalette[i] = s.resolveEntry(bl)
+		}
+		return
+	}
+	var wg sync.WaitGroup
+	n := (len(entries) + workers - 1) / workers
+	for start := 0; start < len(entries); start += n {
+		end := start + n
+		if end > len(entries) {
+			end = len(entries)
+		}
+		wg.Add(1)
+		go func(start, end int) {
+			defer wg.Done()
+			for i := start; i < end; i++ {
+				s.parsedPalette[i] = s.resolveEntry(entries[i])
+			}
+		}(start, end)
 	}
+	wg.Wait()
 }
 
 // parsePaletteEntry parses a single palette entry and adds it to the parsed palette.
 func (s *structure) parsePaletteEntry(bl block) {
+	if s.registry == nil {
+		s.registry = worldRegistry{}
+	}
+	s.parsedPalette = append(s.parsedPalette, s.resolveEntry(bl))
+}
+
+// resolveEntry upgrades the palette entry passed and resolves it to a block using the registry of the
+// structure. resolveEntry may be called from multiple goroutines at the same time.
+func (s *structure) resolveEntry(bl block) parsedBlock {
 	upgraded := blockupgrader.Upgrade(blockupgrader.BlockState{
 		Name:       bl.Name,
 		Properties: bl.States,
 		Version:    bl.Version,
 	})
-	b, _ := world.BlockByName(upgraded.Name, upgraded.Properties)
-	_, n := b.(world.NBTer)
-	s.parsedPalette = append(s.parsedPalette, parsedBlock{
-		b:      b,
-		hasNBT: n,
-	})
+	b, _ := s.registry.BlockByName(upgraded.Name, upgraded.Properties)
+	return newParsedBlock(b)
 }
 
 // lookup looks up the world.Block passed in the palette of the structure. If not found, the value returned is
-// -1.
+// -1. The properties passed are compared with those of palette entries after converting them to their canonical
+// types, as decided by the MatchMode of the structure.
 func (s *structure) lookup(name string, properties map[string]interface{}) int32 {
+	properties = canonicalStates(properties)
 	for index, block := range s.palette.BlockPalette {
-		if block.Name == name {
-			allEqual := true
-			for k, v := range block.States {
-				if bVal, _ := properties[k]; bVal != v {
-					allEqual = false
-					break
-				}
-			}
-			if allEqual {
+		if block.Name == name && statesEqual(block.States, properties) {
+			return int32(index)
+		}
+	}
+	if s.match == MatchFuzzy {
+		for index, block := range s.palette.BlockPalette {
+			if block.Name == name && statesSubset(block.States, properties) {
 				return int32(index)
 			}
 		}
@@ -185,9 +422,38 @@ func (s *structure) lookup(name string, properties map[string]interface{}) int32
 	return -1
 }
 
+// lookupAll looks up all palette entries of the world.Block passed, as the palette may hold multiple entries for the
+// same block, such as after pasting structures read from files. Entries are compared with the properties passed
+// after converting both to their canonical types. If no entry is equal and the MatchMode of the structure is
+// MatchFuzzy, the entry found by lookup is returned, if any.
+func (s *structure) lookupAll(name string, properties map[string]interface{}) []int32 {
+	properties = canonicalStates(properties)
+	var indices []int32
+	for index, block := range s.palette.BlockPalette {
+		if block.Name == name && statesEqual(canonicalStates(block.States), properties) {
+			indices = append(indices, int32(index))
+		}
+	}
+	if len(indices) == 0 {
+		if index := s.lookup(name, properties); index != -1 {
+			indices = append(indices, index)
+		}
+	}
+	return indices
+}
+
 // check verifies if the structure is valid. It returns an error if anything in the structure was found to be
 // incorrect.
 func (s *structure) check() error {
+	layers := make([]int, len(s.Structure.BlockIndices))
+	for i, indices := range s.Structure.BlockIndices {
+		layers[i] = len(indices)
+	}
+	return s.checkLayers(layers)
+}
+
+// checkLayers verifies if the structure is valid, like check, for block index layers with the lengths passed.
+func (s *structure) checkLayers(layers []int) error {
 	if s.FormatVersion != version {
 		return fmt.Errorf("unsupported format version %v: expected version %v", s.FormatVersion, version)
 	}
@@ -200,7 +466,7 @@ func (s *structure) check() error {
 	if s.Structure.Palettes == nil {
 		s.Structure.Palettes = map[string]palette{}
 	}
-	if len(s.Structure.BlockIndices) == 0 {
+	if len(layers) == 0 {
 		return fmt.Errorf("structure has no blocks in it")
 	}
 	if len(s.Structure.Palettes) == 0 {
@@ -210,9 +476,21 @@ func (s *structure) check() error {
 	if size <= 0 {
 		return fmt.Errorf("structure has a total size of 0 blocks or less (%v)", size)
 	}
-	for i, indices := range s.Structure.BlockIndices {
-		if len(indices) != size {
-			return fmt.Errorf("structure is %vx%vx%v and should have %v blocks, but got only %v in storage %v", s.Size[0], s.Size[1], s.Size[2], size, len(indices), i)
+	for i, n := range layers {
+		if n != size {
+			return fmt.Errorf("structure is %vx%vx%v and should have %v blocks, but got only %v in storage %v", s.Size[0], s.Size[1], s.Size[2], size, n, i)
+		}
+	}
+	for name, p := range s.Structure.Palettes {
+		var invalid []string
+		for k := range p.BlockPositionData {
+			if offset, err := strconv.Atoi(k); err != nil || offset < 0 || offset >= size {
+				invalid = append(invalid, k)
+			}
+		}
+		if len(invalid) != 0 {
+			sort.Strings(invalid)
+			return fmt.Errorf("palette %v has block position data with invalid offsets %v: offsets must be integers in the range 0-%v", name, invalid, size-1)
 		}
 	}
 	paletteLen := -1
@@ -228,6 +506,111 @@ func (s *structure) check() error {
 	return nil
 }
 
+// opaqueBlockEntities holds the names of blocks that hold block entity data in Minecraft, but of which the block
+// entity data is not decoded by the world.Block they resolve to. Block position data of these blocks is kept in
+// the structure as is, so that it is preserved when the structure is written again.
+var opaqueBlockEntities = map[string]bool{
+	"minecraft:command_block":           true,
+	"minecraft:repeating_command_block": true,
+	"minecraft:chain_command_block":     true,
+	"minecraft:mob_spawner":             true,
+}
+
+// unknownBlockType is the type of the world.Block Dragonfly resolves block states to that exist in Minecraft, but
+// that Dragonfly does not implement.
+const unknownBlockType = "github.com/df-mc/dragonfly/server/world.unknownBlock"
+
+// unimplemented checks if the world.Block passed is a block that Dragonfly does not implement. Such blocks are
+// placed as they are, but never decode or encode block entity data, even if they hold block entity data in
+// Minecraft.
+func unimplemented(b world.Block) bool {
+	t := reflect.TypeOf(b)
+	return t.PkgPath()+"."+t.Name() == unknownBlockType
+}
+
+// checkPositionData verifies if all block position data in the palette currently used refers to a position that
+// holds a block capable of holding block entity data. It returns an error listing all offsets that do not.
+// Positions holding blocks that could not be resolved, or that resolved to blocks Dragonfly does not implement,
+// such as hoppers and beds, are not checked, as their capabilities are unknown.
+// Only the palette entries referred to by block position data are resolved. The index of the block at an offset
+// is obtained using the function passed.
+func (s *structure) checkPositionData(blockIndex func(offset int) int32) error {
+	if s.registry == nil {
+		s.registry = worldRegistry{}
+	}
+	var invalid []int
+	resolved := make(map[int32]parsedBlock)
+	for k := range s.palette.BlockPositionData {
+		// check already verified that all keys are valid offsets.
+		offset, _ := strconv.Atoi(k)
+		index := blockIndex(offset)
+		if index == -1 {
+			invalid = append(invalid, offset)
+			continue
+		}
+		entry, ok := resolved[index]
+		if !ok {
+			if s.parsedPalette != nil {
+				entry = s.parsedPalette[index]
+			} else {
+				entry = s.resolveEntry(s.palette.BlockPalette[index])
+			}
+			resolved[index] = entry
+		}
+		if entry.b != nil && !entry.hasNBT && !unimplemented(entry.b) && !opaqueBlockEntities[s.palette.BlockPalette[index].Name] {
+			invalid = append(invalid, offset)
+		}
+	}
+	if len(invalid) != 0 {
+		sort.Ints(invalid)
+		return fmt.Errorf("palette %v has block position data at offsets %v which do not hold a block with block entity data", s.paletteName, invalid)
+	}
+	return nil
+}
+
+// checkLiquids verifies if every index in the liquid layer of the structure refers to a palette entry in the palette
+// currently used that is a liquid. It returns an error listing all indices that do not. Palette entries that could
+// not be resolved are not checked, as their capabilities are unknown. Only the palette entries referred to by the
+// liquid layer are resolved. The index of the liquid at an offset is obtained using the function passed.
+func (s *structure) checkLiquids(liquidIndex func(offset int) int32) error {
+	if s.registry == nil {
+		s.registry = worldRegistry{}
+	}
+	dims := s.Dimensions()
+	// valid holds, for every palette index found in the liquid layer, whether it refers to a liquid.
+	valid := make(map[int32]bool)
+	var invalid []int
+	for offset, n := 0, dims[0]*dims[1]*dims[2]; offset < n; offset++ {
+		index := liquidIndex(offset)
+		if index == -1 {
+			continue
+		}
+		if _, ok := valid[index]; ok {
+			continue
+		}
+		if index < -1 || int(index) >= len(s.palette.BlockPalette) {
+			valid[index] = false
+			invalid = append(invalid, int(index))
+			continue
+		}
+		var entry parsedBlock
+		if s.parsedPalette != nil {
+			entry = s.parsedPalette[index]
+		} else {
+			entry = s.resolveEntry(s.palette.BlockPalette[index])
+		}
+		valid[index] = entry.b == nil || entry.liq != nil
+		if !valid[index] {
+			invalid = append(invalid, int(index))
+		}
+	}
+	if len(invalid) != 0 {
+		sort.Ints(invalid)
+		return fmt.Errorf("palette %v has indices %v in the liquid layer which do not refer to a liquid", s.paletteName, invalid)
+	}
+	return nil
+}
+
 // structureData holds the actual data of the structure. This includes both blocks and entities.
 type structureData struct {
 	// BlockIndices holds the actual block data. This is a two-dimensional slice, where the first indicates
@@ -0,0 +1,62 @@
+package structure
+
+import (
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"math/bits"
+)
+
+// markDirty marks the offset passed as changed, so that it is returned by Dirty. The bitset holding the dirty
+// offsets is only allocated once the first offset is marked.
+func (s *structure) markDirty(offset int) {
+	if s.dirty == nil {
+		s.dirty = make([]uint64, (len(s.blocks)+63)/64)
+	}
+	s.dirty[offset>>6] |= 1 << (offset & 63)
+}
+
+// Dirty returns the positions of all blocks and liquids in the structure that were changed since it was created
+// or read, or since ResetDirty was last called, ordered by their position, x first, then y, then z. Positions set
+// to the block they already held are included too. Dirty may be used to save a long-lived structure that is edited
+// in small steps incrementally, or to update only the changed blocks of a structure built in a world.
+// Entities are not held at block positions, so changes to the entities of the structure, such as by AddEntity or
+// FilterEntities, are not included in the positions returned. EntitiesDirty reports these changes instead.
+// Structures returned by functions that copy a structure, such as Vary, track their changes separately from the
+// structure they were copied from, starting without any dirty positions.
+func (s *structure) Dirty() []cube.Pos {
+	var positions []cube.Pos
+	for i, word := range s.dirty {
+		for word != 0 {
+			bit := bits.TrailingZeros64(word)
+			positions = append(positions, s.posOf(i<<6+bit))
+			word &= word - 1
+		}
+	}
+	return positions
+}
+
+// EntitiesDirty checks if the entities of the structure were added, removed or changed since it was created or read,
+// or since ResetDirty was last called. Together with Dirty, it may be used to decide whether a structure needs saving.
+func (s *structure) EntitiesDirty() bool {
+	return s.entitiesDirty
+}
+
+// ResetDirty marks all positions and the entities in the structure as unchanged, so that Dirty and EntitiesDirty
+// report only changes made after calling ResetDirty. ResetDirty is typically called after saving the structure.
+func (s *structure) ResetDirty() {
+	s.dirty, s.entitiesDirty = nil, false
+}
+
+// dirtyCount returns the number of positions in the structure changed since it was created, read or last reset.
+func (s *structure) dirtyCount() int {
+	n := 0
+	for _, word := range s.dirty {
+		n += bits.OnesCount64(word)
+	}
+	return n
+}
+
+// modified checks if the structure was changed in any way since it was created, read or last reset, either by
+// changing its blocks and liquids or by changing its entities.
+func (s *structure) modified() bool {
+	return s.dirty != nil || s.entitiesDirty
+}
@@ -0,0 +1,34 @@
+package structure
+
+import (
+	df "github.com/df-mc/dragonfly/server/block"
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/go-gl/mathgl/mgl64"
+	"reflect"
+	"testing"
+)
+
+func TestDirty(t *testing.T) {
+	s := New([3]int{2, 2, 2})
+	if got := s.Dirty(); len(got) != 0 {
+		t.Fatalf("Dirty() of a new structure = %v, want none", got)
+	}
+	s.Set(1, 0, 1, df.Stone{}, nil)
+	s.Set(0, 1, 0, df.Dirt{}, nil)
+	s.Set(1, 0, 1, df.Dirt{}, nil)
+	if got, want := s.Dirty(), []cube.Pos{{0, 1, 0}, {1, 0, 1}}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("Dirty() = %v, want %v", got, want)
+	}
+	if s.EntitiesDirty() {
+		t.Fatalf("EntitiesDirty() = true without changing any entities")
+	}
+
+	s.ResetDirty()
+	if got := s.Dirty(); len(got) != 0 {
+		t.Fatalf("Dirty() after ResetDirty = %v, want none", got)
+	}
+	s.AddEntity("minecraft:pig", mgl64.Vec3{0.5, 0, 0.5}, nil)
+	if !s.EntitiesDirty() || len(s.Dirty()) != 0 {
+		t.Fatalf("adding an entity = %v dirty positions and EntitiesDirty() %v, want none and true", len(s.Dirty()), s.EntitiesDirty())
+	}
+}
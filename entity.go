@@ -0,0 +1,247 @@
+package structure
+
+import (
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/go-gl/mathgl/mgl64"
+)
+
+// entityPos returns the position stored in the entity data passed. If the data holds no valid position, false is
+// returned.
+func entityPos(data map[string]interface{}) (mgl64.Vec3, bool) {
+	var pos mgl64.Vec3
+	switch v := data["Pos"].(type) {
+	case []float32:
+		if len(v) != 3 {
+			return pos, false
+		}
+		for i, f := range v {
+			pos[i] = float64(f)
+		}
+	case []interface{}:
+		if len(v) != 3 {
+			return pos, false
+		}
+		for i, f := range v {
+			f32, ok := f.(float32)
+			if !ok {
+				return pos, false
+			}
+			pos[i] = float64(f32)
+		}
+	default:
+		return pos, false
+	}
+	return pos, true
+}
+
+// withEntityPos returns a copy of the entity data passed with its position set to the position passed.
+func withEntityPos(data map[string]interface{}, pos mgl64.Vec3) map[string]interface{} {
+	m := make(map[string]interface{}, len(data))
+	for k, v := range data {
+		m[k] = v
+	}
+	m["Pos"] = []float32{float32(pos[0]), float32(pos[1]), float32(pos[2])}
+	return m
+}
+
+// translateEntities returns a copy of the entities passed, with the offset passed added to the position of every
+// entity. Entities without a valid position are returned as is.
+func translateEntities(entities []map[string]interface{}, offset mgl64.Vec3) []map[string]interface{} {
+	if entities == nil {
+		return nil
+	}
+	translated := make([]map[string]interface{}, len(entities))
+	for i, data := range entities {
+		translated[i] = data
+		if pos, ok := entityPos(data); ok {
+			translated[i] = withEntityPos(data, pos.Add(offset))
+		}
+	}
+	return translated
+}
+
+// origin returns the world origin of the structure as an mgl64.Vec3.
+func (s *structure) origin() mgl64.Vec3 {
+	return mgl64.Vec3{float64(s.Origin[0]), float64(s.Origin[1]), float64(s.Origin[2])}
+}
+
+// spawnEntities spawns the entities passed, with positions relative to a structure, in the world.World passed, as if
+// the structure was built at the position passed. Entities of which the type is not registered in the world's
+// EntityRegistry, or that cannot be decoded, are skipped.
+func spawnEntities(w *world.World, pos cube.Pos, entities []map[string]interface{}) {
+	reg := w.EntityRegistry()
+	for _, data := range entities {
+		id, _ := data["identifier"].(string)
+		t, ok := reg.Lookup(id)
+		if !ok {
+			continue
+		}
+		st, ok := t.(world.SaveableEntityType)
+		if !ok {
+			continue
+		}
+		p, ok := entityPos(data)
+		if !ok {
+			continue
+		}
+		if e := st.DecodeNBT(withEntityPos(data, p.Add(pos.Vec3()))); e != nil {
+			w.AddEntity(e)
+		}
+	}
+}
+
+// spawnMarked calls the function of the entity marker of every entity passed, with positions relative to a structure,
+// that has the tag of one of the markers passed, as if the structure was built at the position passed. The
+// entities that do not have any of the tags are returned.
+func spawnMarked(w *world.World, pos cube.Pos, entities []map[string]interface{}, markers []entityMarker) []map[string]interface{} {
+	unmarked := make([]map[string]interface{}, 0, len(entities))
+	for _, data := range entities {
+		m, ok := markedBy(data, markers)
+		if !ok {
+			unmarked = append(unmarked, data)
+			continue
+		}
+		e := newEntity(data)
+		e.Position = e.Position.Add(pos.Vec3())
+		m.spawn(w, e)
+	}
+	return unmarked
+}
+
+// markedBy returns the first of the entity markers passed of which the tag is in the 'Tags' list of the entity
+// data passed. If none of the tags are present, false is returned.
+func markedBy(data map[string]interface{}, markers []entityMarker) (entityMarker, bool) {
+	var tags []string
+	switch v := data["Tags"].(type) {
+	case []string:
+		tags = v
+	case []interface{}:
+		for _, tag := range v {
+			if str, ok := tag.(string); ok {
+				tags = append(tags, str)
+			}
+		}
+	}
+	for _, m := range markers {
+		for _, tag := range tags {
+			if tag == m.tag {
+				return m, true
+			}
+		}
+	}
+	return entityMarker{}, false
+}
+
+// Entity is an entity held by a Structure.
+type Entity struct {
+	// Identifier is the identifier of the entity type, such as 'minecraft:zombie'.
+	Identifier string
+	// Position is the position of the entity relative to the origin of the Structure.
+	Position mgl64.Vec3
+	// Data is the full NBT data of the entity. It must not be modified.
+	Data map[string]interface{}
+}
+
+// newEntity returns an Entity for the entity data passed.
+func newEntity(data map[string]interface{}) Entity {
+	id, _ := data["identifier"].(string)
+	pos, _ := entityPos(data)
+	return Entity{Identifier: id, Position: pos, Data: data}
+}
+
+// FilterEntities removes all entities from the structure for which keep returns false. Entities for which keep
+// returns true are kept in their original order.
+func (s *structure) FilterEntities(keep func(e Entity) bool) {
+	kept := s.Structure.Entities[:0]
+	for _, data := range s.Structure.Entities {
+		if keep(newEntity(data)) {
+			kept = append(kept, data)
+		}
+	}
+	for i := len(kept); i < len(s.Structure.Entities); i++ {
+		// Clear the remaining entries so that the data they refer to may be garbage collected.
+		s.Structure.Entities[i] = nil
+	}
+	s.Structure.Entities = kept
+	s.entitiesDirty = true
+}
+
+// StripEntities removes all entities from the structure.
+func (s *structure) StripEntities() {
+	s.Structure.Entities = nil
+	s.entitiesDirty = true
+}
+
+// SplitEntities splits the Structure into two Structures with the same dimensions and origin: blocksOnly holds the
+// blocks and liquids of the Structure without its entities, and entitiesOnly holds the entities of the Structure
+// without any blocks, so that building it does not change any blocks in the world. The entities of a Structure may
+// then be processed, stored or built separately from its blocks, such as to build the blocks at once and spawn the
+// entities gradually afterwards. The Structure itself is not modified.
+func (s Structure) SplitEntities() (blocksOnly, entitiesOnly Structure) {
+	blocksOnly = s.clone()
+	blocksOnly.Structure.Entities = nil
+
+	entitiesOnly = New(s.Dimensions())
+	for i := range entitiesOnly.blocks {
+		entitiesOnly.blocks[i] = -1
+	}
+	entitiesOnly.Origin = append([]int32(nil), s.Origin...)
+	entitiesOnly.Structure.Entities = append([]map[string]interface{}(nil), s.Structure.Entities...)
+	return blocksOnly, entitiesOnly
+}
+
+// AddEntity adds an entity with the identifier passed, such as 'minecraft:armor_stand', to the structure at the
+// position passed, relative to the origin of the structure. The NBT passed holds any additional data of the
+// entity and may be nil. The identifier and position passed take precedence over those present in the NBT.
+func (s *structure) AddEntity(identifier string, pos mgl64.Vec3, nbt map[string]interface{}) {
+	data := withEntityPos(nbt, pos)
+	data["identifier"] = identifier
+	s.Structure.Entities = append(s.Structure.Entities, data)
+	s.entitiesDirty = true
+}
+
+// rotateEntities returns a copy of the entities passed, rotated by 90 degrees clockwise if direction is 1, or
+// anti-clockwise if direction is -1, within a structure with the width and length passed. Both the position and
+// the yaw of every entity are rotated.
+func rotateEntities(entities []map[string]interface{}, width, length int, direction int) []map[string]interface{} {
+	if entities == nil {
+		return nil
+	}
+	rotated := make([]map[string]interface{}, len(entities))
+	for i, data := range entities {
+		rotated[i] = data
+		pos, ok := entityPos(data)
+		if !ok {
+			continue
+		}
+		if direction == 1 {
+			pos = mgl64.Vec3{float64(length) - pos[2], pos[1], pos[0]}
+		} else {
+			pos = mgl64.Vec3{pos[2], pos[1], float64(width) - pos[0]}
+		}
+		rotated[i] = withEntityPos(data, pos)
+		if yaw, pitch, ok := entityRotation(data); ok {
+			rotated[i]["Rotation"] = []float32{float32(normaliseAngle(float64(yaw) + float64(direction)*90)), pitch}
+		}
+	}
+	return rotated
+}
+
+// entityRotation returns the yaw and pitch stored in the entity data passed. If the data holds no valid rotation,
+// false is returned.
+func entityRotation(data map[string]interface{}) (yaw, pitch float32, ok bool) {
+	switch v := data["Rotation"].(type) {
+	case []float32:
+		if len(v) == 2 {
+			return v[0], v[1], true
+		}
+	case []interface{}:
+		if len(v) == 2 {
+			yaw, ok1 := v[0].(float32)
+			pitch, ok2 := v[1].(float32)
+			return yaw, pitch, ok1 && ok2
+		}
+	}
+	return 0, 0, false
+}
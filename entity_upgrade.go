@@ -0,0 +1,187 @@
+package structure
+
+import (
+	"strings"
+)
+
+// upgradeEntities upgrades the data of the entities passed, which may have been written by an older version of
+// Minecraft, so that it may be decoded by current versions. Entities that are already up-to-date are returned
+// as is.
+func upgradeEntities(entities []map[string]interface{}) []map[string]interface{} {
+	for i, data := range entities {
+		entities[i] = upgradeEntity(data)
+	}
+	return entities
+}
+
+// upgradeEntity upgrades the data of a single entity. Older versions of Minecraft stored the type of an entity
+// as a numeric ID in the 'id' field instead of an 'identifier', and identifiers may lack the 'minecraft'
+// namespace.
+func upgradeEntity(data map[string]interface{}) map[string]interface{} {
+	id, ok := data["identifier"].(string)
+	if !ok {
+		if legacy, ok := legacyEntityID(data["id"]); ok {
+			id, ok = legacyEntityIdentifiers[legacy]
+			if !ok {
+				return data
+			}
+		} else if str, ok := data["id"].(string); ok && str != "" {
+			id = str
+		} else {
+			return data
+		}
+	}
+	id = normaliseIdentifier(id)
+	if id == data["identifier"] {
+		return data
+	}
+	upgraded := make(map[string]interface{}, len(data)+1)
+	for k, v := range data {
+		upgraded[k] = v
+	}
+	upgraded["identifier"] = id
+	return upgraded
+}
+
+// upgradeBlockEntities upgrades the block entity data held by the block position data of all palettes passed, which
+// may have been written by an older version of Minecraft, so that it may be decoded by current versions. Only the
+// data of spawners is currently upgraded.
+func upgradeBlockEntities(palettes map[string]palette) {
+	for _, p := range palettes {
+		for k, d := range p.BlockPositionData {
+			if id, _ := d.BlockEntityData["id"].(string); id != "MobSpawner" {
+				continue
+			}
+			if upgraded := upgradeSpawner(d.BlockEntityData); upgraded != nil {
+				d.BlockEntityData = upgraded
+				p.BlockPositionData[k] = d
+			}
+		}
+	}
+}
+
+// upgradeSpawner upgrades the block entity data of a spawner. Older versions of Minecraft stored the type of the
+// entity spawned as a numeric ID in the 'EntityId' field instead of an 'EntityIdentifier', and identifiers may lack
+// the 'minecraft' namespace. If the data is already up-to-date, nil is returned.
+func upgradeSpawner(data map[string]interface{}) map[string]interface{} {
+	id, ok := data["EntityIdentifier"].(string)
+	if !ok {
+		legacy, ok := legacyEntityID(data["EntityId"])
+		if !ok {
+			return nil
+		}
+		if id, ok = legacyEntityIdentifiers[legacy]; !ok {
+			return nil
+		}
+	}
+	if id == "" {
+		return nil
+	}
+	if id = normaliseIdentifier(id); id == data["EntityIdentifier"] {
+		return nil
+	}
+	upgraded := copyBlockEntity(data)
+	upgraded["EntityIdentifier"] = id
+	return upgraded
+}
+
+// normaliseIdentifier adds the 'minecraft' namespace to the entity identifier passed if it has no namespace.
+func normaliseIdentifier(id string) string {
+	if !strings.Contains(id, ":") {
+		return "minecraft:" + strings.ToLower(id)
+	}
+	return id
+}
+
+// legacyEntityID returns the legacy numeric entity type ID held by the value passed. The lower byte of the value
+// holds the type, while the upper bytes hold flags such as whether the entity is a mob.
+func legacyEntityID(v interface{}) (int32, bool) {
+	switch v := v.(type) {
+	case int32:
+		return v & 0xff, true
+	case int64:
+		return int32(v & 0xff), true
+	case int16:
+		return int32(v & 0xff), true
+	case byte:
+		return int32(v), true
+	}
+	return 0, false
+}
+
+// legacyEntityIdentifiers maps legacy numeric entity type IDs to the identifiers of the entity types.
+var legacyEntityIdentifiers = map[int32]string{
+	10:  "minecraft:chicken",
+	11:  "minecraft:cow",
+	12:  "minecraft:pig",
+	13:  "minecraft:sheep",
+	14:  "minecraft:wolf",
+	15:  "minecraft:villager",
+	16:  "minecraft:mooshroom",
+	17:  "minecraft:squid",
+	18:  "minecraft:rabbit",
+	19:  "minecraft:bat",
+	20:  "minecraft:iron_golem",
+	21:  "minecraft:snow_golem",
+	22:  "minecraft:ocelot",
+	23:  "minecraft:horse",
+	24:  "minecraft:donkey",
+	25:  "minecraft:mule",
+	26:  "minecraft:skeleton_horse",
+	27:  "minecraft:zombie_horse",
+	28:  "minecraft:polar_bear",
+	29:  "minecraft:llama",
+	30:  "minecraft:parrot",
+	31:  "minecraft:dolphin",
+	32:  "minecraft:zombie",
+	33:  "minecraft:creeper",
+	34:  "minecraft:skeleton",
+	35:  "minecraft:spider",
+	36:  "minecraft:zombie_pigman",
+	37:  "minecraft:slime",
+	38:  "minecraft:enderman",
+	39:  "minecraft:silverfish",
+	40:  "minecraft:cave_spider",
+	41:  "minecraft:ghast",
+	42:  "minecraft:magma_cube",
+	43:  "minecraft:blaze",
+	44:  "minecraft:zombie_villager",
+	45:  "minecraft:witch",
+	46:  "minecraft:stray",
+	47:  "minecraft:husk",
+	48:  "minecraft:wither_skeleton",
+	49:  "minecraft:guardian",
+	50:  "minecraft:elder_guardian",
+	51:  "minecraft:npc",
+	52:  "minecraft:wither",
+	53:  "minecraft:ender_dragon",
+	54:  "minecraft:shulker",
+	55:  "minecraft:endermite",
+	57:  "minecraft:vindicator",
+	58:  "minecraft:phantom",
+	61:  "minecraft:armor_stand",
+	64:  "minecraft:item",
+	65:  "minecraft:tnt",
+	66:  "minecraft:falling_block",
+	69:  "minecraft:xp_orb",
+	71:  "minecraft:ender_crystal",
+	74:  "minecraft:turtle",
+	75:  "minecraft:cat",
+	80:  "minecraft:arrow",
+	83:  "minecraft:painting",
+	84:  "minecraft:minecart",
+	88:  "minecraft:leash_knot",
+	90:  "minecraft:boat",
+	96:  "minecraft:hopper_minecart",
+	97:  "minecraft:tnt_minecart",
+	98:  "minecraft:chest_minecart",
+	100: "minecraft:command_block_minecart",
+	104: "minecraft:evocation_illager",
+	105: "minecraft:vex",
+	108: "minecraft:pufferfish",
+	109: "minecraft:salmon",
+	110: "minecraft:drowned",
+	111: "minecraft:tropicalfish",
+	112: "minecraft:cod",
+	113: "minecraft:panda",
+}
@@ -0,0 +1,123 @@
+package structure
+
+import (
+	"fmt"
+	"github.com/df-mc/dragonfly/server/world"
+)
+
+// FloodFill replaces the block at the start position and all blocks connected to it for which replaceable returns
+// true with the block passed. Two positions are connected if they share a face, and the fill never extends beyond
+// the bounds of the structure. replaceable is passed nil for positions that do not hold a block, and a nil block
+// may be passed to remove blocks instead. Liquids at positions filled are removed, and blocks that could not be
+// resolved to a registered block are never replaced.
+// FloodFill returns the number of positions filled, and whether the space filled is enclosed: that is, if none of
+// the positions filled lies on the boundary of the structure. Filling the inside of a hollow build with air from
+// any position within it, for example, reports whether the build is closed off from the outside.
+// FloodFill panics if the start position is outside the structure.
+func (s *structure) FloodFill(start [3]int, replaceable func(b world.Block) bool, with world.Block) (filled int, enclosed bool) {
+	dims := s.Dimensions()
+	if start[0] < 0 || start[1] < 0 || start[2] < 0 || start[0] >= dims[0] || start[1] >= dims[1] || start[2] >= dims[2] {
+		panic(fmt.Sprintf("start %v is outside structure dimensions %v", start, dims))
+	}
+	s.ensureParsed()
+
+	// Whether a block is replaceable is looked up once for every palette entry, except for blocks with block
+	// entity data, of which the data may affect the result.
+	cached := make(map[int32]bool)
+	canReplace := func(offset int) bool {
+		index := s.blocks[offset]
+		if index != -1 && s.parsedPalette[index].hasNBT {
+			b, ok := s.resolvedBlock(offset)
+			return ok && replaceable(b)
+		}
+		if r, ok := cached[index]; ok {
+			return r
+		}
+		b, ok := s.resolvedBlock(offset)
+		r := ok && replaceable(b)
+		cached[index] = r
+		return r
+	}
+
+	startOffset := (start[0] * s.l * s.h) + (start[1] * s.l) + start[2]
+	if !canReplace(startOffset) {
+		return 0, false
+	}
+	ptr, data := int32(-1), map[string]interface{}(nil)
+	if with != nil {
+		ptr, data = s.ptrFor(with), encodeNBT(with)
+	}
+
+	visited := make([]bool, len(s.blocks))
+	visited[startOffset] = true
+	stack := []int{startOffset}
+	enclosed = true
+	for len(stack) > 0 {
+		offset := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+		s.setIndex(offset, ptr, data)
+		filled++
+
+		x, y, z := offset/(s.l*s.h), (offset/s.l)%s.h, offset%s.l
+		if x == 0 || y == 0 || z == 0 || x == dims[0]-1 || y == dims[1]-1 || z == dims[2]-1 {
+			enclosed = false
+		}
+		for _, n := range [...][4]int{
+			{x - 1, y, z, -s.l * s.h}, {x + 1, y, z, s.l * s.h},
+			{x, y - 1, z, -s.l}, {x, y + 1, z, s.l},
+			{x, y, z - 1, -1}, {x, y, z + 1, 1},
+		} {
+			if n[0] < 0 || n[1] < 0 || n[2] < 0 || n[0] >= dims[0] || n[1] >= dims[1] || n[2] >= dims[2] {
+				continue
+			}
+			next := offset + n[3]
+			if visited[next] {
+				continue
+			}
+			visited[next] = true
+			if canReplace(next) {
+				stack = append(stack, next)
+			}
+		}
+	}
+	return filled, enclosed
+}
+
+// Shell removes all blocks from the structure that are not exposed, leaving only its outer shell. A block is
+// exposed if at least one of its faces touches air, a position without a block or the boundary of the structure.
+// The positions of blocks removed are left without a block, so that building the structure leaves these
+// positions in the world untouched. Air in the structure is kept.
+// Shell greatly reduces the number of blocks placed when building large, solid decorative structures such as
+// statues and domes, of which the inside is never seen.
+func (s *structure) Shell() {
+	dims := s.Dimensions()
+	air := s.airEntries()
+	open := func(index int32) bool {
+		return index == -1 || air[index]
+	}
+	var hidden []int
+	for x := 0; x < dims[0]; x++ {
+		for y := 0; y < dims[1]; y++ {
+			for z := 0; z < dims[2]; z++ {
+				offset := (x * s.l * s.h) + (y * s.l) + z
+				if open(s.blocks[offset]) {
+					continue
+				}
+				if x == 0 || y == 0 || z == 0 || x == dims[0]-1 || y == dims[1]-1 || z == dims[2]-1 {
+					continue
+				}
+				if open(s.blocks[offset-s.l*s.h]) || open(s.blocks[offset+s.l*s.h]) ||
+					open(s.blocks[offset-s.l]) || open(s.blocks[offset+s.l]) ||
+					open(s.blocks[offset-1]) || open(s.blocks[offset+1]) {
+					continue
+				}
+				// Blocks are only removed after all blocks have been checked, so that removing a block does not
+				// expose the blocks around it.
+				hidden = append(hidden, offset)
+			}
+		}
+	}
+	for _, offset := range hidden {
+		s.setIndex(offset, -1, nil)
+	}
+}
@@ -0,0 +1,123 @@
+package structure
+
+import (
+	"errors"
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/world"
+)
+
+// ErrFrozen is returned when attempting to modify a FrozenStructure.
+var ErrFrozen = errors.New("structure is frozen and cannot be modified")
+
+// FrozenStructure is an immutable view of a Structure, obtained using Structure.Frozen. Unlike a Structure, a
+// FrozenStructure is safe to use from multiple goroutines at the same time, which makes it suitable for
+// structures that are loaded once and shared, for example in a global structure library. FrozenStructure
+// implements the world.Structure interface, so it may be built in a Dragonfly world directly.
+type FrozenStructure struct {
+	s Structure
+}
+
+// Frozen returns an immutable copy of the Structure. Modifying the Structure afterwards does not affect the
+// FrozenStructure returned.
+func (s Structure) Frozen() FrozenStructure {
+	// The palette is parsed before freezing, so that concurrent reads do not race to parse it lazily.
+	s.ensureParsed()
+	return FrozenStructure{s: s.clone()}
+}
+
+// Thaw returns a mutable copy of the FrozenStructure as a Structure.
+func (f FrozenStructure) Thaw() Structure {
+	return f.s.clone()
+}
+
+// Dimensions returns the dimensions of the structure.
+func (f FrozenStructure) Dimensions() [3]int {
+	return f.s.Dimensions()
+}
+
+// At returns the block and liquid at the x, y and z passed in the structure.
+func (f FrozenStructure) At(x, y, z int, blockAt func(x, y, z int) world.Block) (world.Block, world.Liquid) {
+	return f.s.At(x, y, z, blockAt)
+}
+
+// BlockAt returns the block at the x, y and z passed in the structure. See Structure.BlockAt.
+func (f FrozenStructure) BlockAt(x, y, z int) world.Block {
+	return f.s.BlockAt(x, y, z)
+}
+
+// Region returns all blocks and liquids in a cuboid of the structure. See Structure.Region.
+func (f FrozenStructure) Region(min, max [3]int) ([]world.Block, []world.Liquid) {
+	return f.s.Region(min, max)
+}
+
+// IndexAt returns the raw palette index at a position in the structure. See Structure.IndexAt.
+func (f FrozenStructure) IndexAt(x, y, z, layer int) int32 {
+	return f.s.IndexAt(x, y, z, layer)
+}
+
+// PaletteEntry returns the name, states and version of a palette entry. See Structure.PaletteEntry.
+func (f FrozenStructure) PaletteEntry(i int) (name string, states map[string]interface{}, version int32) {
+	return f.s.PaletteEntry(i)
+}
+
+// Hash returns a hash of the content of the structure. See Structure.Hash.
+func (f FrozenStructure) Hash() [32]byte {
+	return f.s.Hash()
+}
+
+// PaletteSize returns the number of entries in the palette of the structure. See Structure.PaletteSize.
+func (f FrozenStructure) PaletteSize() int {
+	return f.s.PaletteSize()
+}
+
+// Palette returns all distinct blocks in the palette of the structure. See Structure.Palette.
+func (f FrozenStructure) Palette() []world.Block {
+	return f.s.Palette()
+}
+
+// Visit calls fn for every position within a region of the structure that holds a block. See Structure.Visit.
+func (f FrozenStructure) Visit(region cube.BBox, fn func(pos cube.Pos, b world.Block) (stop bool)) bool {
+	return f.s.Visit(region, fn)
+}
+
+// Columns calls fn with the highest y holding a block other than air for every column. See Structure.Columns.
+func (f FrozenStructure) Columns(fn func(x, z, y int)) {
+	f.s.Columns(fn)
+}
+
+// Heightmap returns the highest y holding a block other than air for every column. See Structure.Heightmap.
+func (f FrozenStructure) Heightmap() [][]int {
+	return f.s.Heightmap()
+}
+
+// FindFirst returns the position of the first block with the name passed. See Structure.FindFirst.
+func (f FrozenStructure) FindFirst(name string) (cube.Pos, bool) {
+	return f.s.FindFirst(name)
+}
+
+// FindAll returns the positions of all blocks with the name passed. See Structure.FindAll.
+func (f FrozenStructure) FindAll(name string) []cube.Pos {
+	return f.s.FindAll(name)
+}
+
+// ContentBounds returns the bounds of the content of the structure. See Structure.ContentBounds.
+func (f FrozenStructure) ContentBounds() (min, max [3]int, ok bool) {
+	return f.s.ContentBounds()
+}
+
+// Set always returns ErrFrozen, as a FrozenStructure cannot be modified. Thaw may be used to obtain a mutable
+// copy of the structure.
+func (f FrozenStructure) Set(int, int, int, world.Block, world.Liquid) error {
+	return ErrFrozen
+}
+
+// UsePalette always returns ErrFrozen, as a FrozenStructure cannot be modified. Structure.UsePalette may be
+// called before freezing the structure to freeze it with a different palette.
+func (f FrozenStructure) UsePalette(string) error {
+	return ErrFrozen
+}
+
+// String returns a concise summary of the structure. See Structure.String.
+func (f FrozenStructure) String() string {
+	return f.s.String()
+}
@@ -0,0 +1,36 @@
+package structure
+
+import (
+	df "github.com/df-mc/dragonfly/server/block"
+	"sync"
+	"testing"
+)
+
+func TestFrozenConcurrentThaw(t *testing.T) {
+	s := New([3]int{4, 4, 4})
+	s.Set(1, 1, 1, df.Stone{}, nil)
+	f := s.Frozen()
+	// Entries added to the palette after freezing must not affect the FrozenStructure.
+	s.Set(2, 2, 2, df.Dirt{}, nil)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			thawed := f.Thaw()
+			thawed.Set(i%4, 0, 0, df.Planks{}, nil)
+			if got, _ := thawed.At(1, 1, 1, nil); !sameBlock(got, df.Stone{}) {
+				t.Errorf("block at [1 1 1] = %v, want stone", got)
+			}
+		}(i)
+	}
+	wg.Wait()
+
+	if got, _ := f.At(2, 2, 2, nil); !sameBlock(got, air()) {
+		t.Fatalf("block at [2 2 2] = %v, want air", got)
+	}
+	if got, _ := f.At(0, 0, 0, nil); !sameBlock(got, air()) {
+		t.Fatalf("block at [0 0 0] = %v after modifying thawed copies, want air", got)
+	}
+}
@@ -0,0 +1,340 @@
+//go:build ignore
+
+// This program generates version_table.go from the block state upgrade schemas of the version of
+// github.com/df-mc/worldupgrader required by the module. It is run using go generate.
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"go/format"
+	"log"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// schema is a block state upgrade schema, holding the changes made to block states in a single version.
+type schema struct {
+	MaxVersionMajor    int `json:"maxVersionMajor"`
+	MaxVersionMinor    int `json:"maxVersionMinor"`
+	MaxVersionPatch    int `json:"maxVersionPatch"`
+	MaxVersionRevision int `json:"maxVersionRevision"`
+
+	RenamedIDs                  map[string]string            `json:"renamedIds"`
+	AddedProperties             map[string]map[string]tag    `json:"addedProperties"`
+	RemovedProperties           map[string][]string          `json:"removedProperties"`
+	RenamedProperties           map[string]map[string]string `json:"renamedProperties"`
+	RemappedPropertyValues      map[string]map[string]string `json:"remappedPropertyValues"`
+	RemappedPropertyValuesIndex map[string][]valueRemap      `json:"remappedPropertyValuesIndex"`
+	RemappedStates              map[string][]stateRemap      `json:"remappedStates"`
+}
+
+// tag is the value of a block state property in a schema, of which exactly one field is set.
+type tag struct {
+	String *string `json:"string"`
+	Int    *int32  `json:"int"`
+	Byte   *uint8  `json:"byte"`
+}
+
+// literal returns the Go literal of the value of the tag.
+func (t tag) literal() string {
+	switch {
+	case t.String != nil:
+		return strconv.Quote(*t.String)
+	case t.Int != nil:
+		return fmt.Sprintf("int32(%d)", *t.Int)
+	case t.Byte != nil:
+		return fmt.Sprintf("uint8(%d)", *t.Byte)
+	}
+	panic("tag without value")
+}
+
+// valueRemap is a change of the value of a property.
+type valueRemap struct {
+	Old tag `json:"old"`
+	New tag `json:"new"`
+}
+
+// stateRemap is a change of a block with specific states to another block.
+type stateRemap struct {
+	OldState map[string]tag `json:"oldState"`
+	NewName  string         `json:"newName"`
+	NewState map[string]tag `json:"newState"`
+}
+
+// statesLiteral returns the Go literal of the block states passed.
+func statesLiteral(states map[string]tag) string {
+	var b strings.Builder
+	b.WriteString("map[string]interface{}{")
+	for i, k := range sortedKeys(states) {
+		if i != 0 {
+			b.WriteString(", ")
+		}
+		fmt.Fprintf(&b, "%v: %v", strconv.Quote(k), states[k].literal())
+	}
+	b.WriteString("}")
+	return b.String()
+}
+
+// version is a block state version, split up into its four parts.
+type version [4]int
+
+// ident returns the name of the constant holding the version.
+func (v version) ident() string {
+	return fmt.Sprintf("version%d_%d_%d_%d", v[0], v[1], v[2], v[3])
+}
+
+// less checks if the version is lower than the version passed.
+func (v version) less(o version) bool {
+	for i := range v {
+		if v[i] != o[i] {
+			return v[i] < o[i]
+		}
+	}
+	return false
+}
+
+// value is a property value of a block, as the Go literal of the value.
+type value struct {
+	name, property, literal string
+}
+
+// renames holds the renames of blocks made by a single schema, as Go literals of blockRenames, by the new name of
+// the blocks.
+type renames struct {
+	v     version
+	byNew map[string][]string
+}
+
+func main() {
+	out, err := exec.Command("go", "list", "-m", "-f", "{{.Dir}}", "github.com/df-mc/worldupgrader").Output()
+	if err != nil {
+		log.Fatalf("locate worldupgrader module: %v", err)
+	}
+	files, err := filepath.Glob(filepath.Join(strings.TrimSpace(string(out)), "blockupgrader", "schemas", "*.json"))
+	if err != nil {
+		log.Fatalf("list schemas: %v", err)
+	}
+	sort.Strings(files)
+
+	names, layout, values := map[string]version{}, map[string]version{}, map[value]version{}
+	known := map[string]bool{}
+	var schemaRenames []renames
+	for _, file := range files {
+		data, err := os.ReadFile(file)
+		if err != nil {
+			log.Fatalf("read schema: %v", err)
+		}
+		var s schema
+		if err := json.Unmarshal(data, &s); err != nil {
+			log.Fatalf("decode schema %v: %v", filepath.Base(file), err)
+		}
+		v := version{s.MaxVersionMajor, s.MaxVersionMinor, s.MaxVersionPatch, s.MaxVersionRevision}
+		newName := func(old string) string {
+			if n, ok := s.RenamedIDs[old]; ok {
+				return n
+			}
+			return old
+		}
+
+		// Sources are the blocks changed by the schema, by their name before the change.
+		sources := map[string]bool{}
+		for old := range s.RenamedIDs {
+			sources[old] = true
+		}
+		for old := range s.RemappedStates {
+			sources[old] = true
+		}
+		for old := range s.AddedProperties {
+			sources[old] = true
+		}
+		for old := range s.RemovedProperties {
+			sources[old] = true
+		}
+		for old := range s.RenamedProperties {
+			sources[old] = true
+		}
+		for old := range s.RemappedPropertyValues {
+			sources[old] = true
+		}
+		for name := range sources {
+			known[name] = true
+		}
+
+		targets := map[string]bool{}
+		for _, n := range s.RenamedIDs {
+			targets[n] = true
+		}
+		for _, remaps := range s.RemappedStates {
+			for _, r := range remaps {
+				if sources[r.NewName] {
+					layout[r.NewName] = v
+				}
+				targets[r.NewName] = true
+			}
+		}
+		for t := range targets {
+			known[t] = true
+			if _, ok := names[t]; !ok && !sources[t] {
+				names[t] = v
+			}
+		}
+		r := renames{v: v, byNew: map[string][]string{}}
+		for _, old := range sortedKeys(s.RenamedIDs) {
+			n := s.RenamedIDs[old]
+			r.byNew[n] = append(r.byNew[n], fmt.Sprintf("{name: %v}", strconv.Quote(old)))
+		}
+		for _, old := range sortedKeys(s.RemappedStates) {
+			for _, remap := range s.RemappedStates[old] {
+				r.byNew[remap.NewName] = append(r.byNew[remap.NewName], fmt.Sprintf("{name: %v, states: %v, newStates: %v}", strconv.Quote(old), statesLiteral(remap.OldState), statesLiteral(remap.NewState)))
+			}
+		}
+		if len(r.byNew) != 0 {
+			schemaRenames = append(schemaRenames, r)
+		}
+
+		for old := range s.AddedProperties {
+			layout[newName(old)] = v
+		}
+		for old := range s.RemovedProperties {
+			layout[newName(old)] = v
+		}
+		for old := range s.RenamedProperties {
+			layout[newName(old)] = v
+		}
+		for old, properties := range s.RemappedPropertyValues {
+			for property, index := range properties {
+				remaps := s.RemappedPropertyValuesIndex[index]
+				olds := map[string]bool{}
+				for _, r := range remaps {
+					olds[r.Old.literal()] = true
+				}
+				for _, r := range remaps {
+					if lit := r.New.literal(); !olds[lit] {
+						values[value{newName(old), property, lit}] = v
+					}
+				}
+			}
+		}
+	}
+
+	versionSet := map[version]bool{}
+	for _, m := range []map[string]version{names, layout} {
+		for _, v := range m {
+			versionSet[v] = true
+		}
+	}
+	for _, v := range values {
+		versionSet[v] = true
+	}
+	for _, r := range schemaRenames {
+		versionSet[r.v] = true
+	}
+	versions := make([]version, 0, len(versionSet))
+	for v := range versionSet {
+		versions = append(versions, v)
+	}
+	sort.Slice(versions, func(i, j int) bool { return versions[i].less(versions[j]) })
+
+	var buf bytes.Buffer
+	buf.WriteString("// Code generated by gen_version_table.go; DO NOT EDIT.\n\npackage structure\n\n")
+	buf.WriteString("// The tables below are generated from the block state upgrade schemas of github.com/df-mc/worldupgrader, which\n")
+	buf.WriteString("// describe every change made to block states since Minecraft 1.10. The versions are the block state versions\n")
+	buf.WriteString("// of the schemas in which the changes were made. These are not always the versions of released games: changes\n")
+	buf.WriteString("// made in a beta, for example, hold the version of the beta.\n\n")
+	buf.WriteString("const (\n")
+	for _, v := range versions {
+		fmt.Fprintf(&buf, "\t%v = %d<<24 | %d<<16 | %d<<8 | %d\n", v.ident(), v[0], v[1], v[2], v[3])
+	}
+	buf.WriteString(")\n\n")
+
+	buf.WriteString("// knownBlocks holds the names of all blocks changed by any of the schemas, by either their old or new name.\n")
+	buf.WriteString("var knownBlocks = map[string]struct{}{\n")
+	for _, name := range sortedKeys(known) {
+		fmt.Fprintf(&buf, "\t%v: {},\n", strconv.Quote(name))
+	}
+	buf.WriteString("}\n\n")
+
+	buf.WriteString("// blockIntroductions holds the block state version in which blocks were given their current name, by that name.\n")
+	buf.WriteString("var blockIntroductions = map[string]int32{\n")
+	for _, name := range sortedKeys(names) {
+		fmt.Fprintf(&buf, "\t%v: %v,\n", strconv.Quote(name), names[name].ident())
+	}
+	buf.WriteString("}\n\n")
+
+	buf.WriteString("// stateChanges holds the last block state version in which properties were added to, removed from or renamed in\n")
+	buf.WriteString("// the block states of blocks, by the current name of the block.\n")
+	buf.WriteString("var stateChanges = map[string]int32{\n")
+	for _, name := range sortedKeys(layout) {
+		fmt.Fprintf(&buf, "\t%v: %v,\n", strconv.Quote(name), layout[name].ident())
+	}
+	buf.WriteString("}\n\n")
+
+	buf.WriteString("// stateValue is a value of a block state property of a block.\n")
+	buf.WriteString("type stateValue struct {\n\tname, property string\n\tvalue interface{}\n}\n\n")
+	buf.WriteString("// valueIntroductions holds the block state version in which property values were introduced, by the block,\n")
+	buf.WriteString("// property and value.\n")
+	buf.WriteString("var valueIntroductions = map[stateValue]int32{\n")
+	keys := make([]value, 0, len(values))
+	for k := range values {
+		keys = append(keys, k)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		a, b := keys[i], keys[j]
+		if a.name != b.name {
+			return a.name < b.name
+		}
+		if a.property != b.property {
+			return a.property < b.property
+		}
+		return a.literal < b.literal
+	})
+	for _, k := range keys {
+		fmt.Fprintf(&buf, "\t{%v, %v, %v}: %v,\n", strconv.Quote(k.name), strconv.Quote(k.property), k.literal, values[k].ident())
+	}
+	buf.WriteString("}\n\n")
+
+	buf.WriteString("// blockRename is a rename of a block made by a schema. If states is nil, the block was renamed regardless of its\n")
+	buf.WriteString("// states, which were kept. Otherwise, the block was renamed only if its states were equal to states, which were\n")
+	buf.WriteString("// then replaced with newStates.\n")
+	buf.WriteString("type blockRename struct {\n\tname string\n\tstates, newStates map[string]interface{}\n}\n\n")
+	buf.WriteString("// schemaRenames holds the renames of blocks made by a single schema, by the new name of the blocks.\n")
+	buf.WriteString("type schemaRenames struct {\n\tversion int32\n\trenames map[string][]blockRename\n}\n\n")
+	buf.WriteString("// blockRenames holds the renames made by every schema that renames blocks, in the order of the schemas.\n")
+	buf.WriteString("var blockRenames = []schemaRenames{\n")
+	for _, r := range schemaRenames {
+		fmt.Fprintf(&buf, "\t{version: %v, renames: map[string][]blockRename{\n", r.v.ident())
+		for _, n := range sortedKeys(r.byNew) {
+			fmt.Fprintf(&buf, "\t\t%v: {\n", strconv.Quote(n))
+			for _, rename := range r.byNew[n] {
+				fmt.Fprintf(&buf, "\t\t\t%v,\n", rename)
+			}
+			buf.WriteString("\t\t},\n")
+		}
+		buf.WriteString("\t}},\n")
+	}
+	buf.WriteString("}\n")
+
+	src, err := format.Source(buf.Bytes())
+	if err != nil {
+		log.Fatalf("format table: %v", err)
+	}
+	if err := os.WriteFile("version_table.go", src, 0644); err != nil {
+		log.Fatalf("write table: %v", err)
+	}
+}
+
+// sortedKeys returns the keys of the map passed in sorted order.
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -4,17 +4,23 @@ go 1.18
 
 require (
 	github.com/df-mc/dragonfly v0.9.4
+	github.com/df-mc/goleveldb v1.1.9
 	github.com/df-mc/worldupgrader v1.0.3
+	github.com/fsnotify/fsnotify v1.4.7
+	github.com/go-gl/mathgl v1.0.0
+	github.com/google/uuid v1.3.0
 	github.com/sandertv/gophertunnel v1.28.1
 )
 
 require (
 	github.com/brentp/intintmap v0.0.0-20190211203843-30dc0ade9af9 // indirect
 	github.com/df-mc/atomic v1.10.0 // indirect
-	github.com/go-gl/mathgl v1.0.0 // indirect
-	github.com/google/uuid v1.3.0 // indirect
+	github.com/golang/snappy v0.0.4 // indirect
+	github.com/klauspost/compress v1.15.15 // indirect
 	github.com/sirupsen/logrus v1.9.0 // indirect
+	go.uber.org/atomic v1.10.0 // indirect
 	golang.org/x/exp v0.0.0-20230321023759-10a507213a29 // indirect
 	golang.org/x/image v0.6.0 // indirect
 	golang.org/x/sys v0.6.0 // indirect
+	golang.org/x/text v0.8.0 // indirect
 )
@@ -0,0 +1,188 @@
+package structure
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+	"fmt"
+	"hash"
+	"math"
+	"reflect"
+	"sort"
+	"strconv"
+)
+
+// Hash returns a SHA-256 hash of the content of the structure: its dimensions, the block and liquid at every
+// position, the block entity data of blocks and its entities. Two structures with the same content have the
+// same hash, even if their palettes are ordered differently, hold unused or duplicate entries or were written
+// with an older block version. Data that does not affect the content of the structure, such as its origin in
+// the world it was captured in, the coordinates stored in block entity data and block position data left at
+// positions without a block, is ignored.
+// Hash is suitable for deduplicating structures and as a key for caches of data derived from structures.
+func (s *structure) Hash() [32]byte {
+	h := sha256.New()
+	dims := s.Dimensions()
+	hashInt(h, int64(dims[0]))
+	hashInt(h, int64(dims[1]))
+	hashInt(h, int64(dims[2]))
+
+	// Palette indices depend on the order of the palette, so we replace every index with the rank of its block
+	// state among the distinct block states used in the structure, sorted.
+	used := make([]bool, len(s.palette.BlockPalette))
+	for offset, index := range s.blocks {
+		if index != -1 {
+			used[index] = true
+		}
+		if index = s.liquids[offset]; index != -1 {
+			used[index] = true
+		}
+	}
+	states := make([]string, len(s.palette.BlockPalette))
+	distinct := make([]string, 0, len(states))
+	ranks := make(map[string]int32, len(states))
+	for i, entry := range s.palette.BlockPalette {
+		if !used[i] {
+			continue
+		}
+		states[i] = blockStateOf(entry).String()
+		if _, ok := ranks[states[i]]; !ok {
+			ranks[states[i]] = 0
+			distinct = append(distinct, states[i])
+		}
+	}
+	sort.Strings(distinct)
+	for i, state := range distinct {
+		ranks[state] = int32(i)
+	}
+	rank := func(index int32) int32 {
+		if index == -1 {
+			return -1
+		}
+		return ranks[states[index]]
+	}
+	for _, state := range distinct {
+		hashString(h, state)
+	}
+	// Writing every position to the hash separately is slow, so positions are written in batches.
+	buf, n := make([]byte, 4096), 0
+	for offset, index := range s.blocks {
+		if n == len(buf) {
+			_, _ = h.Write(buf)
+			n = 0
+		}
+		binary.LittleEndian.PutUint32(buf[n:], uint32(rank(index)))
+		binary.LittleEndian.PutUint32(buf[n+4:], uint32(rank(s.liquids[offset])))
+		n += 8
+	}
+	_, _ = h.Write(buf[:n])
+
+	offsets := make([]int, 0, len(s.palette.BlockPositionData))
+	for k, data := range s.palette.BlockPositionData {
+		offset, _ := strconv.Atoi(k)
+		if s.blocks[offset] != -1 && len(data.BlockEntityData) != 0 {
+			offsets = append(offsets, offset)
+		}
+	}
+	sort.Ints(offsets)
+	for _, offset := range offsets {
+		hashInt(h, int64(offset))
+		hashValue(h, withoutKeys(s.palette.BlockPositionData[strconv.Itoa(offset)].BlockEntityData, "x", "y", "z"))
+	}
+
+	// Entities are hashed independently of their order, and without their unique IDs, which differ every time
+	// a structure is captured.
+	entities := make([][32]byte, len(s.Structure.Entities))
+	for i, data := range s.Structure.Entities {
+		eh := sha256.New()
+		hashValue(eh, withoutKeys(data, "UniqueID"))
+		copy(entities[i][:], eh.Sum(nil))
+	}
+	sort.Slice(entities, func(i, j int) bool {
+		return string(entities[i][:]) < string(entities[j][:])
+	})
+	hashInt(h, int64(len(entities)))
+	for _, sum := range entities {
+		_, _ = h.Write(sum[:])
+	}
+
+	var sum [32]byte
+	copy(sum[:], h.Sum(nil))
+	return sum
+}
+
+// withoutKeys returns a copy of the map passed without the keys passed.
+func withoutKeys(m map[string]interface{}, keys ...string) map[string]interface{} {
+	c := make(map[string]interface{}, len(m))
+	for k, v := range m {
+		c[k] = v
+	}
+	for _, k := range keys {
+		delete(c, k)
+	}
+	return c
+}
+
+// hashValue writes an NBT value to the hash.Hash passed in a canonical form: the keys of maps are written in
+// sorted order, and every value is preceded by its type, so that values of different types never collide.
+func hashValue(h hash.Hash, v interface{}) {
+	switch v := v.(type) {
+	case map[string]interface{}:
+		keys := make([]string, 0, len(v))
+		for k := range v {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		hashString(h, "compound")
+		hashInt(h, int64(len(keys)))
+		for _, k := range keys {
+			hashString(h, k)
+			hashValue(h, v[k])
+		}
+	case uint8:
+		hashString(h, "byte")
+		hashInt(h, int64(v))
+	case int16:
+		hashString(h, "short")
+		hashInt(h, int64(v))
+	case int32:
+		hashString(h, "int")
+		hashInt(h, int64(v))
+	case int64:
+		hashString(h, "long")
+		hashInt(h, v)
+	case float32:
+		hashString(h, "float")
+		hashInt(h, int64(math.Float32bits(v)))
+	case float64:
+		hashString(h, "double")
+		hashInt(h, int64(math.Float64bits(v)))
+	case string:
+		hashString(h, "string")
+		hashString(h, v)
+	default:
+		// Lists and arrays may be held in slices and arrays of any type, depending on whether they were decoded
+		// or set by a user, so all of them are written the same way.
+		if val := reflect.ValueOf(v); val.Kind() == reflect.Slice || val.Kind() == reflect.Array {
+			hashString(h, "list")
+			hashInt(h, int64(val.Len()))
+			for i := 0; i < val.Len(); i++ {
+				hashValue(h, val.Index(i).Interface())
+			}
+			return
+		}
+		hashString(h, fmt.Sprintf("%T", v))
+		hashString(h, fmt.Sprint(v))
+	}
+}
+
+// hashInt writes an int64 to the hash.Hash passed in little-endian byte order.
+func hashInt(h hash.Hash, v int64) {
+	var b [8]byte
+	binary.LittleEndian.PutUint64(b[:], uint64(v))
+	_, _ = h.Write(b[:])
+}
+
+// hashString writes a string to the hash.Hash passed, prefixed by its length.
+func hashString(h hash.Hash, s string) {
+	hashInt(h, int64(len(s)))
+	_, _ = h.Write([]byte(s))
+}
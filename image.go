@@ -0,0 +1,179 @@
+package structure
+
+import (
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/df-mc/structure/blockcolour"
+	"image"
+	"image/color"
+)
+
+// ImageOptions holds options that change the way an image is converted to a Structure by FromImage.
+type ImageOptions struct {
+	// Blocks holds the blocks that the pixels of the image are converted to. Every pixel is converted to the
+	// block of which the colour is closest to that of the pixel. Blocks without a known colour are ignored. If
+	// Blocks is empty, concrete and terracotta of all colours registered in the world package are used.
+	Blocks []world.Block
+	// Vertical places the image upright in the XY plane, like a painting, instead of flat in the XZ plane, like
+	// a map. The top of the image is at the top of the Structure.
+	Vertical bool
+	// Staircase raises and lowers blocks of flat images relative to the block north of them, so that, when
+	// viewed on a map, the blocks are drawn in lighter and darker shades. This makes for a closer match with
+	// the colours of the image. Staircase has no effect if Vertical is true.
+	Staircase bool
+	// Colours is the blockcolour.Table used to look up the colours of Blocks. If nil, blockcolour.Default is used.
+	Colours *blockcolour.Table
+}
+
+// mapShades holds the factors by which Minecraft multiplies the colour of a block drawn on a map if it is lower
+// than, level with or higher than the block north of it.
+var mapShades = [3]float64{180.0 / 255, 220.0 / 255, 1}
+
+// FromImage converts the image passed to a pixel-art Structure of one block thick, in which every pixel is
+// represented by one block. Pixels that are mostly transparent do not hold a block. The x axis of the image runs
+// along the x axis of the Structure and its y axis along either the z or the y axis, depending on
+// ImageOptions.Vertical.
+func FromImage(img image.Image, opts ImageOptions) Structure {
+	candidates := imageCandidates(opts.Blocks, opts.Colours)
+	bounds := img.Bounds()
+	w, h := bounds.Dx(), bounds.Dy()
+	at := func(x, y int) (color.RGBA, bool) {
+		r, g, b, a := img.At(bounds.Min.X+x, bounds.Min.Y+y).RGBA()
+		if a < 0x8000 {
+			return color.RGBA{}, false
+		}
+		return color.RGBA{R: uint8(r >> 8), G: uint8(g >> 8), B: uint8(b >> 8), A: 0xff}, true
+	}
+
+	if opts.Vertical {
+		return NewFromFunc([3]int{w, h, 1}, func(x, y, z int) (world.Block, world.Liquid) {
+			if c, ok := at(x, h-1-y); ok {
+				return nearestBlock(candidates, c), nil
+			}
+			return nil, nil
+		})
+	}
+	if !opts.Staircase {
+		return NewFromFunc([3]int{w, 1, h}, func(x, y, z int) (world.Block, world.Liquid) {
+			if c, ok := at(x, z); ok {
+				return nearestBlock(candidates, c), nil
+			}
+			return nil, nil
+		})
+	}
+
+	// With staircasing, the height of every block depends on its shade and the height of the block north of it.
+	blocks, heights := make([][]world.Block, w), make([][]int, w)
+	maxHeight := 0
+	for x := 0; x < w; x++ {
+		blocks[x], heights[x] = make([]world.Block, h), make([]int, h)
+		height, hasNorth := 0, false
+		for z := 0; z < h; z++ {
+			c, ok := at(x, z)
+			if !ok {
+				hasNorth = false
+				continue
+			}
+			b, shade := nearestShadedBlock(candidates, c, hasNorth)
+			height += shade - 1
+			blocks[x][z], heights[x][z], hasNorth = b, height, true
+		}
+		// Move the column up so that its lowest block is at y 0.
+		min := 0
+		for z, b := range blocks[x] {
+			if b != nil && heights[x][z] < min {
+				min = heights[x][z]
+			}
+		}
+		for z := range heights[x] {
+			if heights[x][z] -= min; heights[x][z] > maxHeight {
+				maxHeight = heights[x][z]
+			}
+		}
+	}
+	return NewFromFunc([3]int{w, maxHeight + 1, h}, func(x, y, z int) (world.Block, world.Liquid) {
+		if b := blocks[x][z]; b != nil && heights[x][z] == y {
+			return b, nil
+		}
+		return nil, nil
+	})
+}
+
+// imageCandidate is a block that pixels of an image may be converted to, along with its colour.
+type imageCandidate struct {
+	b world.Block
+	c color.RGBA
+}
+
+// dyes holds the values of the 'color' state of dyed blocks such as concrete.
+var dyes = [...]string{
+	"white", "orange", "magenta", "light_blue", "yellow", "lime", "pink", "gray",
+	"silver", "cyan", "purple", "blue", "brown", "green", "red", "black",
+}
+
+// imageCandidates returns the blocks passed along with their colours as found in the blockcolour.Table passed, or
+// the default blocks used by FromImage if no blocks are passed.
+func imageCandidates(blocks []world.Block, colours *blockcolour.Table) []imageCandidate {
+	if colours == nil {
+		colours = blockcolour.Default
+	}
+	if len(blocks) == 0 {
+		for _, c := range dyes {
+			for _, name := range [...]string{"minecraft:concrete", "minecraft:stained_hardened_clay"} {
+				if b, ok := world.BlockByName(name, map[string]interface{}{"color": c}); ok {
+					blocks = append(blocks, b)
+				}
+			}
+		}
+	}
+	candidates := make([]imageCandidate, 0, len(blocks))
+	for _, b := range blocks {
+		name, states := b.EncodeBlock()
+		if c, ok := colours.Of(name, states); ok {
+			candidates = append(candidates, imageCandidate{b: b, c: c})
+		}
+	}
+	return candidates
+}
+
+// nearestBlock returns the block of the candidate with the colour closest to the colour passed.
+func nearestBlock(candidates []imageCandidate, c color.RGBA) world.Block {
+	var nearest world.Block
+	best := -1
+	for _, candidate := range candidates {
+		if d := colourDistance(candidate.c, c); best == -1 || d < best {
+			nearest, best = candidate.b, d
+		}
+	}
+	return nearest
+}
+
+// nearestShadedBlock returns the block of the candidate that, in any of the shades of mapShades, has the colour
+// closest to the colour passed, along with the index of that shade. If shaded is false, only the middle shade is
+// considered, which is the shade of a block level with the block north of it.
+func nearestShadedBlock(candidates []imageCandidate, c color.RGBA, shaded bool) (world.Block, int) {
+	var nearest world.Block
+	best, bestShade := -1, 1
+	for _, candidate := range candidates {
+		for shade, factor := range mapShades {
+			if !shaded && shade != 1 {
+				continue
+			}
+			shadedColour := color.RGBA{
+				R: uint8(float64(candidate.c.R) * factor),
+				G: uint8(float64(candidate.c.G) * factor),
+				B: uint8(float64(candidate.c.B) * factor),
+			}
+			if d := colourDistance(shadedColour, c); best == -1 || d < best {
+				nearest, best, bestShade = candidate.b, d, shade
+			}
+		}
+	}
+	return nearest, bestShade
+}
+
+// colourDistance returns the squared distance between two colours, weighted by how sensitive the human eye is
+// to each component.
+func colourDistance(a, b color.RGBA) int {
+	dr, dg, db := int(a.R)-int(b.R), int(a.G)-int(b.G), int(a.B)-int(b.B)
+	return 2*dr*dr + 4*dg*dg + 3*db*db
+}
@@ -0,0 +1,48 @@
+package structure
+
+import (
+	"io"
+)
+
+// countingWriter is an io.Writer that counts the number of bytes written to the io.Writer it wraps.
+type countingWriter struct {
+	w io.Writer
+	n int64
+}
+
+// Write writes the byte slice passed to the underlying io.Writer and counts the bytes written.
+func (w *countingWriter) Write(b []byte) (int, error) {
+	n, err := w.w.Write(b)
+	w.n += int64(n)
+	return n, err
+}
+
+// countingReader is an io.Reader that counts the number of bytes read from the io.Reader it wraps. It
+// implements io.ByteReader so that NBT may be decoded from it without reading further than needed.
+type countingReader struct {
+	r io.Reader
+	n int64
+}
+
+// Read reads from the underlying io.Reader into the byte slice passed and counts the bytes read.
+func (r *countingReader) Read(b []byte) (int, error) {
+	n, err := r.r.Read(b)
+	r.n += int64(n)
+	return n, err
+}
+
+// ReadByte reads a single byte from the underlying io.Reader and counts it if successful.
+func (r *countingReader) ReadByte() (byte, error) {
+	if br, ok := r.r.(io.ByteReader); ok {
+		b, err := br.ReadByte()
+		if err == nil {
+			r.n++
+		}
+		return b, err
+	}
+	var b [1]byte
+	if _, err := io.ReadFull(r, b[:]); err != nil {
+		return 0, err
+	}
+	return b[0], nil
+}
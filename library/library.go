@@ -0,0 +1,301 @@
+// Package library implements a registry of named structures, loaded from directories of .mcstructure files on
+// demand and cached in memory, so that servers need not keep track of their structure files themselves.
+package library
+
+import (
+	"errors"
+	"fmt"
+	"github.com/df-mc/structure"
+	"github.com/fsnotify/fsnotify"
+	"io/fs"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// ErrNotFound is returned when looking up a structure with a name that is not present in a Library.
+var ErrNotFound = errors.New("structure not found")
+
+// Option is an option that changes the behaviour of a Library created using New.
+type Option func(l *Library)
+
+// WithMaxLoaded limits the number of structures a Library keeps loaded at the same time to n. When a structure
+// is loaded while n structures are already loaded, the structure that was used least recently is evicted.
+// Without this option, structures remain loaded until evicted explicitly.
+func WithMaxLoaded(n int) Option {
+	return func(l *Library) {
+		if n < 1 {
+			n = 1
+		}
+		l.maxLoaded = n
+	}
+}
+
+// WithCache makes the Library load structures using structure.ReadFileCached, which keeps a cache file next to
+// every structure file to speed up loading structures again later.
+func WithCache() Option {
+	return func(l *Library) {
+		l.load = freeze(structure.ReadFileCached)
+	}
+}
+
+// WithSharing makes the Library load structures using structure.ReadFileShared, so that structures are shared with
+// other Libraries and any other users of structure.ReadFileShared in the process, and identical structures in
+// different files are kept in memory only once. Structures unloaded by the Library are released from the shared
+// cache using structure.ReleaseShared. WithSharing overrides WithCache.
+func WithSharing() Option {
+	return func(l *Library) {
+		l.load, l.release = structure.ReadFileShared, structure.ReleaseShared
+	}
+}
+
+// freeze returns a function that reads a structure file using the function passed and freezes the structure read.
+func freeze(read func(file string) (structure.Structure, error)) func(file string) (structure.FrozenStructure, error) {
+	return func(file string) (structure.FrozenStructure, error) {
+		s, err := read(file)
+		if err != nil {
+			return structure.FrozenStructure{}, err
+		}
+		return s.Frozen(), nil
+	}
+}
+
+// Library holds named structures found in one or more directories. Structures are loaded lazily the first time
+// they are looked up and kept in memory afterwards, until evicted. The name of a structure is its path relative
+// to the directory it was found in, using forward slashes and without the .mcstructure extension, such as
+// 'houses/small'. A Library is safe for concurrent use.
+type Library struct {
+	maxLoaded int
+	load      func(file string) (structure.FrozenStructure, error)
+	// release is called with the file of every structure unloaded, if not nil.
+	release func(file string)
+
+	mu    sync.Mutex
+	dirs  []string
+	files map[string]string
+	// loaded holds all structures currently loaded. uses is incremented every time a structure is looked up,
+	// and is used to find the structure used least recently when evicting.
+	loaded map[string]*entry
+	uses   uint64
+
+	// watcher, pending and subscribers are used while the Library is watched. pending holds the timers of
+	// structure files that are scheduled to be updated.
+	watcher        *fsnotify.Watcher
+	pending        map[string]*time.Timer
+	subscribers    map[int]func(e Event)
+	nextSubscriber int
+}
+
+// entry is a structure loaded by a Library.
+type entry struct {
+	s       structure.FrozenStructure
+	file    string
+	lastUse uint64
+}
+
+// New creates a new, empty Library. Directories may be added to it using AddDir.
+func New(opts ...Option) *Library {
+	l := &Library{load: freeze(structure.ReadFile), files: map[string]string{}, loaded: map[string]*entry{}}
+	for _, opt := range opts {
+		opt(l)
+	}
+	return l
+}
+
+// AddDir adds all .mcstructure files in the directory passed and its subdirectories to the Library. If a file in
+// the directory has the same name as a structure already in the Library, the file replaces it: directories added
+// later take precedence over directories added earlier.
+// The directory is only indexed: none of the structures in it are loaded until they are looked up. If the Library
+// is being watched, the directory is watched too.
+func (l *Library) AddDir(dir string) error {
+	files, err := index(dir)
+	if err != nil {
+		return err
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if l.watcher != nil {
+		if err := addWatches(l.watcher, dir); err != nil {
+			return err
+		}
+	}
+	l.dirs = append(l.dirs, dir)
+	for name, file := range files {
+		if l.files[name] != file {
+			l.unload(name)
+		}
+		l.files[name] = file
+	}
+	return nil
+}
+
+// index returns the names of all .mcstructure files in the directory passed and its subdirectories, mapped to
+// the paths of these files.
+func index(dir string) (map[string]string, error) {
+	files := map[string]string{}
+	err := filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() || !strings.EqualFold(filepath.Ext(path), ".mcstructure") {
+			return nil
+		}
+		rel, err := filepath.Rel(dir, path)
+		if err != nil {
+			return err
+		}
+		files[filepath.ToSlash(strings.TrimSuffix(rel, filepath.Ext(rel)))] = path
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("index directory: %w", err)
+	}
+	return files, nil
+}
+
+// Get returns the structure with the name passed, loading it from disk if it is not yet loaded. If the Library
+// holds no structure with the name passed, ErrNotFound is returned. The structure returned is shared by all
+// callers of Get, which is why it is frozen. FrozenStructure.Thaw may be used to obtain a copy that may be
+// modified.
+func (l *Library) Get(name string) (structure.FrozenStructure, error) {
+	l.mu.Lock()
+	if e, ok := l.loaded[name]; ok {
+		l.uses++
+		e.lastUse = l.uses
+		l.mu.Unlock()
+		return e.s, nil
+	}
+	file, ok := l.files[name]
+	l.mu.Unlock()
+	if !ok {
+		return structure.FrozenStructure{}, fmt.Errorf("get %v: %w", name, ErrNotFound)
+	}
+
+	// The structure is read without holding the lock, so that loading a large structure does not block
+	// lookups of other structures.
+	frozen, err := l.load(file)
+	if err != nil {
+		return structure.FrozenStructure{}, fmt.Errorf("load %v: %w", name, err)
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if e, ok := l.loaded[name]; ok {
+		// The structure was loaded by another goroutine in the meantime, so we return that one instead.
+		return e.s, nil
+	}
+	if l.files[name] != file {
+		// The file of the structure was replaced while loading it, so we do not keep it loaded.
+		if l.release != nil {
+			l.release(file)
+		}
+		return frozen, nil
+	}
+	if l.maxLoaded > 0 && len(l.loaded) >= l.maxLoaded {
+		l.evictLeastUsed()
+	}
+	l.uses++
+	l.loaded[name] = &entry{s: frozen, file: file, lastUse: l.uses}
+	return frozen, nil
+}
+
+// MustGet returns the structure with the name passed like Get, but panics if it cannot be loaded. MustGet is
+// useful for structures that a server cannot run without.
+func (l *Library) MustGet(name string) structure.FrozenStructure {
+	s, err := l.Get(name)
+	if err != nil {
+		panic(err)
+	}
+	return s
+}
+
+// Has checks if the Library holds a structure with the name passed, without loading it.
+func (l *Library) Has(name string) bool {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	_, ok := l.files[name]
+	return ok
+}
+
+// Path returns the path of the file of the structure with the name passed. If the Library holds no structure
+// with the name passed, false is returned.
+func (l *Library) Path(name string) (string, bool) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	file, ok := l.files[name]
+	return file, ok
+}
+
+// Names returns the names of all structures in the Library, loaded or not, sorted alphabetically.
+func (l *Library) Names() []string {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	names := make([]string, 0, len(l.files))
+	for name := range l.files {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Loaded returns the names of all structures in the Library that are currently loaded, sorted alphabetically.
+func (l *Library) Loaded() []string {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	names := make([]string, 0, len(l.loaded))
+	for name := range l.loaded {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Evict unloads the structure with the name passed, so that it is loaded from disk again the next time it is
+// looked up. Structures previously returned by Get remain valid.
+func (l *Library) Evict(name string) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.unload(name)
+}
+
+// EvictAll unloads all structures in the Library.
+func (l *Library) EvictAll() {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	for name := range l.loaded {
+		l.unload(name)
+	}
+}
+
+// unload unloads the structure with the name passed, releasing it from the shared cache if the Library was created
+// with WithSharing. unload must be called with the lock of the Library held.
+func (l *Library) unload(name string) {
+	e, ok := l.loaded[name]
+	if !ok {
+		return
+	}
+	delete(l.loaded, name)
+	if l.release != nil {
+		l.release(e.file)
+	}
+}
+
+// evictLeastUsed unloads the structure that was looked up least recently. evictLeastUsed must be called with
+// the lock of the Library held.
+func (l *Library) evictLeastUsed() {
+	var (
+		least string
+		use   uint64
+		found bool
+	)
+	for name, e := range l.loaded {
+		if !found || e.lastUse < use {
+			least, use, found = name, e.lastUse, true
+		}
+	}
+	if found {
+		l.unload(least)
+	}
+}
@@ -0,0 +1,307 @@
+package library
+
+import (
+	"errors"
+	"fmt"
+	"github.com/fsnotify/fsnotify"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// reloadDelay is the time the watcher of a Library waits after the last change to a file before reloading it.
+// Files are often written in several steps, so reloading immediately would often read a file that is only
+// partially written.
+const reloadDelay = time.Millisecond * 200
+
+// Op is the kind of change to a structure reported in an Event.
+type Op int
+
+const (
+	// Added indicates that a structure was added to the Library.
+	Added Op = iota
+	// Modified indicates that the file of a structure was changed. If the structure was loaded, it has been
+	// reloaded.
+	Modified
+	// Removed indicates that a structure was removed from the Library.
+	Removed
+)
+
+// String returns the name of the Op.
+func (op Op) String() string {
+	switch op {
+	case Added:
+		return "added"
+	case Modified:
+		return "modified"
+	case Removed:
+		return "removed"
+	}
+	return fmt.Sprintf("Op(%d)", int(op))
+}
+
+// Event is a change to a structure in a Library, detected by watching the directories of the Library. Events
+// are passed to functions registered using Subscribe.
+type Event struct {
+	// Name is the name of the structure that changed.
+	Name string
+	// Op is the kind of change to the structure.
+	Op Op
+	// Err is non-nil if a loaded structure could not be reloaded after its file was modified, for example
+	// because the new file is invalid. The structure that was loaded before remains in use in this case.
+	// Err is also set for errors that occurred while watching, in which case Name is empty.
+	Err error
+}
+
+// Subscribe registers a function that is called for every change to a structure in the Library detected while
+// watching it using Watch. The function is called from the goroutine that watches the Library, so it should not
+// block. Calling the function returned cancels the subscription.
+func (l *Library) Subscribe(fn func(e Event)) (cancel func()) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if l.subscribers == nil {
+		l.subscribers = map[int]func(e Event){}
+	}
+	id := l.nextSubscriber
+	l.nextSubscriber++
+	l.subscribers[id] = fn
+	return func() {
+		l.mu.Lock()
+		defer l.mu.Unlock()
+		delete(l.subscribers, id)
+	}
+}
+
+// Watch starts watching the directories of the Library, including directories added later using AddDir, for
+// changes to structure files. Structure files that are added are added to the Library, and structure files that
+// are removed are removed from it. Loaded structures of which the file is modified are reloaded, so that calls to
+// Get afterwards return the new structure. Every change is reported to functions registered using Subscribe.
+// Watching continues until Close is called.
+func (l *Library) Watch() error {
+	w, err := fsnotify.NewWatcher()
+	if err != nil {
+		return fmt.Errorf("create watcher: %w", err)
+	}
+	l.mu.Lock()
+	if l.watcher != nil {
+		l.mu.Unlock()
+		_ = w.Close()
+		return errors.New("library is already being watched")
+	}
+	l.watcher, l.pending = w, map[string]*time.Timer{}
+	dirs := append([]string(nil), l.dirs...)
+	l.mu.Unlock()
+
+	for _, dir := range dirs {
+		if err := addWatches(w, dir); err != nil {
+			_ = l.Close()
+			return err
+		}
+	}
+	go l.watch(w)
+	return nil
+}
+
+// Close stops watching the directories of the Library if it is being watched using Watch. The structures in the
+// Library remain available.
+func (l *Library) Close() error {
+	l.mu.Lock()
+	w := l.watcher
+	l.watcher = nil
+	for _, t := range l.pending {
+		t.Stop()
+	}
+	l.pending = nil
+	l.mu.Unlock()
+	if w == nil {
+		return nil
+	}
+	return w.Close()
+}
+
+// addWatches makes the fsnotify.Watcher passed watch the directory passed and all of its subdirectories.
+func addWatches(w *fsnotify.Watcher, dir string) error {
+	err := filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil || !d.IsDir() {
+			return err
+		}
+		return w.Add(path)
+	})
+	if err != nil {
+		return fmt.Errorf("watch directory: %w", err)
+	}
+	return nil
+}
+
+// watch handles the events of the fsnotify.Watcher passed until it is closed.
+func (l *Library) watch(w *fsnotify.Watcher) {
+	for {
+		select {
+		case ev, ok := <-w.Events:
+			if !ok {
+				return
+			}
+			l.handle(w, ev)
+		case err, ok := <-w.Errors:
+			if !ok {
+				return
+			}
+			l.notify(Event{Err: err})
+		}
+	}
+}
+
+// handle handles a single fsnotify.Event. Changes to structure files are not handled immediately, but only once
+// the file has not changed for reloadDelay.
+func (l *Library) handle(w *fsnotify.Watcher, ev fsnotify.Event) {
+	if ev.Op&fsnotify.Create != 0 {
+		if info, err := os.Stat(ev.Name); err == nil && info.IsDir() {
+			// Files may have been created in the new directory before we started watching it, so we handle
+			// all of them as if they were just created.
+			if err := addWatches(w, ev.Name); err != nil {
+				l.notify(Event{Err: err})
+			}
+			files, _ := index(ev.Name)
+			for _, file := range files {
+				l.schedule(file)
+			}
+			return
+		}
+	}
+	if strings.EqualFold(filepath.Ext(ev.Name), ".mcstructure") {
+		l.schedule(ev.Name)
+	}
+}
+
+// schedule schedules the structure file at the path passed to be updated after reloadDelay. If it was already
+// scheduled, the delay is reset.
+func (l *Library) schedule(file string) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if l.pending == nil {
+		// The Library is no longer being watched.
+		return
+	}
+	if t, ok := l.pending[file]; ok {
+		t.Reset(reloadDelay)
+		return
+	}
+	l.pending[file] = time.AfterFunc(reloadDelay, func() {
+		l.mu.Lock()
+		delete(l.pending, file)
+		l.mu.Unlock()
+		l.update(file)
+	})
+}
+
+// update updates the Library for the structure file at the path passed after it was added, modified or removed,
+// and notifies subscribers of the change.
+func (l *Library) update(file string) {
+	l.mu.Lock()
+	dir := l.dirOf(file)
+	if dir == -1 {
+		l.mu.Unlock()
+		return
+	}
+	rel, _ := filepath.Rel(l.dirs[dir], file)
+	name := filepath.ToSlash(strings.TrimSuffix(rel, filepath.Ext(rel)))
+	current, known := l.files[name]
+	if known && current != file && l.dirOf(current) > dir {
+		// The structure is shadowed by a file with the same name in a directory added later, so the change
+		// does not affect the Library.
+		l.mu.Unlock()
+		return
+	}
+	if _, err := os.Stat(file); err != nil {
+		if !known || current != file {
+			l.mu.Unlock()
+			return
+		}
+		l.unload(name)
+		// A file with the same name in a directory added earlier may now be used instead.
+		if fallback, ok := l.fallback(name, file); ok {
+			l.files[name] = fallback
+			l.mu.Unlock()
+			l.notify(Event{Name: name, Op: Modified})
+			return
+		}
+		delete(l.files, name)
+		l.mu.Unlock()
+		l.notify(Event{Name: name, Op: Removed})
+		return
+	}
+	l.files[name] = file
+	_, loaded := l.loaded[name]
+	l.mu.Unlock()
+
+	if !known {
+		l.notify(Event{Name: name, Op: Added})
+		return
+	}
+	ev := Event{Name: name, Op: Modified}
+	if loaded {
+		ev.Err = l.reload(name, file)
+	}
+	l.notify(ev)
+}
+
+// reload reads the structure file at the path passed and replaces the loaded structure with the name passed
+// with it. If the file cannot be read, the loaded structure is kept and an error is returned.
+func (l *Library) reload(name, file string) error {
+	frozen, err := l.load(file)
+	if err != nil {
+		return fmt.Errorf("reload %v: %w", name, err)
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if e, ok := l.loaded[name]; ok && l.files[name] == file {
+		e.s = frozen
+	} else if l.release != nil {
+		l.release(file)
+	}
+	return nil
+}
+
+// dirOf returns the index of the directory of the Library that the file passed is in. If multiple directories
+// hold the file, the one added last is returned. If none of them do, dirOf returns -1. dirOf must be called with
+// the lock of the Library held.
+func (l *Library) dirOf(file string) int {
+	for i := len(l.dirs) - 1; i >= 0; i-- {
+		if rel, err := filepath.Rel(l.dirs[i], file); err == nil && rel != ".." && !strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+			return i
+		}
+	}
+	return -1
+}
+
+// fallback finds a structure file with the name passed in any of the directories of the Library other than the
+// one holding the file passed, preferring directories added later. fallback must be called with the lock of the
+// Library held.
+func (l *Library) fallback(name, file string) (string, bool) {
+	for i := len(l.dirs) - 1; i >= 0; i-- {
+		candidate := filepath.Join(l.dirs[i], filepath.FromSlash(name)+".mcstructure")
+		if candidate == file {
+			continue
+		}
+		if info, err := os.Stat(candidate); err == nil && !info.IsDir() {
+			return candidate, true
+		}
+	}
+	return "", false
+}
+
+// notify calls all functions registered using Subscribe with the Event passed.
+func (l *Library) notify(e Event) {
+	l.mu.Lock()
+	subscribers := make([]func(e Event), 0, len(l.subscribers))
+	for _, fn := range l.subscribers {
+		subscribers = append(subscribers, fn)
+	}
+	l.mu.Unlock()
+	for _, fn := range subscribers {
+		fn(e)
+	}
+}
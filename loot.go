@@ -0,0 +1,142 @@
+package structure
+
+import (
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/item"
+	"github.com/df-mc/dragonfly/server/item/inventory"
+	"github.com/df-mc/dragonfly/server/world"
+	"math/rand"
+)
+
+// LootTable describes the items that a container is filled with by WithLoot.
+type LootTable struct {
+	// MinRolls and MaxRolls are the bounds of the random number of times an entry is selected from Entries, which
+	// is the number of item stacks put in the container. If MaxRolls is smaller than MinRolls, exactly MinRolls
+	// entries are selected.
+	MinRolls, MaxRolls int
+	// Entries holds the entries that may be selected.
+	Entries []LootEntry
+}
+
+// LootEntry is an entry in a LootTable.
+type LootEntry struct {
+	// Stack is the item stack put in the container if the entry is selected. Its count is ignored if MaxCount is
+	// positive.
+	Stack item.Stack
+	// MinCount and MaxCount are the bounds of the random number of items in the stack put in the container. If
+	// MaxCount is 0 or less, the count of Stack is used instead.
+	MinCount, MaxCount int
+	// Weight is the weight of the entry relative to the weights of the other entries in the LootTable, like the
+	// weights passed to Mix. Entries with a weight of zero or less are never selected.
+	Weight float64
+}
+
+// WithLoot makes Build fill containers in the Structure, such as chests and barrels, with items from the loot
+// tables passed once the Structure is built. The loot table used for a container is the one with the name that
+// the positions passed map the position of the container in the Structure to. Containers at other positions use
+// the loot table named by the 'LootTable' field of their block entity data, which Minecraft sets for containers
+// of which the loot is generated once opened, such as 'loot_tables/chests/simple_dungeon.json'. Containers without
+// a matching loot table are left as they are.
+// The items selected depend only on the seed and the position of the container in the world, so building the same
+// Structure at the same position twice with the same seed results in the same loot.
+func WithLoot(seed int64, tables map[string]LootTable, positions map[cube.Pos]string) BuildOption {
+	return func(conf *buildConfig) {
+		conf.loot = &lootConfig{seed: seed, tables: tables, positions: positions}
+	}
+}
+
+// lootConfig holds the loot tables and seed passed to WithLoot.
+type lootConfig struct {
+	seed      int64
+	tables    map[string]LootTable
+	positions map[cube.Pos]string
+}
+
+// table returns the loot table for a container at the position in the structure passed, with the block entity data
+// passed, which may be nil.
+func (conf *lootConfig) table(pos cube.Pos, data map[string]interface{}) (LootTable, bool) {
+	name, ok := conf.positions[pos]
+	if !ok {
+		if name, ok = data["LootTable"].(string); !ok {
+			return LootTable{}, false
+		}
+	}
+	table, ok := conf.tables[name]
+	return table, ok
+}
+
+// container is a block that holds an inventory, such as a chest.
+type container interface {
+	Inventory() *inventory.Inventory
+}
+
+// fill fills the container at the position passed in the world.World passed with items from the LootTable.
+func (table LootTable) fill(w *world.World, pos cube.Pos, seed int64) {
+	c, ok := w.Block(pos).(container)
+	if !ok {
+		return
+	}
+	inv := c.Inventory()
+	r := rand.New(rand.NewSource(int64(positionRand(seed, pos[0], pos[1], pos[2]) * (1 << 53))))
+
+	var total float64
+	for _, e := range table.Entries {
+		if e.Weight > 0 {
+			total += e.Weight
+		}
+	}
+	if total == 0 {
+		return
+	}
+	rolls := table.MinRolls
+	if table.MaxRolls > table.MinRolls {
+		rolls += r.Intn(table.MaxRolls - table.MinRolls + 1)
+	}
+	for i := 0; i < rolls; i++ {
+		stack, ok := table.roll(r, total)
+		if !ok {
+			continue
+		}
+		// Like in Minecraft, items are put in random slots rather than filling up the container from the start.
+		var empty []int
+		for slot, it := range inv.Slots() {
+			if it.Empty() {
+				empty = append(empty, slot)
+			}
+		}
+		if len(empty) == 0 {
+			return
+		}
+		_ = inv.SetItem(empty[r.Intn(len(empty))], stack)
+	}
+}
+
+// roll selects an entry from the LootTable at random and returns its item stack. total is the sum of the positive
+// weights of all entries. If the stack selected is empty, false is returned.
+func (table LootTable) roll(r *rand.Rand, total float64) (item.Stack, bool) {
+	n := r.Float64() * total
+	for _, e := range table.Entries {
+		if e.Weight <= 0 {
+			continue
+		}
+		if n -= e.Weight; n >= 0 {
+			continue
+		}
+		stack := e.Stack
+		if e.MaxCount > 0 {
+			count := e.MinCount
+			if e.MaxCount > e.MinCount {
+				count += r.Intn(e.MaxCount - e.MinCount + 1)
+			}
+			if count > stack.MaxCount() {
+				count = stack.MaxCount()
+			}
+			if count <= 0 {
+				return item.Stack{}, false
+			}
+			stack = stack.Grow(count - stack.Count())
+		}
+		return stack, !stack.Empty()
+	}
+	return item.Stack{}, false
+}
@@ -0,0 +1,122 @@
+package structure
+
+import (
+	"github.com/df-mc/dragonfly/server/world"
+)
+
+// Map replaces every block and liquid in the structure with the block and liquid returned by fn for its
+// position. A nil block passed to or returned by fn indicates that no block is present at the position. A nil
+// liquid indicates that the block is not waterlogged. Positions holding a block or liquid that could not be
+// resolved to a registered block are left untouched.
+// Map looks up the palette entry of every distinct block returned only once. If fn depends only on the block
+// passed, MapBlocks should be used instead, which calls fn only once for every palette entry.
+func (s *structure) Map(fn func(x, y, z int, b world.Block, liq world.Liquid) (world.Block, world.Liquid)) {
+	s.ensureParsed()
+	cache := make(map[uint64]int32)
+	dims := s.Dimensions()
+	for x := 0; x < dims[0]; x++ {
+		for y := 0; y < dims[1]; y++ {
+			offset := (x * s.l * s.h) + (y * s.l)
+			for z := 0; z < dims[2]; z, offset = z+1, offset+1 {
+				b, ok := s.resolvedBlock(offset)
+				if !ok {
+					continue
+				}
+				var liq world.Liquid
+				if index := s.liquids[offset]; index != -1 {
+					if liq = s.parsedPalette[index].liq; liq == nil {
+						continue
+					}
+				}
+				newB, newLiq := fn(x, y, z, b, liq)
+				s.setMapped(offset, cache, newB)
+				s.liquids[offset] = -1
+				if newLiq != nil {
+					s.liquids[offset] = s.cachedPtrFor(cache, newLiq)
+				}
+			}
+		}
+	}
+}
+
+// MapBlocks replaces every block in the structure with the block returned by fn for it. A nil block passed to
+// or returned by fn indicates that no block is present. Liquids in the structure are left untouched, as are
+// blocks that could not be resolved to a registered block.
+// Unlike Map, MapBlocks calls fn only once for every entry in the palette of the structure, except for blocks
+// carrying block entity data, such as chests and signs, for which fn is called for every position they are at.
+func (s *structure) MapBlocks(fn func(b world.Block) world.Block) {
+	s.ensureParsed()
+	type result struct {
+		ptr  int32
+		data map[string]interface{}
+	}
+	cache := make(map[uint64]int32)
+	mapped := make(map[int32]result, len(s.parsedPalette))
+	for offset, index := range s.blocks {
+		var b world.Block
+		if index != -1 {
+			entry := s.parsedPalette[index]
+			if entry.b == nil {
+				continue
+			}
+			if entry.hasNBT {
+				// The block depends on its block entity data, so we can't map it once for all positions.
+				b, _ = s.resolvedBlock(offset)
+				liq := s.liquids[offset]
+				s.setMapped(offset, cache, fn(b))
+				if s.blocks[offset] != -1 {
+					s.liquids[offset] = liq
+				}
+				continue
+			}
+			b = entry.b
+		}
+		r, ok := mapped[index]
+		if !ok {
+			newB := fn(b)
+			r = result{ptr: s.ptrForMapped(cache, newB), data: encodeNBT(newB)}
+			mapped[index] = r
+		}
+		if r.ptr == index && r.data == nil {
+			continue
+		}
+		liq := s.liquids[offset]
+		s.setIndex(offset, r.ptr, r.data)
+		if r.ptr != -1 {
+			s.liquids[offset] = liq
+		}
+	}
+}
+
+// resolvedBlock returns the block at the offset passed, decoding its block entity data if present. If no block
+// is present, nil is returned. If the block could not be resolved to a registered block, false is returned.
+func (s *structure) resolvedBlock(offset int) (world.Block, bool) {
+	index := s.blocks[offset]
+	if index == -1 {
+		return nil, true
+	}
+	entry := s.parsedPalette[index]
+	if entry.b == nil {
+		return nil, false
+	}
+	if entry.hasNBT {
+		if nbtData, ok := s.positionData(offset); ok {
+			return entry.b.(world.NBTer).DecodeNBT(nbtData.BlockEntityData).(world.Block), true
+		}
+	}
+	return entry.b, true
+}
+
+// setMapped sets the block at the offset passed to the block returned by a mapping function, storing its block
+// entity data if present. A nil block clears the offset.
+func (s *structure) setMapped(offset int, cache map[uint64]int32, b world.Block) {
+	s.setIndex(offset, s.ptrForMapped(cache, b), encodeNBT(b))
+}
+
+// ptrForMapped returns the palette pointer for the block passed, or -1 if the block is nil.
+func (s *structure) ptrForMapped(cache map[uint64]int32, b world.Block) int32 {
+	if b == nil {
+		return -1
+	}
+	return s.cachedPtrFor(cache, b)
+}
@@ -0,0 +1,230 @@
+package structure
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/sandertv/gophertunnel/minecraft/nbt"
+	"io"
+	"os"
+)
+
+// MappedStructure is a read-only structure of which the block indices are not held in memory, but read from a
+// memory-mapped file when accessed. A MappedStructure costs little more heap than the palette of the structure,
+// so that servers may keep many large structures open at once and leave it up to the operating system to keep
+// the parts that are used often in memory.
+// A MappedStructure implements world.Structure, so it may be built directly using world.World.BuildStructure. It
+// is not safe for concurrent use, and must be closed using Close once it is no longer used.
+type MappedStructure struct {
+	s *structure
+	// data is the memory-mapped content of the file. layers holds the little-endian block indices of each layer
+	// of the structure, which are sub-slices of data.
+	data   []byte
+	layers [2][]byte
+}
+
+// Check to ensure that *MappedStructure implements the world.Structure interface.
+var _ world.Structure = (*MappedStructure)(nil)
+
+// OpenMapped opens the .mcstructure file at the path passed as a MappedStructure. The file is memory-mapped:
+// everything but the block indices of the structure is decoded immediately, while the block indices are read
+// from the file only once At or BlockAt is called for the positions they belong to. On platforms that do not
+// support memory-mapping files, the file is read into memory completely instead.
+// The file must not be modified while the MappedStructure is open. Files written in a format version other than the
+// current one cannot be mapped, as they need to be migrated first: such files must be read using ReadFile.
+func OpenMapped(file string) (*MappedStructure, error) {
+	f, err := os.Open(file)
+	if err != nil {
+		return nil, fmt.Errorf("open file: %w", err)
+	}
+	defer f.Close()
+	info, err := f.Stat()
+	if err != nil {
+		return nil, fmt.Errorf("stat file: %w", err)
+	}
+	data, err := mapFile(f, info.Size())
+	if err != nil {
+		return nil, fmt.Errorf("map file: %w", err)
+	}
+	m, err := newMapped(data)
+	if err != nil {
+		_ = unmapFile(data)
+		return nil, err
+	}
+	return m, nil
+}
+
+// newMapped creates a MappedStructure from the encoded structure passed. Everything but the block indices is
+// decoded from data, and the block indices are located so that they may be read when needed.
+func newMapped(data []byte) (*MappedStructure, error) {
+	start, end, layers, err := locateBlockIndices(data)
+	if err != nil {
+		return nil, fmt.Errorf("decode structure: %w", err)
+	}
+	// The decoder never sees the block indices: we decode everything before and after them, which leaves the
+	// block indices of the structure empty.
+	r := io.MultiReader(bytes.NewReader(data[:start]), bytes.NewReader(data[end:]))
+	s := &structure{}
+	if err := nbt.NewDecoderWithEncoding(r, nbt.LittleEndian).Decode(s); err != nil {
+		return nil, fmt.Errorf("decode structure: %v", err.Error())
+	}
+	lengths := make([]int, len(layers))
+	for i, layer := range layers {
+		lengths[i] = len(layer) / 4
+	}
+	if err := s.checkLayers(lengths); err != nil {
+		return nil, fmt.Errorf("verify structure: %w", err)
+	}
+	m := &MappedStructure{s: s, data: data, layers: [2][]byte{layers[0]}}
+	if len(layers) > 1 {
+		m.layers[1] = layers[1]
+	}
+	s.Structure.Entities = upgradeEntities(s.Structure.Entities)
+	upgradeBlockEntities(s.Structure.Palettes)
+	s.canonicaliseStates()
+	s.Structure.Entities = translateEntities(s.Structure.Entities, s.origin().Mul(-1))
+	str := Structure{structure: s}
+	str.UsePalette("default")
+	if err := str.checkPositionData(m.blockIndex); err != nil {
+		return nil, fmt.Errorf("verify structure: %w", err)
+	}
+	if m.layers[1] != nil {
+		// liquidIndex is not used here, as it parses the entire palette.
+		liquidIndex := func(offset int) int32 {
+			return int32(binary.LittleEndian.Uint32(m.layers[1][offset*4:]))
+		}
+		if err := str.checkLiquids(liquidIndex); err != nil {
+			return nil, fmt.Errorf("verify structure: %w", err)
+		}
+	}
+	return m, nil
+}
+
+// Dimensions returns the dimensions of the structure.
+func (m *MappedStructure) Dimensions() [3]int {
+	return m.s.Dimensions()
+}
+
+// At returns the block and liquid at the x, y and z passed in the structure. Like Structure.At, it returns nil
+// for positions that do not hold a block or liquid.
+func (m *MappedStructure) At(x, y, z int, _ func(x int, y int, z int) world.Block) (world.Block, world.Liquid) {
+	offset := (x * m.s.l * m.s.h) + (y * m.s.l) + z
+	b := m.blockAt(offset)
+	index := m.liquidIndex(offset)
+	if index == -1 {
+		// Minecraft structures use -1 to indicate that there is no block at a position.
+		return b, nil
+	}
+	return b, m.s.parsedPalette[index].liq
+}
+
+// BlockAt returns the block at the x, y and z passed in the structure, without looking up the liquid at the
+// position.
+func (m *MappedStructure) BlockAt(x, y, z int) world.Block {
+	return m.blockAt((x * m.s.l * m.s.h) + (y * m.s.l) + z)
+}
+
+// blockAt returns the block at the offset passed, decoding its block entity data if it has any.
+func (m *MappedStructure) blockAt(offset int) world.Block {
+	index := m.blockIndex(offset)
+	if index == -1 {
+		return nil
+	}
+	m.s.ensureParsed()
+	entry := m.s.parsedPalette[index]
+	if entry.hasNBT {
+		if nbtData, ok := m.s.positionData(offset); ok {
+			return entry.b.(world.NBTer).DecodeNBT(nbtData.BlockEntityData).(world.Block)
+		}
+	}
+	return entry.b
+}
+
+// blockIndex reads the palette index of the block at the offset passed from the mapped file.
+func (m *MappedStructure) blockIndex(offset int) int32 {
+	return int32(binary.LittleEndian.Uint32(m.layers[0][offset*4:]))
+}
+
+// liquidIndex reads the palette index of the liquid at the offset passed from the mapped file. It returns -1 if
+// the structure has no liquid layer.
+func (m *MappedStructure) liquidIndex(offset int) int32 {
+	if m.layers[1] == nil {
+		return -1
+	}
+	m.s.ensureParsed()
+	return int32(binary.LittleEndian.Uint32(m.layers[1][offset*4:]))
+}
+
+// PaletteSize returns the number of entries in the palette of the structure.
+func (m *MappedStructure) PaletteSize() int {
+	return m.s.PaletteSize()
+}
+
+// Palette returns all distinct blocks in the palette of the structure. See Structure.Palette.
+func (m *MappedStructure) Palette() []world.Block {
+	return m.s.Palette()
+}
+
+// String returns a summary of the structure, holding its dimensions and the size of its palette.
+func (m *MappedStructure) String() string {
+	dims := m.Dimensions()
+	return fmt.Sprintf("MappedStructure(%vx%vx%v, palette: %v)", dims[0], dims[1], dims[2], m.PaletteSize())
+}
+
+// Load reads the MappedStructure into memory completely, returning it as a regular, mutable Structure. The
+// Structure returned remains valid after the MappedStructure is closed.
+func (m *MappedStructure) Load() (Structure, error) {
+	return Read(bytes.NewReader(m.data))
+}
+
+// Close unmaps the file of the MappedStructure. The MappedStructure must not be used after calling Close.
+func (m *MappedStructure) Close() error {
+	data := m.data
+	m.data, m.layers = nil, [2][]byte{}
+	if data == nil {
+		return nil
+	}
+	return unmapFile(data)
+}
+
+// locateBlockIndices finds the block indices in the little-endian NBT encoded structure passed, without decoding
+// them. It returns the offsets of the start and end of the block_indices tag and the content of every layer in
+// it.
+func locateBlockIndices(data []byte) (start, end int, layers [][]byte, err error) {
+	r := &nbtScanner{data: data}
+	if t := r.byte(); t != nbtCompound {
+		return 0, 0, nil, fmt.Errorf("expected root compound tag, got tag type %v", t)
+	}
+	r.string()
+	for {
+		t, name := r.tag()
+		if r.err != nil || t == nbtEnd {
+			break
+		}
+		if t != nbtCompound || name != "structure" {
+			r.skip(t)
+			continue
+		}
+		for {
+			tagStart := r.off
+			t, name := r.tag()
+			if r.err != nil || t == nbtEnd {
+				break
+			}
+			if t != nbtList || name != "block_indices" {
+				r.skip(t)
+				continue
+			}
+			layers = r.intLists()
+			if r.err == nil {
+				return tagStart, r.off, layers, nil
+			}
+		}
+		break
+	}
+	if r.err != nil {
+		return 0, 0, nil, r.err
+	}
+	return 0, 0, nil, fmt.Errorf("structure has no block indices")
+}
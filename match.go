@@ -0,0 +1,34 @@
+package structure
+
+// MatchMode decides how the block states of blocks set in a Structure are matched against those of the entries
+// already present in its palette, and thereby whether a block reuses an existing palette entry or adds a new one.
+type MatchMode int
+
+const (
+	// MatchStrict matches a block with a palette entry only if both have exactly the same block states. Values are
+	// compared after converting them to the types Minecraft uses for block states, so that, for example, an int
+	// state matches an int32 state of the same value. MatchStrict is the MatchMode used by default.
+	MatchStrict MatchMode = iota
+	// MatchFuzzy matches a block with a palette entry like MatchStrict, but if no palette entry has exactly the
+	// same block states, it matches the first palette entry of which all block states are equal to those of the
+	// block, ignoring any block states the palette entry does not have. MatchFuzzy may be used for palettes
+	// written by tools that leave out block states, at the risk of matching a palette entry that differs from
+	// the block in the block states it leaves out.
+	MatchFuzzy
+)
+
+// UseMatchMode changes the MatchMode used by the Structure to match blocks against the entries in its palette.
+// Palette entries already added are not affected.
+func (s Structure) UseMatchMode(m MatchMode) {
+	s.match = m
+}
+
+// statesSubset checks if all canonical block states in sub are present in states with the same value.
+func statesSubset(sub, states map[string]interface{}) bool {
+	for k, v := range sub {
+		if sv, ok := states[k]; !ok || sv != v {
+			return false
+		}
+	}
+	return true
+}
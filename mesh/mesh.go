@@ -0,0 +1,197 @@
+// Package mesh converts structure.Structures to polygon meshes, so that they may be previewed in external 3D
+// tools or used in 3D printing pipelines.
+package mesh
+
+import (
+	"bufio"
+	"fmt"
+	"github.com/df-mc/structure"
+	"io"
+	"os"
+	"strings"
+)
+
+// Quad is a single rectangular face of a Mesh.
+type Quad struct {
+	// Corners holds the four corners of the Quad, ordered counter-clockwise when looking at the Quad from the
+	// side its normal points to.
+	Corners [4][3]int
+	// Normal is the direction the Quad faces, such as {0, 1, 0} for the top face of a block.
+	Normal [3]int
+	// Material is the name of the block the Quad is a face of, such as 'minecraft:stone'.
+	Material string
+}
+
+// Mesh is a polygon mesh made up of the visible faces of the blocks in a structure.Structure.
+type Mesh struct {
+	Quads []Quad
+}
+
+// New converts the Structure passed to a Mesh. Only faces of blocks that are not covered by another block are
+// part of the Mesh. Adjacent faces of blocks with the same name that face the same way are merged into a single
+// Quad, which greatly reduces the size of the Mesh for large, flat surfaces. Air and structure voids are not
+// part of the Mesh. Liquids are part of the Mesh if no other block is present at their position.
+func New(s structure.Structure) Mesh {
+	dims := s.Dimensions()
+	materials, names := materialsOf(s)
+	at := func(pos [3]int) int {
+		if pos[0] < 0 || pos[1] < 0 || pos[2] < 0 || pos[0] >= dims[0] || pos[1] >= dims[1] || pos[2] >= dims[2] {
+			return -1
+		}
+		for layer := 0; layer <= 1; layer++ {
+			if index := s.IndexAt(pos[0], pos[1], pos[2], layer); index != -1 && materials[index] != -1 {
+				return materials[index]
+			}
+		}
+		return -1
+	}
+
+	var m Mesh
+	for axis := 0; axis < 3; axis++ {
+		u, v := (axis+1)%3, (axis+2)%3
+		mask := make([]int, dims[u]*dims[v])
+		for _, sign := range [2]int{1, -1} {
+			normal := [3]int{}
+			normal[axis] = sign
+			for i := 0; i < dims[axis]; i++ {
+				// Find all faces in this slice that face the direction of the normal and are not covered by
+				// another block.
+				for a := 0; a < dims[u]; a++ {
+					for b := 0; b < dims[v]; b++ {
+						var pos [3]int
+						pos[axis], pos[u], pos[v] = i, a, b
+						mask[a*dims[v]+b] = -1
+						if mat := at(pos); mat != -1 {
+							pos[axis] += sign
+							if at(pos) == -1 {
+								mask[a*dims[v]+b] = mat
+							}
+						}
+					}
+				}
+				plane := i
+				if sign > 0 {
+					plane++
+				}
+				greedy(mask, dims[u], dims[v], func(a, b, w, h, mat int) {
+					corner := func(a, b int) [3]int {
+						var c [3]int
+						c[axis], c[u], c[v] = plane, a, b
+						return c
+					}
+					q := Quad{Normal: normal, Material: names[mat]}
+					q.Corners = [4][3]int{corner(a, b), corner(a+w, b), corner(a+w, b+h), corner(a, b+h)}
+					if sign < 0 {
+						q.Corners[1], q.Corners[3] = q.Corners[3], q.Corners[1]
+					}
+					m.Quads = append(m.Quads, q)
+				})
+			}
+		}
+	}
+	return m
+}
+
+// greedy merges the faces in the mask passed, which has a width of w and a height of h, into rectangles of the
+// same material. fn is called for every rectangle with its position, size and material. Entries in the mask
+// of -1 hold no face. The mask is cleared by greedy.
+func greedy(mask []int, w, h int, fn func(a, b, w, h, mat int)) {
+	for a := 0; a < w; a++ {
+		for b := 0; b < h; {
+			mat := mask[a*h+b]
+			if mat == -1 {
+				b++
+				continue
+			}
+			// Extend the rectangle along b as far as possible, then along a for as long as every row matches.
+			height := 1
+			for b+height < h && mask[a*h+b+height] == mat {
+				height++
+			}
+			width := 1
+		extend:
+			for a+width < w {
+				for k := 0; k < height; k++ {
+					if mask[(a+width)*h+b+k] != mat {
+						break extend
+					}
+				}
+				width++
+			}
+			for da := 0; da < width; da++ {
+				for k := 0; k < height; k++ {
+					mask[(a+da)*h+b+k] = -1
+				}
+			}
+			fn(a, b, width, height, mat)
+			b += height
+		}
+	}
+}
+
+// materialsOf returns the material of every entry in the palette of the Structure passed, and the names of the
+// materials. Palette entries that are not part of a Mesh have a material of -1.
+func materialsOf(s structure.Structure) ([]int, []string) {
+	materials := make([]int, s.PaletteSize())
+	indices := map[string]int{}
+	var names []string
+	for i := range materials {
+		name, _, _ := s.PaletteEntry(i)
+		if name == "minecraft:air" || name == "minecraft:structure_void" {
+			materials[i] = -1
+			continue
+		}
+		index, ok := indices[name]
+		if !ok {
+			index = len(names)
+			indices[name] = index
+			names = append(names, name)
+		}
+		materials[i] = index
+	}
+	return materials, names
+}
+
+// WriteOBJ writes the Mesh to the io.Writer passed in the Wavefront OBJ format. Every Quad refers to a material
+// named after its block, with the colon replaced by an underscore, such as 'minecraft_stone'. Vertices shared by
+// multiple Quads are only written once.
+func (m Mesh) WriteOBJ(w io.Writer) error {
+	bw := bufio.NewWriter(w)
+	vertices := map[[3]int]int{}
+	vertex := func(c [3]int) int {
+		index, ok := vertices[c]
+		if !ok {
+			index = len(vertices) + 1
+			vertices[c] = index
+			_, _ = fmt.Fprintf(bw, "v %v %v %v\n", c[0], c[1], c[2])
+		}
+		return index
+	}
+	material := ""
+	for _, q := range m.Quads {
+		var indices [4]int
+		for i, c := range q.Corners {
+			indices[i] = vertex(c)
+		}
+		if q.Material != material {
+			material = q.Material
+			_, _ = fmt.Fprintf(bw, "usemtl %v\n", strings.ReplaceAll(material, ":", "_"))
+		}
+		_, _ = fmt.Fprintf(bw, "f %v %v %v %v\n", indices[0], indices[1], indices[2], indices[3])
+	}
+	if err := bw.Flush(); err != nil {
+		return fmt.Errorf("write obj: %w", err)
+	}
+	return nil
+}
+
+// WriteOBJFile writes the Mesh to the file passed in the Wavefront OBJ format. WriteOBJFile creates a file if it
+// doesn't yet exist and truncates it if one does exist.
+func (m Mesh) WriteOBJFile(file string) error {
+	f, err := os.OpenFile(file, os.O_TRUNC|os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		return fmt.Errorf("open file: %w", err)
+	}
+	defer f.Close()
+	return m.WriteOBJ(f)
+}
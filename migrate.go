@@ -0,0 +1,69 @@
+package structure
+
+import (
+	"bytes"
+	"fmt"
+	"github.com/sandertv/gophertunnel/minecraft/nbt"
+)
+
+// migration migrates the NBT of a structure from one format version to another.
+type migration struct {
+	// to is the format version of the NBT once migrated.
+	to int32
+	// migrate changes the NBT passed in place, so that it matches format version to. The format_version field is
+	// updated after migrate returns and need not be changed by it.
+	migrate func(data map[string]interface{}) error
+}
+
+// migrations holds all migrations registered using registerMigration, keyed by the format version they migrate
+// from. When Mojang changes the format of structures, a migration is registered that converts structures of the
+// previous format version to the new one, or the other way around, so that structures of either version can be
+// read.
+var migrations = map[int32]migration{}
+
+// registerMigration registers a migration that converts the NBT of structures of format version from to format
+// version to using the function passed. Migrations are applied one after another until the NBT matches the format
+// version supported by the package. registerMigration panics if a migration from the same format version was
+// already registered.
+func registerMigration(from, to int32, migrate func(data map[string]interface{}) error) {
+	if _, ok := migrations[from]; ok {
+		panic(fmt.Sprintf("migration from format version %v already registered", from))
+	}
+	migrations[from] = migration{to: to, migrate: migrate}
+}
+
+// migrateStructure decodes the encoded NBT of a structure passed, applies the migrations registered to convert it
+// to the format version supported by the package and decodes the result into the structure passed. If no chain of
+// migrations leads to that format version, an error is returned.
+func migrateStructure(data []byte, s *structure) error {
+	var m map[string]interface{}
+	if err := nbt.NewDecoderWithEncoding(bytes.NewReader(data), nbt.LittleEndian).Decode(&m); err != nil {
+		return fmt.Errorf("decode structure: %v", err.Error())
+	}
+	// Migrations are never applied more than once each, so that migrations that convert between two format
+	// versions in both directions cannot loop forever.
+	applied := map[int32]bool{}
+	for {
+		v, _ := m["format_version"].(int32)
+		if v == version {
+			break
+		}
+		mig, ok := migrations[v]
+		if !ok || applied[v] {
+			return fmt.Errorf("unsupported format version %v: expected version %v", v, version)
+		}
+		if err := mig.migrate(m); err != nil {
+			return fmt.Errorf("migrate format version %v to %v: %w", v, mig.to, err)
+		}
+		applied[v], m["format_version"] = true, mig.to
+	}
+
+	buf := bytes.NewBuffer(make([]byte, 0, len(data)))
+	if err := nbt.NewEncoderWithEncoding(buf, nbt.LittleEndian).Encode(m); err != nil {
+		return fmt.Errorf("encode migrated structure: %w", err)
+	}
+	if err := nbt.NewDecoderWithEncoding(buf, nbt.LittleEndian).Decode(s); err != nil {
+		return fmt.Errorf("decode migrated structure: %v", err.Error())
+	}
+	return nil
+}
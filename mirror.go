@@ -0,0 +1,233 @@
+package structure
+
+import (
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/df-mc/dragonfly/server/world/chunk"
+	"github.com/go-gl/mathgl/mgl64"
+	"go/ast"
+	"reflect"
+	"strconv"
+)
+
+// Plane is a vertical plane through the centre of a Structure, across which the Structure may be mirrored.
+type Plane int
+
+const (
+	// PlaneX is the plane perpendicular to the X axis. Mirroring across it swaps the west and east sides of a
+	// Structure.
+	PlaneX Plane = iota
+	// PlaneZ is the plane perpendicular to the Z axis. Mirroring across it swaps the north and south sides of a
+	// Structure.
+	PlaneZ
+)
+
+// axis returns the index of the axis perpendicular to the Plane in positions and dimensions.
+func (p Plane) axis() int {
+	if p == PlaneZ {
+		return 2
+	}
+	return 0
+}
+
+// Symmetrize mirrors the half of the Structure with the lowest coordinates on the axis perpendicular to the plane
+// passed onto the other half, so that the Structure becomes symmetric across the plane. A builder may construct
+// only the west half of a symmetric build, for example, and have Symmetrize(PlaneX) complete the east half.
+// Blocks facing a direction, such as stairs, are flipped as they are mirrored, so that stairs facing west in the
+// west half face east in the east half. If the Structure has an odd size on the mirrored axis, the middle layer is
+// left unchanged, including the entities within it. Entities in the upper half are removed and replaced with
+// mirrored copies of those in the lower half.
+func (s Structure) Symmetrize(plane Plane) {
+	s.ensureParsed()
+	dims, a := s.Dimensions(), plane.axis()
+	mirrored := map[int32]int32{-1: -1}
+
+	var pos [3]int
+	for pos[0] = 0; pos[0] < dims[0]; pos[0]++ {
+		for pos[1] = 0; pos[1] < dims[1]; pos[1]++ {
+			for pos[2] = 0; pos[2] < dims[2]; pos[2]++ {
+				if pos[a] >= dims[a]/2 {
+					continue
+				}
+				dst := pos
+				dst[a] = dims[a] - 1 - pos[a]
+				offset := (pos[0] * s.l * s.h) + (pos[1] * s.l) + pos[2]
+				dstOffset := (dst[0] * s.l * s.h) + (dst[1] * s.l) + dst[2]
+
+				index := s.blocks[offset]
+				s.markDirty(dstOffset)
+				s.blocks[dstOffset] = s.mirrorIndex(mirrored, index, plane)
+				s.liquids[dstOffset] = s.mirrorIndex(mirrored, s.liquids[offset], plane)
+				if data, ok := s.positionData(offset); ok {
+					// The mirrored position gets its own copy of the data, so that changing the block entity at
+					// either position does not change the other.
+					data.BlockEntityData = cloneData(data.BlockEntityData)
+					if index != -1 {
+						data.BlockEntityData = mirrorBlockEntity(s.palette.BlockPalette[index], data.BlockEntityData, plane)
+					}
+					s.palette.BlockPositionData[strconv.Itoa(dstOffset)] = data
+				} else if len(s.palette.BlockPositionData) != 0 {
+					delete(s.palette.BlockPositionData, strconv.Itoa(dstOffset))
+				}
+			}
+		}
+	}
+
+	// Entities in the first half are kept and mirrored, entities in the middle layer of a structure with an odd
+	// size are kept unchanged, and entities in the second half are replaced by the mirrored ones.
+	low, high := float64(dims[a]/2), float64((dims[a]+1)/2)
+	entities := make([]map[string]interface{}, 0, len(s.Structure.Entities))
+	var mirroredEntities []map[string]interface{}
+	for _, e := range s.Structure.Entities {
+		p, ok := entityPos(e)
+		switch {
+		case !ok || (p[a] >= low && p[a] < high):
+			entities = append(entities, e)
+		case p[a] < low:
+			entities = append(entities, e)
+			mirroredEntities = append(mirroredEntities, mirrorEntity(e, p, dims[a], plane))
+		}
+	}
+	s.Structure.Entities = append(entities, mirroredEntities...)
+	s.entitiesDirty = true
+}
+
+// mirrorIndex returns the palette index of the block at the palette index passed, mirrored across the plane
+// passed. Indices already mirrored are looked up in the map passed, and newly mirrored indices are added to it.
+func (s Structure) mirrorIndex(mirrored map[int32]int32, index int32, plane Plane) int32 {
+	if ptr, ok := mirrored[index]; ok {
+		return ptr
+	}
+	ptr := index
+	if b, ok := mirrorBlock(s.parsedPalette[index].b, plane); ok {
+		name, states := b.EncodeBlock()
+		ptr = s.ptrForEntry(block{Name: name, States: states, Version: chunk.CurrentBlockVersion})
+	}
+	mirrored[index] = ptr
+	return ptr
+}
+
+var (
+	directionType   = reflect.TypeOf(cube.Direction(0))
+	faceType        = reflect.TypeOf(cube.Face(0))
+	orientationType = reflect.TypeOf(cube.Orientation(0))
+)
+
+// mirrorBlock returns the world.Block passed mirrored across the plane passed. Exported fields of the block that
+// hold a cube.Direction, cube.Face or cube.Orientation are flipped. If the block has no such fields, false is
+// returned.
+func mirrorBlock(b world.Block, plane Plane) (world.Block, bool) {
+	if b == nil || reflect.TypeOf(b).Kind() != reflect.Struct {
+		return nil, false
+	}
+	t := reflect.TypeOf(b)
+	v := reflect.New(t).Elem()
+	v.Set(reflect.ValueOf(b))
+
+	mirrored := false
+	for i := 0; i < v.NumField(); i++ {
+		if !ast.IsExported(t.Field(i).Name) {
+			continue
+		}
+		field := v.Field(i)
+		switch field.Type() {
+		case directionType:
+			field.SetInt(int64(mirrorDirection(cube.Direction(field.Int()), plane)))
+		case faceType:
+			field.SetInt(int64(mirrorFace(cube.Face(field.Int()), plane)))
+		case orientationType:
+			field.SetInt(int64(mirrorOrientation(int(field.Int()), 16, plane)))
+		default:
+			continue
+		}
+		mirrored = true
+	}
+	if !mirrored {
+		return nil, false
+	}
+	return v.Interface().(world.Block), true
+}
+
+// mirrorDirection returns the cube.Direction passed mirrored across the plane passed.
+func mirrorDirection(d cube.Direction, plane Plane) cube.Direction {
+	if (plane == PlaneX && (d == cube.West || d == cube.East)) || (plane == PlaneZ && (d == cube.North || d == cube.South)) {
+		return d.Opposite()
+	}
+	return d
+}
+
+// mirrorFace returns the cube.Face passed mirrored across the plane passed.
+func mirrorFace(f cube.Face, plane Plane) cube.Face {
+	if (plane == PlaneX && (f == cube.FaceWest || f == cube.FaceEast)) || (plane == PlaneZ && (f == cube.FaceNorth || f == cube.FaceSouth)) {
+		return f.Opposite()
+	}
+	return f
+}
+
+// mirrorOrientation returns the rotation passed, which is one of n rotations starting at south and turning
+// clockwise, mirrored across the plane passed.
+func mirrorOrientation(rot, n int, plane Plane) int {
+	if plane == PlaneX {
+		// Mirroring swaps west and east, so a yaw of a becomes -a.
+		return (n - rot) % n
+	}
+	// Mirroring swaps north and south, so a yaw of a becomes 180-a.
+	return (n/2 - rot + n) % n
+}
+
+// mirrorAngle returns the yaw passed, in degrees, mirrored across the plane passed.
+func mirrorAngle(yaw float64, plane Plane) float64 {
+	if plane == PlaneX {
+		return normaliseAngle(-yaw)
+	}
+	return normaliseAngle(180 - yaw)
+}
+
+// mirrorBlockEntity returns the block entity data passed, of the palette entry passed, mirrored across the plane
+// passed. Only block entities that store a rotation in their data are changed.
+func mirrorBlockEntity(bl block, data map[string]interface{}, plane Plane) map[string]interface{} {
+	switch bl.Name {
+	case "minecraft:skull":
+		data = copyBlockEntity(data)
+		if rot, ok := data["Rot"].(byte); ok {
+			data["Rot"] = byte(mirrorOrientation(int(rot), 16, plane))
+		}
+		if rot, ok := data["Rotation"].(float32); ok {
+			data["Rotation"] = float32(mirrorAngle(float64(rot), plane))
+		}
+	case "minecraft:frame", "minecraft:glow_frame":
+		data = copyBlockEntity(data)
+		if facing, _ := bl.States["facing_direction"].(int32); facing > 1 {
+			// The item in a frame attached to a wall is seen from the front, so mirroring the frame reverses the
+			// direction in which the item is rotated, regardless of the plane.
+			if rot, ok := data["ItemRotation"].(byte); ok {
+				data["ItemRotation"] = byte((8 - int(rot)) % 8)
+			}
+			if rot, ok := data["ItemRotation"].(float32); ok {
+				data["ItemRotation"] = float32(normaliseAngle(-float64(rot)))
+			}
+			break
+		}
+		// Items in item frames lying on the floor or hanging from the ceiling are mirrored like any other rotation
+		// around the Y axis.
+		if rot, ok := data["ItemRotation"].(byte); ok {
+			data["ItemRotation"] = byte(mirrorOrientation(int(rot), 8, plane))
+		}
+		if rot, ok := data["ItemRotation"].(float32); ok {
+			data["ItemRotation"] = float32(mirrorAngle(float64(rot), plane))
+		}
+	}
+	return data
+}
+
+// mirrorEntity returns a copy of the entity data passed, with its position pos mirrored across the plane passed
+// in a structure with the size passed on the mirrored axis. The yaw of the entity is mirrored too.
+func mirrorEntity(data map[string]interface{}, pos mgl64.Vec3, size int, plane Plane) map[string]interface{} {
+	a := plane.axis()
+	pos[a] = float64(size) - pos[a]
+	m := withEntityPos(data, pos)
+	if yaw, pitch, ok := entityRotation(data); ok {
+		m["Rotation"] = []float32{float32(mirrorAngle(float64(yaw), plane)), pitch}
+	}
+	return m
+}
@@ -0,0 +1,22 @@
+package structure
+
+import (
+	df "github.com/df-mc/dragonfly/server/block"
+	"strconv"
+	"testing"
+)
+
+func TestSymmetrizeCopiesBlockEntityData(t *testing.T) {
+	s := New([3]int{2, 1, 1})
+	s.Set(0, 0, 0, df.NewChest(), nil)
+	s.Symmetrize(PlaneX)
+
+	a, b := s.palette.BlockPositionData[strconv.Itoa(0)], s.palette.BlockPositionData[strconv.Itoa(1)]
+	if a.BlockEntityData == nil || b.BlockEntityData == nil {
+		t.Fatalf("expected block entity data at both positions")
+	}
+	a.BlockEntityData["CustomName"] = "changed"
+	if _, ok := b.BlockEntityData["CustomName"]; ok {
+		t.Fatalf("block entity data is shared between mirrored positions")
+	}
+}
@@ -0,0 +1,24 @@
+//go:build !(linux || darwin || freebsd || netbsd || openbsd || dragonfly)
+
+package structure
+
+import (
+	"io"
+	"os"
+)
+
+// mapFile reads the first size bytes of the file passed into memory. Memory-mapping files is not supported on
+// this platform, so the file is read completely instead.
+func mapFile(f *os.File, size int64) ([]byte, error) {
+	data := make([]byte, size)
+	if _, err := io.ReadFull(f, data); err != nil {
+		return nil, err
+	}
+	return data, nil
+}
+
+// unmapFile releases data previously returned by mapFile. Data read into memory is released by the garbage
+// collector, so unmapFile does nothing.
+func unmapFile([]byte) error {
+	return nil
+}
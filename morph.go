@@ -0,0 +1,131 @@
+package structure
+
+import (
+	"hash/fnv"
+	"reflect"
+	"sort"
+	"strconv"
+)
+
+// Morph returns steps structures that gradually transform the Structure from into the Structure to, so that
+// building them one after another, at the same position, shows a transition from one build to the other. The
+// first structure returned already holds some of the changes, while the last structure returned holds all of
+// them. Blocks that are removed are removed from the top down, while blocks that are added are added from the
+// bottom up.
+// The structures returned are as large as from and to combined, with both aligned at their lowest corner.
+// Positions that are only within the bounds of from are cleared to air. Positions that are only within the
+// bounds of to and at which to holds no block are left untouched.
+func Morph(from, to Structure, steps int) []Structure {
+	if steps < 1 {
+		steps = 1
+	}
+	fromDims, toDims := from.Dimensions(), to.Dimensions()
+	var dims [3]int
+	for i := range dims {
+		dims[i] = fromDims[i]
+		if toDims[i] > dims[i] {
+			dims[i] = toDims[i]
+		}
+	}
+	// The palette of a new structure holds only air, at index 0.
+	current, airPtr := New(dims), int32(0)
+	for i := range current.blocks {
+		current.blocks[i] = -1
+	}
+	// Resolve the palettes of from and to in the palette of the structure being morphed, so that the indices of
+	// both may be compared directly.
+	fromPtrs, toPtrs := make([]int32, len(from.palette.BlockPalette)), make([]int32, len(to.palette.BlockPalette))
+	for i, entry := range from.palette.BlockPalette {
+		fromPtrs[i] = current.ptrForEntry(entry)
+	}
+	for i, entry := range to.palette.BlockPalette {
+		toPtrs[i] = current.ptrForEntry(entry)
+	}
+	for x := 0; x < fromDims[0]; x++ {
+		for y := 0; y < fromDims[1]; y++ {
+			for z := 0; z < fromDims[2]; z++ {
+				fromOffset, offset := (x*from.l*from.h)+(y*from.l)+z, (x*current.l*current.h)+(y*current.l)+z
+				if index := from.blocks[fromOffset]; index != -1 {
+					current.blocks[offset] = fromPtrs[index]
+				}
+				if index := from.liquids[fromOffset]; index != -1 {
+					current.liquids[offset] = fromPtrs[index]
+				}
+				if d, ok := from.palette.BlockPositionData[strconv.Itoa(fromOffset)]; ok {
+					current.palette.BlockPositionData[strconv.Itoa(offset)] = d
+				}
+			}
+		}
+	}
+
+	type change struct {
+		offset     int
+		block, liq int32
+		data       map[string]interface{}
+		key        int
+		hash       uint32
+	}
+	var changes []change
+	for x := 0; x < dims[0]; x++ {
+		for y := 0; y < dims[1]; y++ {
+			for z := 0; z < dims[2]; z++ {
+				offset := (x * current.l * current.h) + (y * current.l) + z
+				c := change{offset: offset, block: -1, liq: -1}
+				if x < toDims[0] && y < toDims[1] && z < toDims[2] {
+					toOffset := (x * to.l * to.h) + (y * to.l) + z
+					if index := to.blocks[toOffset]; index != -1 {
+						c.block = toPtrs[index]
+					}
+					if index := to.liquids[toOffset]; index != -1 {
+						c.liq = toPtrs[index]
+					}
+					if d, ok := to.palette.BlockPositionData[strconv.Itoa(toOffset)]; ok {
+						c.data = d.BlockEntityData
+					}
+				} else if current.blocks[offset] != -1 {
+					c.block = airPtr
+				}
+				if c.block == -1 && c.liq == -1 {
+					// to does not hold anything here, so whatever is present is left as is.
+					continue
+				}
+				if c.block == current.blocks[offset] && c.liq == current.liquids[offset] {
+					d := current.palette.BlockPositionData[strconv.Itoa(offset)]
+					if reflect.DeepEqual(c.data, d.BlockEntityData) {
+						continue
+					}
+				}
+				// Removals start at the top, additions at the bottom.
+				c.key = y
+				if c.block == airPtr || c.block == -1 {
+					c.key = dims[1] - 1 - y
+				}
+				h := fnv.New32a()
+				_, _ = h.Write([]byte{byte(x), byte(x >> 8), byte(y), byte(y >> 8), byte(z), byte(z >> 8)})
+				c.hash = h.Sum32()
+				changes = append(changes, c)
+			}
+		}
+	}
+	// Changes are ordered by their key, and within the same key in a pseudo-random but deterministic order, so
+	// that every layer changes gradually rather than row by row.
+	sort.Slice(changes, func(i, j int) bool {
+		if changes[i].key != changes[j].key {
+			return changes[i].key < changes[j].key
+		}
+		return changes[i].hash < changes[j].hash
+	})
+
+	structures := make([]Structure, steps)
+	applied := 0
+	for step := 0; step < steps; step++ {
+		until := len(changes) * (step + 1) / steps
+		for ; applied < until; applied++ {
+			c := changes[applied]
+			current.setIndex(c.offset, c.block, c.data)
+			current.liquids[c.offset] = c.liq
+		}
+		structures[step] = current.clone()
+	}
+	return structures
+}
@@ -0,0 +1,190 @@
+package structure
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"math"
+)
+
+// NBT tag types, as found in the data of a structure.
+const (
+	nbtEnd = iota
+	nbtByte
+	nbtShort
+	nbtInt
+	nbtLong
+	nbtFloat
+	nbtDouble
+	nbtByteArray
+	nbtString
+	nbtList
+	nbtCompound
+	nbtIntArray
+	nbtLongArray
+)
+
+// nbtScanner scans through little-endian NBT data without decoding it. Once an error occurs, the nbtScanner stops
+// advancing and the error is kept in the err field.
+type nbtScanner struct {
+	data []byte
+	off  int
+	err  error
+
+	// src is the io.Reader that data is read from as the nbtScanner advances. If src is nil, data holds all NBT to
+	// scan. The nbtScanner never reads further from src than it has advanced, and reads no more than the length
+	// of the data it advances over, so that lengths in the NBT cannot make it allocate more than the NBT holds.
+	src io.Reader
+}
+
+// next advances the nbtScanner by n bytes, returning the bytes advanced over.
+func (r *nbtScanner) next(n int) []byte {
+	if r.err != nil {
+		return nil
+	}
+	if n > len(r.data)-r.off && n >= 0 && r.src != nil {
+		buf := bytes.NewBuffer(r.data)
+		_, err := buf.ReadFrom(io.LimitReader(r.src, int64(n-(len(r.data)-r.off))))
+		if r.data = buf.Bytes(); err != nil {
+			r.err = err
+			return nil
+		}
+	}
+	if n < 0 || n > len(r.data)-r.off {
+		r.err = fmt.Errorf("unexpected end of data at offset %v", r.off)
+		return nil
+	}
+	b := r.data[r.off : r.off+n]
+	r.off += n
+	return b
+}
+
+// byte reads a single byte.
+func (r *nbtScanner) byte() byte {
+	if b := r.next(1); b != nil {
+		return b[0]
+	}
+	return 0
+}
+
+// int32 reads a little-endian int32.
+func (r *nbtScanner) int32() int32 {
+	if b := r.next(4); b != nil {
+		return int32(binary.LittleEndian.Uint32(b))
+	}
+	return 0
+}
+
+// length reads a little-endian int32 length prefix, multiplied by the size of an element passed. Negative
+// lengths and lengths that overflow result in an error.
+func (r *nbtScanner) length(size int) int {
+	n := r.int32()
+	if n < 0 || int64(n)*int64(size) > math.MaxInt32 {
+		if r.err == nil {
+			r.err = fmt.Errorf("invalid length %v at offset %v", n, r.off)
+		}
+		return 0
+	}
+	return int(n) * size
+}
+
+// string reads a string prefixed by its length as a little-endian uint16.
+func (r *nbtScanner) string() string {
+	b := r.next(2)
+	if b == nil {
+		return ""
+	}
+	return string(r.next(int(binary.LittleEndian.Uint16(b))))
+}
+
+// tag reads the type and name of a named tag. If the tag type is nbtEnd, no name is read.
+func (r *nbtScanner) tag() (t byte, name string) {
+	if t = r.byte(); t == nbtEnd {
+		return t, ""
+	}
+	return t, r.string()
+}
+
+// skip advances the nbtScanner past the payload of a tag of the type passed.
+func (r *nbtScanner) skip(t byte) {
+	switch t {
+	case nbtByte:
+		r.next(1)
+	case nbtShort:
+		r.next(2)
+	case nbtInt, nbtFloat:
+		r.next(4)
+	case nbtLong, nbtDouble:
+		r.next(8)
+	case nbtByteArray:
+		r.next(r.length(1))
+	case nbtString:
+		r.string()
+	case nbtList:
+		elem := r.byte()
+		r.skipElements(elem, r.length(1))
+	case nbtCompound:
+		for r.err == nil {
+			t, _ := r.tag()
+			if t == nbtEnd {
+				break
+			}
+			r.skip(t)
+		}
+	case nbtIntArray:
+		r.next(r.length(4))
+	case nbtLongArray:
+		r.next(r.length(8))
+	default:
+		if r.err == nil {
+			r.err = fmt.Errorf("unknown tag type %v at offset %v", t, r.off)
+		}
+	}
+}
+
+// skipElements advances the nbtScanner past n list elements of the tag type passed. Elements with a fixed size
+// are skipped at once.
+func (r *nbtScanner) skipElements(t byte, n int) {
+	size := 0
+	switch t {
+	case nbtByte:
+		size = 1
+	case nbtShort:
+		size = 2
+	case nbtInt, nbtFloat:
+		size = 4
+	case nbtLong, nbtDouble:
+		size = 8
+	}
+	if size != 0 {
+		if int64(n)*int64(size) > math.MaxInt32 {
+			r.err = fmt.Errorf("invalid list length %v at offset %v", n, r.off)
+			return
+		}
+		r.next(n * size)
+		return
+	}
+	for i := 0; i < n && r.err == nil; i++ {
+		r.skip(t)
+	}
+}
+
+// intLists reads the payload of a list of lists of int32s, returning the encoded content of every inner list.
+func (r *nbtScanner) intLists() [][]byte {
+	elem := r.byte()
+	n := r.length(1)
+	if r.err == nil && n != 0 && elem != nbtList {
+		r.err = fmt.Errorf("block indices must be a list of lists, got element tag type %v", elem)
+	}
+	lists := make([][]byte, 0, n)
+	for i := 0; i < n && r.err == nil; i++ {
+		elem := r.byte()
+		size := r.length(4)
+		if r.err == nil && size != 0 && elem != nbtInt {
+			r.err = fmt.Errorf("block indices must be lists of ints, got element tag type %v", elem)
+		}
+		lists = append(lists, r.next(size))
+	}
+	return lists
+}
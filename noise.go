@@ -0,0 +1,78 @@
+package structure
+
+import (
+	"math"
+	"math/rand"
+)
+
+// simplex generates 3D simplex noise, based on the implementation described by Stefan Gustavson in 'Simplex noise
+// demystified'. The permutation table is shuffled using a seed, so that different seeds result in different noise.
+type simplex struct {
+	perm [512]int
+}
+
+// simplexGradients holds the gradients used for 3D simplex noise: the midpoints of the edges of a cube.
+var simplexGradients = [12][3]float64{
+	{1, 1, 0}, {-1, 1, 0}, {1, -1, 0}, {-1, -1, 0},
+	{1, 0, 1}, {-1, 0, 1}, {1, 0, -1}, {-1, 0, -1},
+	{0, 1, 1}, {0, -1, 1}, {0, 1, -1}, {0, -1, -1},
+}
+
+// newSimplex creates a simplex noise generator with a permutation table shuffled using the seed passed.
+func newSimplex(seed int64) *simplex {
+	n := &simplex{}
+	for i, v := range rand.New(rand.NewSource(seed)).Perm(256) {
+		n.perm[i], n.perm[i+256] = v, v
+	}
+	return n
+}
+
+// noise returns the noise value at the x, y and z passed, in the range [-1, 1].
+func (n *simplex) noise(x, y, z float64) float64 {
+	const f3, g3 = 1.0 / 3.0, 1.0 / 6.0
+
+	// Skew the input space to find the simplex cell the point is in.
+	s := (x + y + z) * f3
+	i, j, k := math.Floor(x+s), math.Floor(y+s), math.Floor(z+s)
+	t := (i + j + k) * g3
+	x0, y0, z0 := x-(i-t), y-(j-t), z-(k-t)
+
+	// Find out which of the six simplices of the cell the point is in.
+	var i1, j1, k1, i2, j2, k2 float64
+	switch {
+	case x0 >= y0 && y0 >= z0:
+		i1, j1, k1, i2, j2, k2 = 1, 0, 0, 1, 1, 0
+	case x0 >= y0 && x0 >= z0:
+		i1, j1, k1, i2, j2, k2 = 1, 0, 0, 1, 0, 1
+	case x0 >= y0:
+		i1, j1, k1, i2, j2, k2 = 0, 0, 1, 1, 0, 1
+	case y0 < z0:
+		i1, j1, k1, i2, j2, k2 = 0, 0, 1, 0, 1, 1
+	case x0 < z0:
+		i1, j1, k1, i2, j2, k2 = 0, 1, 0, 0, 1, 1
+	default:
+		i1, j1, k1, i2, j2, k2 = 0, 1, 0, 1, 1, 0
+	}
+	corners := [4][3]float64{
+		{x0, y0, z0},
+		{x0 - i1 + g3, y0 - j1 + g3, z0 - k1 + g3},
+		{x0 - i2 + 2*g3, y0 - j2 + 2*g3, z0 - k2 + 2*g3},
+		{x0 - 1 + 3*g3, y0 - 1 + 3*g3, z0 - 1 + 3*g3},
+	}
+	offsets := [4][3]int{{0, 0, 0}, {int(i1), int(j1), int(k1)}, {int(i2), int(j2), int(k2)}, {1, 1, 1}}
+
+	ii, jj, kk := int(i)&255, int(j)&255, int(k)&255
+	var total float64
+	for c, p := range corners {
+		t := 0.6 - p[0]*p[0] - p[1]*p[1] - p[2]*p[2]
+		if t < 0 {
+			continue
+		}
+		o := offsets[c]
+		g := simplexGradients[n.perm[ii+o[0]+n.perm[jj+o[1]+n.perm[kk+o[2]]]]%12]
+		t *= t
+		total += t * t * (g[0]*p[0] + g[1]*p[1] + g[2]*p[2])
+	}
+	// Scale the result so that it lies roughly in the range [-1, 1].
+	return 32 * total
+}
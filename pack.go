@@ -0,0 +1,147 @@
+package structure
+
+import (
+	"archive/zip"
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"github.com/google/uuid"
+	"io"
+	"os"
+	"sort"
+	"strings"
+)
+
+// BehaviourPack describes a behaviour pack written using WriteBehaviourPack. Structures in a behaviour pack may be
+// loaded in-game by players that apply the pack to a world, using the /structure load command or a structure
+// block.
+type BehaviourPack struct {
+	// Name is the name of the pack shown in-game.
+	Name string
+	// Description is the description of the pack shown in-game.
+	Description string
+	// Version is the version of the pack. Minecraft replaces an applied pack with the same UUID only if its
+	// version is higher. If left empty, version 1.0.0 is used.
+	Version [3]int
+	// UUID is the UUID of the pack. If left empty, a UUID is derived from the name of the pack, so that packs
+	// written again with the same name replace the pack written before.
+	UUID uuid.UUID
+}
+
+// packManifest is the manifest.json file of a behaviour pack.
+type packManifest struct {
+	FormatVersion int                  `json:"format_version"`
+	Header        packManifestHeader   `json:"header"`
+	Modules       []packManifestModule `json:"modules"`
+}
+
+// packManifestHeader is the header of the manifest of a behaviour pack.
+type packManifestHeader struct {
+	Name             string `json:"name"`
+	Description      string `json:"description"`
+	UUID             string `json:"uuid"`
+	Version          [3]int `json:"version"`
+	MinEngineVersion [3]int `json:"min_engine_version"`
+}
+
+// packManifestModule is a module listed in the manifest of a behaviour pack.
+type packManifestModule struct {
+	Type    string `json:"type"`
+	UUID    string `json:"uuid"`
+	Version [3]int `json:"version"`
+}
+
+// packNamespace is the namespace used to derive the UUIDs of behaviour packs without a UUID from their name.
+var packNamespace = uuid.MustParse("8c5e5b0e-4d7b-4c57-9f3a-5a2f3c1d6e90")
+
+// WriteBehaviourPack writes a behaviour pack holding the structures passed, keyed by their names, to the
+// io.Writer passed as a zip file, which may be saved as an .mcpack file. If successful, the error returned is nil.
+// Names may have a namespace, such as 'village:house', in which case the structure is loaded in-game using the
+// same name. Names without a namespace, such as 'house', are loaded using the 'mystructure' namespace, like
+// 'mystructure:house'.
+func WriteBehaviourPack(w io.Writer, pack BehaviourPack, structures map[string]Structure) error {
+	names := make([]string, 0, len(structures))
+	for name := range structures {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	zw := zip.NewWriter(w)
+	for _, name := range names {
+		f, err := zw.Create(packStructureFile(name))
+		if err != nil {
+			return fmt.Errorf("create structure %v: %w", name, err)
+		}
+		if err := Write(f, structures[name]); err != nil {
+			return fmt.Errorf("write structure %v: %w", name, err)
+		}
+	}
+	f, err := zw.Create("manifest.json")
+	if err != nil {
+		return fmt.Errorf("create manifest: %w", err)
+	}
+	enc := json.NewEncoder(f)
+	enc.SetIndent("", "  ")
+	if err := enc.Encode(pack.manifest()); err != nil {
+		return fmt.Errorf("encode manifest: %w", err)
+	}
+	if err := zw.Close(); err != nil {
+		return fmt.Errorf("close zip: %w", err)
+	}
+	return nil
+}
+
+// WriteBehaviourPackFile writes a behaviour pack holding the structures passed, keyed by their names, to the file
+// passed, like WriteBehaviourPack. If successful, the error returned is nil. WriteBehaviourPackFile creates a file
+// if it doesn't yet exist and truncates it if one does exist.
+func WriteBehaviourPackFile(file string, pack BehaviourPack, structures map[string]Structure) error {
+	f, err := os.OpenFile(file, os.O_TRUNC|os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		return fmt.Errorf("open file: %w", err)
+	}
+	w := bufio.NewWriter(f)
+	defer func() {
+		_ = w.Flush()
+		_ = f.Close()
+	}()
+	return WriteBehaviourPack(w, pack, structures)
+}
+
+// manifest returns the manifest of the BehaviourPack.
+func (pack BehaviourPack) manifest() packManifest {
+	version := pack.Version
+	if version == [3]int{} {
+		version = [3]int{1, 0, 0}
+	}
+	id := pack.UUID
+	if id == uuid.Nil {
+		id = uuid.NewSHA1(packNamespace, []byte(pack.Name))
+	}
+	return packManifest{
+		FormatVersion: 2,
+		Header: packManifestHeader{
+			Name:             pack.Name,
+			Description:      pack.Description,
+			UUID:             id.String(),
+			Version:          version,
+			MinEngineVersion: [3]int{1, 16, 0},
+		},
+		Modules: []packManifestModule{{
+			Type: "data",
+			// The UUID of the module must differ from that of the pack, but should be stable too.
+			UUID:    uuid.NewSHA1(id, []byte("data")).String(),
+			Version: version,
+		}},
+	}
+}
+
+// packStructureFile returns the path of the file in a behaviour pack that holds the structure with the name passed.
+// Structures are stored in the structures directory, in a subdirectory named after their namespace.
+func packStructureFile(name string) string {
+	if namespace, path, ok := strings.Cut(name, ":"); ok && namespace != "mystructure" {
+		return "structures/" + namespace + "/" + path + ".mcstructure"
+	} else if ok {
+		name = path
+	}
+	return "structures/" + name + ".mcstructure"
+}
@@ -0,0 +1,280 @@
+package structure
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"github.com/df-mc/dragonfly/server/world"
+	"os"
+	"strconv"
+)
+
+// pageHeight is the number of layers along the Y axis that make up a single page of a PagedStructure, which is
+// the height of a chunk section.
+const pageHeight = 16
+
+// PagedStructure is a mutable structure for structures far larger than would fit in memory. Its block indices are
+// split up into pages of 16 layers along the Y axis, of which only the most recently used pages are held in
+// memory. Other pages are spilled to a temporary file and read back transparently once At or Set is called for a
+// position in them. Blocks are placed and read like in a Structure, so that gigantic builds may be constructed and
+// built layer by layer without holding all of them in memory at once. The palette and block entity data of a
+// PagedStructure are always held in memory.
+// A PagedStructure implements world.Structure, so it may be built directly using world.World.BuildStructure. It
+// is not safe for concurrent use, and must be closed using Close once it is no longer used, which removes its
+// temporary file.
+type PagedStructure struct {
+	s *structure
+	// file is the temporary file that pages are spilled to. Page i is stored at offset i*pageLen*8 in the file,
+	// with its block indices followed by its liquid indices. stored records which pages were ever written to the
+	// file: pages that were not hold no blocks other than air.
+	file   *os.File
+	stored []bool
+
+	maxPages, pageLen int
+	pages             map[int]*page
+	// used is incremented every time a page is accessed, so that the least recently used page may be found.
+	used uint64
+	err  error
+}
+
+// page is a page of a PagedStructure held in memory.
+type page struct {
+	blocks, liquids []int32
+	dirty           bool
+	used            uint64
+}
+
+// Check to ensure that *PagedStructure implements the world.Structure interface.
+var _ world.Structure = (*PagedStructure)(nil)
+
+// NewPaged creates a new PagedStructure with the dimensions passed, initialised with air blocks. At most maxPages
+// pages of 16 layers are held in memory at once, which must be at least 1. The temporary file that other pages are
+// spilled to is created in the default directory for temporary files.
+func NewPaged(dimensions [3]int, maxPages int) (*PagedStructure, error) {
+	for i, d := range dimensions {
+		if d <= 0 {
+			return nil, fmt.Errorf("structure dimensions must be positive, but got %v for axis %v (%v)", d, i, dimensions)
+		}
+	}
+	if maxPages < 1 {
+		return nil, fmt.Errorf("maximum number of pages must be at least 1, but got %v", maxPages)
+	}
+	f, err := os.CreateTemp("", "structure-*.pages")
+	if err != nil {
+		return nil, fmt.Errorf("create page file: %w", err)
+	}
+	s := &structure{
+		FormatVersion: version,
+		Size:          []int32{int32(dimensions[0]), int32(dimensions[1]), int32(dimensions[2])},
+		Origin:        []int32{0, 0, 0},
+		Structure:     structureData{Palettes: map[string]palette{}},
+	}
+	Structure{structure: s}.UsePalette("default")
+	return &PagedStructure{
+		s:        s,
+		file:     f,
+		stored:   make([]bool, (dimensions[1]+pageHeight-1)/pageHeight),
+		maxPages: maxPages,
+		pageLen:  dimensions[0] * pageHeight * dimensions[2],
+		pages:    make(map[int]*page, maxPages),
+	}, nil
+}
+
+// Dimensions returns the dimensions of the structure.
+func (m *PagedStructure) Dimensions() [3]int {
+	return m.s.Dimensions()
+}
+
+// At returns the block and liquid at the x, y and z passed in the structure. Like Structure.At, it returns nil
+// for positions that do not hold a block or liquid.
+func (m *PagedStructure) At(x, y, z int, _ func(x int, y int, z int) world.Block) (world.Block, world.Liquid) {
+	p, offset := m.page(x, y, z)
+	m.s.ensureParsed()
+	var b world.Block
+	if index := p.blocks[offset]; index != -1 {
+		entry := m.s.parsedPalette[index]
+		b = entry.b
+		if entry.hasNBT {
+			if nbtData, ok := m.s.positionData(m.offset(x, y, z)); ok {
+				b = entry.b.(world.NBTer).DecodeNBT(nbtData.BlockEntityData).(world.Block)
+			}
+		}
+	}
+	if index := p.liquids[offset]; index != -1 {
+		return b, m.s.parsedPalette[index].liq
+	}
+	return b, nil
+}
+
+// Set sets the block and liquid at the x, y and z passed in the structure, like Structure.Set. The world.Liquid
+// passed may be nil to avoid waterlogging the block.
+func (m *PagedStructure) Set(x, y, z int, b world.Block, liq world.Liquid) {
+	p, offset := m.page(x, y, z)
+	p.blocks[offset], p.dirty = m.s.ptrFor(b), true
+
+	key := strconv.Itoa(m.offset(x, y, z))
+	if nbtBlock, ok := b.(world.NBTer); ok {
+		m.s.palette.BlockPositionData[key] = blockPositionData{BlockEntityData: nbtBlock.EncodeNBT()}
+	} else if len(m.s.palette.BlockPositionData) != 0 {
+		delete(m.s.palette.BlockPositionData, key)
+	}
+
+	p.liquids[offset] = -1
+	if liq != nil {
+		p.liquids[offset] = m.s.ptrFor(liq)
+	}
+}
+
+// PaletteSize returns the number of entries in the palette of the structure.
+func (m *PagedStructure) PaletteSize() int {
+	return m.s.PaletteSize()
+}
+
+// Palette returns all distinct blocks in the palette of the structure. See Structure.Palette.
+func (m *PagedStructure) Palette() []world.Block {
+	return m.s.Palette()
+}
+
+// String returns a summary of the structure, holding its dimensions and the size of its palette.
+func (m *PagedStructure) String() string {
+	dims := m.Dimensions()
+	return fmt.Sprintf("PagedStructure(%vx%vx%v, palette: %v, pages in memory: %v)", dims[0], dims[1], dims[2], m.PaletteSize(), len(m.pages))
+}
+
+// Err returns the first error that occurred reading pages from or writing pages to the temporary file of the
+// PagedStructure. Because At and Set cannot return errors, Err should be checked after building or filling a
+// PagedStructure. Positions in pages that could not be read hold no block.
+func (m *PagedStructure) Err() error {
+	return m.err
+}
+
+// Load reads the PagedStructure into memory completely, returning it as a regular Structure. An error is returned
+// if the PagedStructure is too large to be held in a Structure, or if a page could not be read.
+func (m *PagedStructure) Load() (Structure, error) {
+	dims := m.Dimensions()
+	s, err := NewChecked(dims)
+	if err != nil {
+		return Structure{}, err
+	}
+	s.sharePalette(m.s)
+	s.prepare()
+	for k, v := range m.s.palette.BlockPositionData {
+		s.palette.BlockPositionData[k] = v
+	}
+	for y := 0; y < dims[1]; y++ {
+		for x := 0; x < dims[0]; x++ {
+			p, offset := m.page(x, y, 0)
+			start := (x * s.l * s.h) + (y * s.l)
+			copy(s.blocks[start:start+s.l], p.blocks[offset:offset+s.l])
+			copy(s.liquids[start:start+s.l], p.liquids[offset:offset+s.l])
+		}
+	}
+	if m.err != nil {
+		return Structure{}, m.err
+	}
+	return s, nil
+}
+
+// Close closes and removes the temporary file of the PagedStructure. The PagedStructure must not be used after
+// calling Close.
+func (m *PagedStructure) Close() error {
+	m.pages = nil
+	if m.file == nil {
+		return nil
+	}
+	name := m.file.Name()
+	err := m.file.Close()
+	m.file = nil
+	if rmErr := os.Remove(name); err == nil {
+		err = rmErr
+	}
+	return err
+}
+
+// offset returns the offset of the x, y and z passed in the structure as a whole, which is used as key for block
+// entity data.
+func (m *PagedStructure) offset(x, y, z int) int {
+	return (x * m.s.l * m.s.h) + (y * m.s.l) + z
+}
+
+// page returns the page holding the x, y and z passed, along with the offset of the position in the page. If the
+// page is not in memory, it is read from the temporary file, spilling the least recently used page if needed.
+func (m *PagedStructure) page(x, y, z int) (*page, int) {
+	i := y / pageHeight
+	offset := (x*pageHeight+y%pageHeight)*m.s.l + z
+
+	m.used++
+	if p, ok := m.pages[i]; ok {
+		p.used = m.used
+		return p, offset
+	}
+	if len(m.pages) >= m.maxPages {
+		m.evict()
+	}
+	p := &page{blocks: make([]int32, m.pageLen), liquids: make([]int32, m.pageLen), used: m.used}
+	if m.stored[i] {
+		if err := m.read(i, p); err != nil && m.err == nil {
+			m.err = err
+		}
+	} else {
+		// Pages never spilled hold only air, which is always the first entry in the palette.
+		for j := range p.liquids {
+			p.liquids[j] = -1
+		}
+	}
+	m.pages[i] = p
+	return p, offset
+}
+
+// evict removes the least recently used page from memory, writing it to the temporary file if it was changed
+// since it was last read.
+func (m *PagedStructure) evict() {
+	lru := -1
+	for i, p := range m.pages {
+		if lru == -1 || p.used < m.pages[lru].used {
+			lru = i
+		}
+	}
+	if p := m.pages[lru]; p.dirty {
+		if err := m.write(lru, p); err != nil && m.err == nil {
+			m.err = err
+		}
+	}
+	delete(m.pages, lru)
+}
+
+// read reads the page with the index passed from the temporary file into the page passed.
+func (m *PagedStructure) read(i int, p *page) error {
+	if m.file == nil {
+		return errors.New("read page: structure is closed")
+	}
+	buf := make([]byte, m.pageLen*8)
+	if _, err := m.file.ReadAt(buf, int64(i)*int64(len(buf))); err != nil {
+		for j := range p.blocks {
+			p.blocks[j], p.liquids[j] = -1, -1
+		}
+		return fmt.Errorf("read page: %w", err)
+	}
+	for j := range p.blocks {
+		p.blocks[j] = int32(binary.LittleEndian.Uint32(buf[j*4:]))
+		p.liquids[j] = int32(binary.LittleEndian.Uint32(buf[(m.pageLen+j)*4:]))
+	}
+	return nil
+}
+
+// write writes the page passed to the temporary file at the index passed.
+func (m *PagedStructure) write(i int, p *page) error {
+	if m.file == nil {
+		return errors.New("write page: structure is closed")
+	}
+	buf := make([]byte, m.pageLen*8)
+	for j := range p.blocks {
+		binary.LittleEndian.PutUint32(buf[j*4:], uint32(p.blocks[j]))
+		binary.LittleEndian.PutUint32(buf[(m.pageLen+j)*4:], uint32(p.liquids[j]))
+	}
+	if _, err := m.file.WriteAt(buf, int64(i)*int64(len(buf))); err != nil {
+		return fmt.Errorf("write page: %w", err)
+	}
+	m.stored[i], p.dirty = true, false
+	return nil
+}
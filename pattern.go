@@ -0,0 +1,140 @@
+package structure
+
+import (
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/world"
+	"math"
+)
+
+// Pattern decides which block is placed at a position when filling an area of a structure, for example using
+// Builder.FillPattern. Patterns make it possible to fill areas with varied blocks, such as terrain-like fills, without
+// setting every block separately.
+type Pattern interface {
+	// At returns the block to place at the x, y and z passed. These are positions in the structure being filled.
+	// If nil is returned, the position is left untouched.
+	At(x, y, z int) world.Block
+}
+
+// PatternFunc is a function that implements Pattern.
+type PatternFunc func(x, y, z int) world.Block
+
+// At calls the PatternFunc with the x, y and z passed.
+func (f PatternFunc) At(x, y, z int) world.Block {
+	return f(x, y, z)
+}
+
+// Weighted is a block with a weight, used to create a Pattern using Mix.
+type Weighted struct {
+	// Block is the block placed. Block may be nil to leave positions untouched.
+	Block world.Block
+	// Weight is the weight of the block relative to the weights of the other blocks in the Pattern. Weights
+	// need not add up to any particular number: blocks weighted 70 and 30 and blocks weighted 0.7 and 0.3
+	// result in the same Pattern.
+	Weight float64
+}
+
+// Mix returns a Pattern that selects one of the blocks passed at random for every position, with a chance
+// proportional to its weight. The selection depends only on the seed and position, so filling the same area
+// twice with the same seed results in the same blocks. Blocks with a weight of zero or less are never selected.
+// If no block has a positive weight, the Pattern leaves all positions untouched.
+func Mix(seed int64, blocks ...Weighted) Pattern {
+	var total float64
+	for _, b := range blocks {
+		if b.Weight > 0 {
+			total += b.Weight
+		}
+	}
+	return PatternFunc(func(x, y, z int) world.Block {
+		r := positionRand(seed, x, y, z) * total
+		for _, b := range blocks {
+			if b.Weight <= 0 {
+				continue
+			}
+			if r -= b.Weight; r < 0 {
+				return b.Block
+			}
+		}
+		return nil
+	})
+}
+
+// Gradient returns a Pattern that blends between the blocks passed along an axis. Positions at or before start
+// on the axis are filled with the first block, and positions at or after end with the last. Positions in between
+// are filled with a mix of the two closest blocks, with the chance of either block depending on how close the
+// position is to it, so that the blocks blend into each other gradually. start may be larger than end to run the
+// gradient in the opposite direction. The mix depends only on the seed and position.
+// Gradient(cube.Y, 0, 16, seed, block.Sandstone{}, block.Sandstone{Red: true}) for example fills a column that
+// turns from sandstone at the bottom to red sandstone at the top. If no blocks are passed, the Pattern leaves all
+// positions untouched.
+func Gradient(axis cube.Axis, start, end int, seed int64, blocks ...world.Block) Pattern {
+	return PatternFunc(func(x, y, z int) world.Block {
+		if len(blocks) == 0 {
+			return nil
+		}
+		v := y
+		switch axis {
+		case cube.X:
+			v = x
+		case cube.Z:
+			v = z
+		}
+		var t float64
+		switch {
+		case start == end && v < start:
+			t = 0
+		case start == end:
+			t = 1
+		default:
+			t = math.Max(0, math.Min(1, float64(v-start)/float64(end-start)))
+		}
+		pos := t * float64(len(blocks)-1)
+		i := int(pos)
+		if i == len(blocks)-1 {
+			return blocks[i]
+		}
+		if positionRand(seed, x, y, z) < pos-float64(i) {
+			return blocks[i+1]
+		}
+		return blocks[i]
+	})
+}
+
+// Noise returns a Pattern that selects one of the blocks passed using 3D simplex noise, so that each block forms
+// smooth, natural-looking patches. scale is the rough size of these patches in blocks: larger values result in
+// larger patches. The range of the noise is divided evenly over the blocks passed, so blocks passed next to each
+// other also tend to be found next to each other. The noise depends only on the seed and position. If no blocks
+// are passed, the Pattern leaves all positions untouched.
+func Noise(seed int64, scale float64, blocks ...world.Block) Pattern {
+	if scale <= 0 {
+		scale = 1
+	}
+	n := newSimplex(seed)
+	return PatternFunc(func(x, y, z int) world.Block {
+		if len(blocks) == 0 {
+			return nil
+		}
+		v := n.noise(float64(x)/scale, float64(y)/scale, float64(z)/scale)
+		i := int((v + 1) / 2 * float64(len(blocks)))
+		if i < 0 {
+			i = 0
+		} else if i >= len(blocks) {
+			i = len(blocks) - 1
+		}
+		return blocks[i]
+	})
+}
+
+// positionRand returns a pseudo-random number in the range [0, 1) that depends only on the seed and position
+// passed.
+func positionRand(seed int64, x, y, z int) float64 {
+	h := uint64(seed)
+	h ^= uint64(int64(x)) * 0x9e3779b97f4a7c15
+	h ^= uint64(int64(y)) * 0xc2b2ae3d27d4eb4f
+	h ^= uint64(int64(z)) * 0x165667b19e3779f9
+	// Finalise the hash using splitmix64, which spreads small differences in input over all bits.
+	h += 0x9e3779b97f4a7c15
+	h = (h ^ (h >> 30)) * 0xbf58476d1ce4e5b9
+	h = (h ^ (h >> 27)) * 0x94d049bb133111eb
+	h ^= h >> 31
+	return float64(h>>11) / (1 << 53)
+}
@@ -0,0 +1,226 @@
+package structure
+
+import (
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/world"
+	"math"
+)
+
+// Visit calls fn for every position within the region passed that holds a block. The region is clamped to
+// the dimensions of the structure, so passing a cube.BBox that exceeds these dimensions is valid. Positions
+// that do not hold a block (those that hold a structure void) are skipped. If fn returns true, Visit stops
+// iterating immediately.
+// Visit returns true if it was stopped by fn.
+func (s *structure) Visit(region cube.BBox, fn func(pos cube.Pos, b world.Block) (stop bool)) bool {
+	min, max := s.clamp(region)
+	for x := min[0]; x < max[0]; x++ {
+		for y := min[1]; y < max[1]; y++ {
+			for z := min[2]; z < max[2]; z++ {
+				b := s.BlockAt(x, y, z)
+				if b == nil {
+					continue
+				}
+				if fn(cube.Pos{x, y, z}, b) {
+					return true
+				}
+			}
+		}
+	}
+	return false
+}
+
+// clamp converts a cube.BBox to an inclusive minimum and exclusive maximum position, clamped to the
+// dimensions of the structure.
+func (s *structure) clamp(region cube.BBox) (min, max [3]int) {
+	dims := s.Dimensions()
+	regionMin, regionMax := region.Min(), region.Max()
+	for i := 0; i < 3; i++ {
+		min[i] = int(math.Floor(regionMin[i]))
+		max[i] = int(math.Ceil(regionMax[i]))
+		if min[i] < 0 {
+			min[i] = 0
+		}
+		if max[i] > dims[i] {
+			max[i] = dims[i]
+		}
+	}
+	return min, max
+}
+
+// Columns calls fn for every column of the structure, passing the x and z of the column and the highest y
+// in that column that holds a block other than air. If the column holds no such block, y is -1.
+func (s *structure) Columns(fn func(x, z, y int)) {
+	air := s.airEntries()
+	dims := s.Dimensions()
+	for x := 0; x < dims[0]; x++ {
+		for z := 0; z < dims[2]; z++ {
+			y := dims[1] - 1
+			for ; y >= 0; y-- {
+				if index := s.blocks[(x*s.l*s.h)+(y*s.l)+z]; index != -1 && !air[index] {
+					break
+				}
+			}
+			fn(x, z, y)
+		}
+	}
+}
+
+// Heightmap returns the highest y holding a block other than air for every column of the structure. The
+// heightmap is indexed as heightmap[x][z]. Columns without any such block have a height of -1.
+func (s *structure) Heightmap() [][]int {
+	dims := s.Dimensions()
+	heightmap := make([][]int, dims[0])
+	for x := range heightmap {
+		heightmap[x] = make([]int, dims[2])
+	}
+	s.Columns(func(x, z, y int) {
+		heightmap[x][z] = y
+	})
+	return heightmap
+}
+
+// airEntries returns a slice indicating for every entry in the palette currently used whether it is air.
+func (s *structure) airEntries() []bool {
+	air := make([]bool, len(s.palette.BlockPalette))
+	for i, bl := range s.palette.BlockPalette {
+		air[i] = bl.Name == "minecraft:air"
+	}
+	return air
+}
+
+// FindFirst returns the position of the first block in the structure with the name passed, such as
+// "minecraft:chest". The structure is searched x first, then y, then z. If no such block is found, FindFirst
+// returns false.
+func (s *structure) FindFirst(name string) (cube.Pos, bool) {
+	match := s.entriesNamed(name)
+	for offset, index := range s.blocks {
+		if index != -1 && match[index] {
+			return s.posOf(offset), true
+		}
+	}
+	return cube.Pos{}, false
+}
+
+// FindAll returns the positions of all blocks in the structure with the name passed, such as
+// "minecraft:chest". The positions are ordered x first, then y, then z.
+func (s *structure) FindAll(name string) []cube.Pos {
+	match := s.entriesNamed(name)
+	var positions []cube.Pos
+	for offset, index := range s.blocks {
+		if index != -1 && match[index] {
+			positions = append(positions, s.posOf(offset))
+		}
+	}
+	return positions
+}
+
+// entriesNamed returns a slice indicating for every entry in the palette currently used whether it has the name
+// passed. Entries are matched by the name held in the palette, so that blocks that could not be resolved, such as
+// custom or addon blocks used as markers, are found too. Entries written under an older name are also matched by
+// the name of the block they resolved to.
+func (s *structure) entriesNamed(name string) []bool {
+	s.ensureParsed()
+	match := make([]bool, len(s.palette.BlockPalette))
+	for i, bl := range s.palette.BlockPalette {
+		if bl.Name == name {
+			match[i] = true
+			continue
+		}
+		if i < len(s.parsedPalette) && s.parsedPalette[i].b != nil {
+			n, _ := s.parsedPalette[i].b.EncodeBlock()
+			match[i] = n == name
+		}
+	}
+	return match
+}
+
+// posOf converts an offset in the block indices of the structure back to the position it represents.
+func (s *structure) posOf(offset int) cube.Pos {
+	return cube.Pos{offset / (s.l * s.h), (offset / s.l) % s.h, offset % s.l}
+}
+
+// ContentBounds returns the smallest cuboid that contains all blocks in the structure that are neither air
+// nor a structure void. min is inclusive and max is exclusive, so that the bounds may be passed to Region
+// directly. If the structure holds no such blocks, ok is false. ContentBounds does not modify the structure.
+func (s *structure) ContentBounds() (min, max [3]int, ok bool) {
+	air := s.airEntries()
+	min = s.Dimensions()
+	for offset, index := range s.blocks {
+		if index == -1 || air[index] {
+			continue
+		}
+		pos := s.posOf(offset)
+		for i := 0; i < 3; i++ {
+			if pos[i] < min[i] {
+				min[i] = pos[i]
+			}
+			if pos[i]+1 > max[i] {
+				max[i] = pos[i] + 1
+			}
+		}
+		ok = true
+	}
+	if !ok {
+		return [3]int{}, [3]int{}, false
+	}
+	return min, max, true
+}
+
+// Geometry holds statistics about the shape of a structure, as returned by Geometry.
+type Geometry struct {
+	// Volume is the number of positions in the structure: the product of its dimensions.
+	Volume int
+	// Blocks is the number of positions that hold a block that is neither air nor a structure void.
+	Blocks int
+	// SurfaceArea is the number of block faces of these blocks that are exposed, which is the case if they touch
+	// air, a structure void or the boundary of the structure.
+	SurfaceArea int
+	// Density is the fraction of the Volume taken up by Blocks, between 0 and 1. The Density of an empty
+	// structure is 0.
+	Density float64
+}
+
+// Geometry returns statistics about the shape of the structure, such as the number of blocks it holds and its
+// exposed surface area. These may be used to estimate the cost of building the structure or to reject structures
+// that are too large. Geometry does not modify the structure.
+func (s *structure) Geometry() Geometry {
+	dims := s.Dimensions()
+	g := Geometry{Volume: dims[0] * dims[1] * dims[2]}
+	air := s.airEntries()
+	open := func(index int32) bool {
+		return index == -1 || air[index]
+	}
+	for x := 0; x < dims[0]; x++ {
+		for y := 0; y < dims[1]; y++ {
+			for z := 0; z < dims[2]; z++ {
+				offset := (x * s.l * s.h) + (y * s.l) + z
+				if open(s.blocks[offset]) {
+					continue
+				}
+				g.Blocks++
+				if x == 0 || open(s.blocks[offset-s.l*s.h]) {
+					g.SurfaceArea++
+				}
+				if x == dims[0]-1 || open(s.blocks[offset+s.l*s.h]) {
+					g.SurfaceArea++
+				}
+				if y == 0 || open(s.blocks[offset-s.l]) {
+					g.SurfaceArea++
+				}
+				if y == dims[1]-1 || open(s.blocks[offset+s.l]) {
+					g.SurfaceArea++
+				}
+				if z == 0 || open(s.blocks[offset-1]) {
+					g.SurfaceArea++
+				}
+				if z == dims[2]-1 || open(s.blocks[offset+1]) {
+					g.SurfaceArea++
+				}
+			}
+		}
+	}
+	if g.Volume != 0 {
+		g.Density = float64(g.Blocks) / float64(g.Volume)
+	}
+	return g
+}
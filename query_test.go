@@ -0,0 +1,21 @@
+package structure
+
+import (
+	df "github.com/df-mc/dragonfly/server/block"
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"testing"
+)
+
+func TestFindAllUnresolved(t *testing.T) {
+	s := New([3]int{3, 1, 1})
+	s.Set(0, 0, 0, df.Stone{}, nil)
+	marker := s.ptrForEntry(block{Name: "addon:marker"})
+	s.setIndex(2, marker, nil)
+
+	if got := s.FindAll("addon:marker"); len(got) != 1 || got[0] != (cube.Pos{2, 0, 0}) {
+		t.Fatalf("FindAll(addon:marker) = %v, want [[2 0 0]]", got)
+	}
+	if pos, ok := s.FindFirst("minecraft:stone"); !ok || pos != (cube.Pos{}) {
+		t.Fatalf("FindFirst(minecraft:stone) = %v, %v, want [0 0 0], true", pos, ok)
+	}
+}
@@ -0,0 +1,268 @@
+// Package queue implements a queue of structure builds, which spreads building many structures over time so that
+// building them does not overwhelm a server, for example when resetting many minigame arenas at once.
+package queue
+
+import (
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/df-mc/structure"
+	"sync"
+	"time"
+)
+
+// Status is the status of a Job.
+type Status int
+
+const (
+	// Queued is the status of a Job that is waiting to be built.
+	Queued Status = iota
+	// Building is the status of a Job that is currently being built.
+	Building
+	// Built is the status of a Job that was built completely.
+	Built
+	// Cancelled is the status of a Job that was cancelled before it was built, either using Job.Cancel or by
+	// closing the Queue.
+	Cancelled
+)
+
+// String returns the name of the Status.
+func (s Status) String() string {
+	switch s {
+	case Queued:
+		return "queued"
+	case Building:
+		return "building"
+	case Built:
+		return "built"
+	case Cancelled:
+		return "cancelled"
+	}
+	return "unknown"
+}
+
+// Job is a build of a structure added to a Queue. A Job may be used to follow the progress of the build and to
+// cancel it before it starts.
+type Job struct {
+	w    *world.World
+	pos  cube.Pos
+	s    structure.Structure
+	opts []structure.BuildOption
+	cost int
+
+	mu     sync.Mutex
+	status Status
+	done   chan struct{}
+}
+
+// Status returns the current Status of the Job.
+func (j *Job) Status() Status {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	return j.status
+}
+
+// Cancel cancels the Job if it has not yet started building. Cancel returns true if the Job was cancelled, or
+// false if it was already being built, built or cancelled.
+func (j *Job) Cancel() bool {
+	return j.finish(Queued, Cancelled)
+}
+
+// Done returns a channel that is closed once the Job is built or cancelled.
+func (j *Job) Done() <-chan struct{} {
+	return j.done
+}
+
+// Wait blocks until the Job is built or cancelled and returns its final Status.
+func (j *Job) Wait() Status {
+	<-j.done
+	return j.Status()
+}
+
+// transition changes the Status of the Job from the Status passed to a new Status. It returns false if the Job
+// did not have the Status passed.
+func (j *Job) transition(from, to Status) bool {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	if j.status != from {
+		return false
+	}
+	j.status = to
+	return true
+}
+
+// finish changes the Status of the Job like transition and closes the done channel of the Job if successful.
+func (j *Job) finish(from, to Status) bool {
+	if !j.transition(from, to) {
+		return false
+	}
+	close(j.done)
+	return true
+}
+
+// Queue builds structures added to it one by one in the background. The number of blocks built per tick is
+// limited for all worlds combined, and worlds take turns, so that a world with many queued builds does not
+// delay builds in other worlds until all of its own builds are done. A Queue is safe for concurrent use.
+type Queue struct {
+	blocksPerTick int
+
+	mu sync.Mutex
+	// worlds holds all worlds with queued Jobs in the order in which they take turns. jobs holds the queued Jobs
+	// of every world in the order in which they were added.
+	worlds  []*world.World
+	jobs    map[*world.World][]*Job
+	turn    int
+	stopped bool
+
+	wake   chan struct{}
+	closed chan struct{}
+	once   sync.Once
+}
+
+// New creates a Queue that builds at most blocksPerTick blocks per tick (a twentieth of a second) on average.
+// Structures larger than blocksPerTick are still built at once, after which the Queue waits until the blocks
+// built are within the limit again. The Queue starts building immediately and keeps running until Close is
+// called.
+func New(blocksPerTick int) *Queue {
+	if blocksPerTick < 1 {
+		blocksPerTick = 1
+	}
+	q := &Queue{
+		blocksPerTick: blocksPerTick,
+		jobs:          map[*world.World][]*Job{},
+		wake:          make(chan struct{}, 1),
+		closed:        make(chan struct{}),
+	}
+	go q.run()
+	return q
+}
+
+// Add adds a build of the Structure passed in the world.World passed, at the position passed, to the Queue. The
+// structure is built by calling structure.Build with the BuildOptions passed once it is its turn. The Job
+// returned may be used to follow the progress of the build or to cancel it. Jobs added after the Queue is
+// closed are cancelled immediately.
+func (q *Queue) Add(w *world.World, pos cube.Pos, s structure.Structure, opts ...structure.BuildOption) *Job {
+	dims := s.Dimensions()
+	j := &Job{w: w, pos: pos, s: s, opts: opts, cost: dims[0] * dims[1] * dims[2], done: make(chan struct{})}
+	q.mu.Lock()
+	if q.stopped {
+		q.mu.Unlock()
+		j.finish(Queued, Cancelled)
+		return j
+	}
+	if _, ok := q.jobs[w]; !ok {
+		q.worlds = append(q.worlds, w)
+	}
+	q.jobs[w] = append(q.jobs[w], j)
+	q.mu.Unlock()
+
+	select {
+	case q.wake <- struct{}{}:
+	default:
+	}
+	return j
+}
+
+// Len returns the number of Jobs in the Queue that are waiting to be built.
+func (q *Queue) Len() int {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	n := 0
+	for _, jobs := range q.jobs {
+		for _, j := range jobs {
+			if j.Status() == Queued {
+				n++
+			}
+		}
+	}
+	return n
+}
+
+// Close stops the Queue. Jobs that are waiting to be built are cancelled. A Job that is being built when Close is
+// called is built completely.
+func (q *Queue) Close() {
+	q.once.Do(func() {
+		close(q.closed)
+	})
+}
+
+// run builds the Jobs in the Queue until it is closed.
+func (q *Queue) run() {
+	t := time.NewTicker(time.Second / 20)
+	defer t.Stop()
+	defer q.cancelAll()
+
+	// budget is the number of blocks that may still be built. It may become negative after building a large
+	// structure, in which case no structures are built until it is positive again.
+	budget := q.blocksPerTick
+	for {
+		for budget > 0 {
+			j, ok := q.next()
+			if !ok {
+				break
+			}
+			if !j.transition(Queued, Building) {
+				// The Job was cancelled while queued.
+				continue
+			}
+			structure.Build(j.w, j.pos, j.s, j.opts...)
+			j.finish(Building, Built)
+			budget -= j.cost
+		}
+
+		select {
+		case <-q.closed:
+			return
+		case <-t.C:
+			if budget += q.blocksPerTick; budget > q.blocksPerTick {
+				// The budget is not accumulated while the Queue is idle, so that a burst of Jobs after a quiet
+				// period is still spread over time.
+				budget = q.blocksPerTick
+			}
+		case <-q.wake:
+		}
+	}
+}
+
+// next removes the next Job from the Queue and returns it. Worlds take turns: the Job returned is the oldest Job
+// of the world after the world of the previous Job returned. If no Jobs are queued or the Queue is closed, false
+// is returned.
+func (q *Queue) next() (*Job, bool) {
+	select {
+	case <-q.closed:
+		return nil, false
+	default:
+	}
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	if len(q.worlds) == 0 {
+		return nil, false
+	}
+	if q.turn >= len(q.worlds) {
+		q.turn = 0
+	}
+	w := q.worlds[q.turn]
+	jobs := q.jobs[w]
+	j := jobs[0]
+	jobs[0] = nil
+	if jobs = jobs[1:]; len(jobs) == 0 {
+		// The world has no more Jobs, so it leaves the rotation. The next world moves into its turn.
+		delete(q.jobs, w)
+		q.worlds = append(q.worlds[:q.turn], q.worlds[q.turn+1:]...)
+	} else {
+		q.jobs[w] = jobs
+		q.turn++
+	}
+	return j, true
+}
+
+// cancelAll cancels all Jobs that are still queued.
+func (q *Queue) cancelAll() {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	for _, jobs := range q.jobs {
+		for _, j := range jobs {
+			j.finish(Queued, Cancelled)
+		}
+	}
+	q.worlds, q.jobs, q.stopped = nil, map[*world.World][]*Job{}, true
+}
@@ -0,0 +1,36 @@
+package structure
+
+import (
+	"github.com/df-mc/dragonfly/server/world"
+)
+
+// BlockRegistry resolves the entries in the palette of a Structure to blocks. By default, a Structure resolves
+// blocks using the blocks registered in Dragonfly's world package. A custom BlockRegistry may be set using
+// Structure.UseRegistry, for example to use the package in tools that do not register Dragonfly's blocks.
+// Large palettes are resolved across multiple goroutines, so a BlockRegistry must be safe for concurrent use.
+type BlockRegistry interface {
+	// BlockByName returns the block with the name and properties passed. If no such block exists, BlockByName
+	// returns false.
+	BlockByName(name string, properties map[string]interface{}) (world.Block, bool)
+}
+
+// worldRegistry is the BlockRegistry used by default. It resolves blocks using world.BlockByName.
+type worldRegistry struct{}
+
+// BlockByName ...
+func (worldRegistry) BlockByName(name string, properties map[string]interface{}) (world.Block, bool) {
+	return world.BlockByName(name, properties)
+}
+
+// UseRegistry changes the BlockRegistry used to resolve the entries in the palette of the Structure to blocks.
+// The palette currently in use is resolved again using the BlockRegistry passed. Passing a nil BlockRegistry
+// resets the Structure to use Dragonfly's registered blocks.
+func (s Structure) UseRegistry(r BlockRegistry) {
+	if r == nil {
+		r = worldRegistry{}
+	}
+	s.registry = r
+	// The palette is parsed again lazily, once it is first needed.
+	s.parsedPalette = nil
+	s.prepare()
+}
@@ -0,0 +1,89 @@
+package render
+
+import (
+	"github.com/df-mc/structure"
+	"image"
+	"image/color"
+)
+
+// Isometric renders an isometric image of the Structure passed, viewed from above its south-east corner. Every
+// block is drawn with its top, south and east faces visible, each shaded differently, so that the image conveys
+// the height and shape of the Structure. The scale passed is the width in pixels of half a block and is
+// rounded up to an even number of at least 2. Positions without any blocks are transparent.
+func Isometric(s structure.Structure, scale int, opts ...Option) *image.RGBA {
+	t := scale + scale%2
+	if t < 2 {
+		t = 2
+	}
+	h := t / 2
+
+	dims := s.Dimensions()
+	img := image.NewRGBA(image.Rect(0, 0, (dims[0]+dims[2])*t, (dims[0]+dims[2])*h+dims[1]*t))
+	colours := paletteColours(s, newConfig(opts).colours)
+	coloured := func(x, y, z int) bool {
+		if x >= dims[0] || y >= dims[1] || z >= dims[2] {
+			return false
+		}
+		_, ok := colourAt(s, colours, x, y, z)
+		return ok
+	}
+
+	// Blocks are drawn from back to front, so that blocks closer to the viewer are drawn over those further
+	// away. Blocks with the same sum of coordinates never overlap.
+	for d := 0; d <= dims[0]+dims[1]+dims[2]-3; d++ {
+		for x := 0; x < dims[0] && x <= d; x++ {
+			for y := 0; y < dims[1] && x+y <= d; y++ {
+				z := d - x - y
+				if z >= dims[2] {
+					continue
+				}
+				c, ok := colourAt(s, colours, x, y, z)
+				if !ok {
+					continue
+				}
+				cx, cy := (x-z)*t+dims[2]*t, (x+z)*h+(dims[1]-1-y)*t
+				if !coloured(x, y+1, z) {
+					drawTop(img, cx, cy, t, h, c)
+				}
+				if !coloured(x, y, z+1) {
+					drawSide(img, cx-t, cy+h, t, h, shade(c, 0.8), 1)
+				}
+				if !coloured(x+1, y, z) {
+					drawSide(img, cx, cy+2*h, t, h, shade(c, 0.6), -1)
+				}
+			}
+		}
+	}
+	return img
+}
+
+// drawTop draws the top face of a block, a diamond with its top corner at cx and cy, a width of 2t and a height
+// of 2h.
+func drawTop(img *image.RGBA, cx, cy, t, h int, c color.RGBA) {
+	for dx := -t; dx < t; dx++ {
+		// The half-height of the diamond at this column, which shrinks towards its left and right corners.
+		w := dx
+		if w < 0 {
+			w = -w - 1
+		}
+		half := (t - w) * h / t
+		for dy := h - half; dy < h+half; dy++ {
+			img.SetRGBA(cx+dx, cy+dy, c)
+		}
+	}
+}
+
+// drawSide draws a side face of a block, a parallelogram with a width of t and a height of t, of which the top
+// left corner is at x and y. The top edge of the parallelogram goes down to the right if slope is 1 and up to
+// the right if slope is -1.
+func drawSide(img *image.RGBA, x, y, t, h int, c color.RGBA, slope int) {
+	for dx := 0; dx < t; dx++ {
+		top := y + slope*dx*h/t
+		if slope == -1 {
+			top = y - (dx+1)*h/t
+		}
+		for dy := 0; dy < t; dy++ {
+			img.SetRGBA(x+dx, top+dy, c)
+		}
+	}
+}
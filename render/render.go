@@ -0,0 +1,141 @@
+// Package render renders preview images of structure.Structures, such as thumbnails for structure libraries
+// and web panels, without requiring a Minecraft client. The images returned may be encoded to PNG using the
+// image/png package:
+//
+//	img := render.TopDown(s, 4)
+//	err := png.Encode(w, img)
+package render
+
+import (
+	"github.com/df-mc/structure"
+	"github.com/df-mc/structure/blockcolour"
+	"image"
+	"image/color"
+)
+
+// Option is an option that changes the way an image is rendered.
+type Option func(conf *config)
+
+// config holds the configuration of a single render, as changed by Options.
+type config struct {
+	colours *blockcolour.Table
+}
+
+// WithColours makes the image be rendered using the colours in the blockcolour.Table passed, for example to match
+// the textures of a resource pack. By default, blockcolour.Default is used.
+func WithColours(t *blockcolour.Table) Option {
+	return func(conf *config) {
+		conf.colours = t
+	}
+}
+
+// newConfig returns the config resulting from applying the Options passed.
+func newConfig(opts []Option) *config {
+	conf := &config{}
+	for _, opt := range opts {
+		opt(conf)
+	}
+	if conf.colours == nil {
+		conf.colours = blockcolour.Default
+	}
+	return conf
+}
+
+// unknownColour is the colour with which blocks without a known colour are drawn.
+var unknownColour = color.RGBA{R: 0xa0, G: 0x8c, B: 0xa0, A: 0xff}
+
+// TopDown renders a top-down image of the Structure passed, looking down the Y axis. Every column of the
+// Structure is drawn as a square of scale by scale pixels, coloured by the highest block in the column. The x
+// axis of the Structure runs along the width of the image and the z axis along its height, so that north is at
+// the top of the image. Columns without any blocks are transparent. Like Minecraft maps, columns that are
+// higher than the column north of them are drawn lighter and columns that are lower are drawn darker.
+func TopDown(s structure.Structure, scale int, opts ...Option) *image.RGBA {
+	if scale < 1 {
+		scale = 1
+	}
+	dims := s.Dimensions()
+	img := image.NewRGBA(image.Rect(0, 0, dims[0]*scale, dims[2]*scale))
+	colours := paletteColours(s, newConfig(opts).colours)
+
+	heights := make([]int, dims[0])
+	for z := 0; z < dims[2]; z++ {
+		for x := 0; x < dims[0]; x++ {
+			c, y, ok := topColour(s, colours, x, z)
+			if ok {
+				if z > 0 && heights[x] != -1 {
+					switch {
+					case y > heights[x]:
+						c = shade(c, 1.1)
+					case y < heights[x]:
+						c = shade(c, 0.85)
+					}
+				}
+				fill(img, x*scale, z*scale, scale, c)
+			}
+			heights[x] = y
+		}
+	}
+	return img
+}
+
+// topColour returns the colour of the highest coloured block or liquid in the column at the x and z passed, and
+// the y at which it is found. If the column holds no such block, false is returned and y is -1.
+func topColour(s structure.Structure, colours []color.RGBA, x, z int) (color.RGBA, int, bool) {
+	for y := s.Dimensions()[1] - 1; y >= 0; y-- {
+		if c, ok := colourAt(s, colours, x, y, z); ok {
+			return c, y, true
+		}
+	}
+	return color.RGBA{}, -1, false
+}
+
+// colourAt returns the colour of the block at the x, y and z passed. If the block has no colour, the colour of
+// the liquid at the position is returned. If neither has a colour, false is returned.
+func colourAt(s structure.Structure, colours []color.RGBA, x, y, z int) (color.RGBA, bool) {
+	for layer := 0; layer <= 1; layer++ {
+		if index := s.IndexAt(x, y, z, layer); index != -1 && colours[index].A != 0 {
+			return colours[index], true
+		}
+	}
+	return color.RGBA{}, false
+}
+
+// paletteColours returns the colour of every entry in the palette of the Structure passed, as found in the
+// blockcolour.Table passed. Air and other blocks that should not be drawn have a fully transparent colour.
+func paletteColours(s structure.Structure, t *blockcolour.Table) []color.RGBA {
+	colours := make([]color.RGBA, s.PaletteSize())
+	for i := range colours {
+		name, states, _ := s.PaletteEntry(i)
+		switch name {
+		case "minecraft:air", "minecraft:structure_void", "minecraft:barrier", "minecraft:light_block":
+			continue
+		}
+		c, ok := t.Of(name, states)
+		if !ok {
+			c = unknownColour
+		}
+		colours[i] = c
+	}
+	return colours
+}
+
+// shade multiplies the red, green and blue components of the colour passed by the factor passed.
+func shade(c color.RGBA, factor float64) color.RGBA {
+	f := func(v uint8) uint8 {
+		if r := float64(v) * factor; r < 255 {
+			return uint8(r)
+		}
+		return 255
+	}
+	return color.RGBA{R: f(c.R), G: f(c.G), B: f(c.B), A: c.A}
+}
+
+// fill fills a square of size by size pixels with its top left corner at the x and y passed with the colour
+// passed.
+func fill(img *image.RGBA, x, y, size int, c color.RGBA) {
+	for dx := 0; dx < size; dx++ {
+		for dy := 0; dy < size; dy++ {
+			img.SetRGBA(x+dx, y+dy, c)
+		}
+	}
+}
@@ -0,0 +1,296 @@
+package structure
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/df-mc/dragonfly/server/world/chunk"
+	"github.com/df-mc/goleveldb/leveldb"
+	"github.com/df-mc/goleveldb/leveldb/opt"
+	"github.com/sandertv/gophertunnel/minecraft/nbt"
+	"path/filepath"
+)
+
+// Keys under which the data of a chunk is stored in the database of a world. These keys are prefixed by the
+// position of the chunk, and keySubChunkData additionally by the index of the sub chunk.
+const (
+	keySubChunkData  = '/'
+	keyVersion       = ','
+	keyVersionOld    = 'v'
+	keyBlockEntities = '1'
+	keyFinalisation  = '6'
+	key3DData        = '+'
+)
+
+// chunkVersion is the version written for chunks saved by BuildIntoSave, which is the version Dragonfly writes.
+const chunkVersion = 40
+
+// CaptureFromSave creates a new Structure holding the blocks and liquids in the cuboid between the two corners
+// passed, like Capture, but reads them directly from the world saved in the directory passed instead of from a
+// running world.World. The cuboid is read from the world.Dimension passed.
+// Positions in chunks that were never generated, and positions outside the height range of the dimension, are
+// captured as air. Blocks are resolved using the blocks registered in the world package, so the packages that
+// register them, such as the block package of Dragonfly, must be imported.
+// CaptureFromSave opens the database of the world directly, so the world must not be in use, for example by a
+// running Dragonfly server, while calling CaptureFromSave. The world is not modified.
+func CaptureFromSave(dir string, dim world.Dimension, a, b cube.Pos, opts ...CaptureOption) (Structure, error) {
+	conf := &captureConfig{liquidPolicy: InherentWater}
+	for _, opt := range opts {
+		opt(conf)
+	}
+	db, err := leveldb.OpenFile(filepath.Join(dir, "db"), &opt.Options{ReadOnly: true, ErrorIfMissing: true})
+	if err != nil {
+		return Structure{}, fmt.Errorf("open world database: %w", err)
+	}
+	defer db.Close()
+
+	min, max := cornersOf(a, b)
+	r := dim.Range()
+	chunks := make(map[world.ChunkPos]*savedChunk)
+	for x := min[0] >> 4; x <= max[0]>>4; x++ {
+		for z := min[2] >> 4; z <= max[2]>>4; z++ {
+			pos := world.ChunkPos{int32(x), int32(z)}
+			c, err := loadChunk(db, pos, dim)
+			if err != nil {
+				return Structure{}, fmt.Errorf("load chunk %v: %w", pos, err)
+			}
+			chunks[pos] = c
+		}
+	}
+
+	air := airBlock()
+	s := NewFromFunc([3]int{max[0] - min[0] + 1, max[1] - min[1] + 1, max[2] - min[2] + 1}, func(x, y, z int) (world.Block, world.Liquid) {
+		pos := min.Add(cube.Pos{x, y, z})
+		c := chunks[world.ChunkPos{int32(pos[0] >> 4), int32(pos[2] >> 4)}]
+		if c == nil || pos.OutOfBounds(r) {
+			return air, nil
+		}
+		b := c.block(pos)
+		liq, _ := c.layer(pos, 1).(world.Liquid)
+		return b, conf.liquidFor(b, liq)
+	})
+	s.Origin = []int32{int32(min[0]), int32(min[1]), int32(min[2])}
+	return s, nil
+}
+
+// BuildIntoSave builds the Structure passed into the world saved in the directory passed, with its origin at the
+// position passed in the world.Dimension passed, without running a world.World. Like Build, positions in the
+// Structure that do not hold a block are left untouched, and liquids present at positions that do hold a block
+// are replaced. Parts of the Structure outside the height range of the dimension are discarded, and chunks that
+// were never generated are created, so that they hold only the blocks of the Structure. Entities held by the
+// Structure are not built.
+// All chunks affected are written at once, so that the world is left unchanged if writing fails. BuildIntoSave
+// opens the database of the world directly, so the world must not be in use, for example by a running Dragonfly
+// server, while calling BuildIntoSave.
+func BuildIntoSave(dir string, dim world.Dimension, pos cube.Pos, s Structure) error {
+	db, err := leveldb.OpenFile(filepath.Join(dir, "db"), &opt.Options{
+		Compression:    opt.FlateCompression,
+		BlockSize:      16 * opt.KiB,
+		ErrorIfMissing: true,
+	})
+	if err != nil {
+		return fmt.Errorf("open world database: %w", err)
+	}
+	defer db.Close()
+
+	dims, r := s.Dimensions(), dim.Range()
+	if dims[0] == 0 || dims[1] == 0 || dims[2] == 0 {
+		return nil
+	}
+	end := pos.Add(cube.Pos{dims[0] - 1, dims[1] - 1, dims[2] - 1})
+	airRID := world.BlockRuntimeID(airBlock())
+	batch := new(leveldb.Batch)
+	for cx := pos[0] >> 4; cx <= end[0]>>4; cx++ {
+		for cz := pos[2] >> 4; cz <= end[2]>>4; cz++ {
+			chunkPos := world.ChunkPos{int32(cx), int32(cz)}
+			c, err := loadChunk(db, chunkPos, dim)
+			if err != nil {
+				return fmt.Errorf("load chunk %v: %w", chunkPos, err)
+			}
+			if c == nil {
+				c = &savedChunk{c: chunk.New(airRID, r), blockEntities: map[cube.Pos]map[string]interface{}{}}
+			}
+			// The part of the Structure in this chunk spans from the highest of the two minimums to the lowest of
+			// the two maximums on both horizontal axes.
+			minX, maxX, minZ, maxZ := cx<<4, cx<<4+15, cz<<4, cz<<4+15
+			if pos[0] > minX {
+				minX = pos[0]
+			}
+			if end[0] < maxX {
+				maxX = end[0]
+			}
+			if pos[2] > minZ {
+				minZ = pos[2]
+			}
+			if end[2] < maxZ {
+				maxZ = end[2]
+			}
+			for x := minX; x <= maxX; x++ {
+				for z := minZ; z <= maxZ; z++ {
+					for y := pos[1]; y <= end[1]; y++ {
+						worldPos := cube.Pos{x, y, z}
+						if worldPos.OutOfBounds(r) {
+							continue
+						}
+						b, liq := s.At(x-pos[0], y-pos[1], z-pos[2], nil)
+						if b == nil {
+							continue
+						}
+						c.c.SetBlock(uint8(x&15), int16(y), uint8(z&15), 0, world.BlockRuntimeID(b))
+						liqRID := airRID
+						if liq != nil {
+							liqRID = world.BlockRuntimeID(liq)
+						}
+						c.c.SetBlock(uint8(x&15), int16(y), uint8(z&15), 1, liqRID)
+
+						delete(c.blockEntities, worldPos)
+						if nbter, ok := b.(world.NBTer); ok {
+							data := nbter.EncodeNBT()
+							data["x"], data["y"], data["z"] = int32(x), int32(y), int32(z)
+							c.blockEntities[worldPos] = data
+						}
+					}
+				}
+			}
+			if err := c.save(batch, chunkPos, dim); err != nil {
+				return fmt.Errorf("save chunk %v: %w", chunkPos, err)
+			}
+		}
+	}
+	if err := db.Write(batch, nil); err != nil {
+		return fmt.Errorf("write chunks: %w", err)
+	}
+	return nil
+}
+
+// savedChunk is a chunk read from the database of a world, along with the block entity data of its blocks.
+type savedChunk struct {
+	c *chunk.Chunk
+	// blockEntities holds the block entity data of the chunk, indexed by the world position of the block.
+	blockEntities map[cube.Pos]map[string]interface{}
+}
+
+// block returns the block at the world position passed in the chunk, with its block entity data decoded.
+func (c *savedChunk) block(pos cube.Pos) world.Block {
+	b := c.layer(pos, 0)
+	if nbter, ok := b.(world.NBTer); ok {
+		if data, ok := c.blockEntities[pos]; ok {
+			b = nbter.DecodeNBT(data).(world.Block)
+		}
+	}
+	return b
+}
+
+// layer returns the block at the world position passed in a layer of the chunk, without block entity data. Blocks
+// that are not registered are returned as air.
+func (c *savedChunk) layer(pos cube.Pos, layer uint8) world.Block {
+	b, ok := world.BlockByRuntimeID(c.c.Block(uint8(pos[0]&15), int16(pos[1]), uint8(pos[2]&15), layer))
+	if !ok {
+		return airBlock()
+	}
+	return b
+}
+
+// loadChunk reads the chunk at the position passed in the world.Dimension passed from the database of a world. If
+// the chunk does not exist, nil is returned.
+func loadChunk(db *leveldb.DB, pos world.ChunkPos, dim world.Dimension) (*savedChunk, error) {
+	key := chunkKey(pos, dim)
+	if _, err := db.Get(append(key, keyVersion), nil); err == leveldb.ErrNotFound {
+		// Older worlds store the version of the chunk under a different key.
+		if _, err = db.Get(append(key, keyVersionOld), nil); err == leveldb.ErrNotFound {
+			return nil, nil
+		} else if err != nil {
+			return nil, fmt.Errorf("read version: %w", err)
+		}
+	} else if err != nil {
+		return nil, fmt.Errorf("read version: %w", err)
+	}
+
+	r := dim.Range()
+	data := chunk.SerialisedData{SubChunks: make([][]byte, (r.Height()>>4)+1)}
+	biomes, err := db.Get(append(key, key3DData), nil)
+	if err != nil && err != leveldb.ErrNotFound {
+		return nil, fmt.Errorf("read biomes: %w", err)
+	}
+	if len(biomes) > 512 {
+		// The biomes are preceded by a height map, which we do not need.
+		data.Biomes = biomes[512:]
+	}
+	for i := range data.SubChunks {
+		sub, err := db.Get(append(key, keySubChunkData, uint8(i+(r[0]>>4))), nil)
+		if err != nil && err != leveldb.ErrNotFound {
+			return nil, fmt.Errorf("read sub chunk %v: %w", i, err)
+		}
+		data.SubChunks[i] = sub
+	}
+	c, err := chunk.DiskDecode(data, r)
+	if err != nil {
+		return nil, fmt.Errorf("decode chunk: %w", err)
+	}
+
+	blockEntities := make(map[cube.Pos]map[string]interface{})
+	raw, err := db.Get(append(key, keyBlockEntities), nil)
+	if err != nil && err != leveldb.ErrNotFound {
+		return nil, fmt.Errorf("read block entities: %w", err)
+	}
+	// Block entities are stored as NBT compounds appended to each other.
+	buf := bytes.NewBuffer(raw)
+	dec := nbt.NewDecoderWithEncoding(buf, nbt.LittleEndian)
+	for buf.Len() != 0 {
+		var m map[string]interface{}
+		if err := dec.Decode(&m); err != nil {
+			return nil, fmt.Errorf("decode block entities: %w", err)
+		}
+		x, _ := m["x"].(int32)
+		y, _ := m["y"].(int32)
+		z, _ := m["z"].(int32)
+		blockEntities[cube.Pos{int(x), int(y), int(z)}] = m
+	}
+	return &savedChunk{c: c, blockEntities: blockEntities}, nil
+}
+
+// save writes the chunk at the position passed in the world.Dimension passed, along with its block entity data, to
+// the leveldb.Batch passed.
+func (c *savedChunk) save(batch *leveldb.Batch, pos world.ChunkPos, dim world.Dimension) error {
+	key := chunkKey(pos, dim)
+	c.c.Compact()
+	data := chunk.Encode(c.c, chunk.DiskEncoding)
+	batch.Put(append(key, keyVersion), []byte{chunkVersion})
+	// The height map is written as 512 empty bytes: it is recalculated when the chunk is loaded.
+	batch.Put(append(key, key3DData), append(make([]byte, 512), data.Biomes...))
+	finalisation := make([]byte, 4)
+	binary.LittleEndian.PutUint32(finalisation, 2)
+	batch.Put(append(key, keyFinalisation), finalisation)
+	for i, sub := range data.SubChunks {
+		batch.Put(append(key, keySubChunkData, byte(i+(c.c.Range()[0]>>4))), sub)
+	}
+
+	if len(c.blockEntities) == 0 {
+		batch.Delete(append(key, keyBlockEntities))
+		return nil
+	}
+	buf := bytes.NewBuffer(nil)
+	enc := nbt.NewEncoderWithEncoding(buf, nbt.LittleEndian)
+	for _, m := range c.blockEntities {
+		if err := enc.Encode(m); err != nil {
+			return fmt.Errorf("encode block entities: %w", err)
+		}
+	}
+	batch.Put(append(key, keyBlockEntities), buf.Bytes())
+	return nil
+}
+
+// chunkKey returns the prefix of the keys under which the data of the chunk at the position passed in the
+// world.Dimension passed is stored. Chunks in the overworld are not prefixed with their dimension.
+func chunkKey(pos world.ChunkPos, dim world.Dimension) []byte {
+	key := make([]byte, 12)
+	binary.LittleEndian.PutUint32(key, uint32(pos[0]))
+	binary.LittleEndian.PutUint32(key[4:], uint32(pos[1]))
+	if id := dim.EncodeDimension(); id != 0 {
+		binary.LittleEndian.PutUint32(key[8:], uint32(id))
+		return key
+	}
+	return key[:8]
+}
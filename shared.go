@@ -0,0 +1,95 @@
+package structure
+
+import (
+	"fmt"
+	"os"
+	"sync"
+	"time"
+)
+
+// sharedCache holds the structures read using ReadFileShared. files maps the path of every file read to the hash of
+// its structure, and structures maps these hashes to the structures themselves, so that files holding identical
+// structures share a single FrozenStructure.
+var sharedCache = struct {
+	mu         sync.Mutex
+	files      map[string]sharedFile
+	structures map[[32]byte]*sharedStructure
+}{files: map[string]sharedFile{}, structures: map[[32]byte]*sharedStructure{}}
+
+// sharedFile is a file read using ReadFileShared. Its modification time and size are used to notice that the file
+// changed since it was read.
+type sharedFile struct {
+	hash    [32]byte
+	modTime time.Time
+	size    int64
+}
+
+// sharedStructure is a structure held by the shared cache, along with the number of files that hold it.
+type sharedStructure struct {
+	s     FrozenStructure
+	files int
+}
+
+// ReadFileShared reads a Structure from the file at the path passed, like ReadFile, and returns it frozen. The
+// FrozenStructure returned is kept in a cache shared by the whole process: reading a file again returns the same
+// FrozenStructure without reading the file, unless the file was changed since. Files at different paths that hold
+// identical structures, as decided by Structure.Hash, share a single FrozenStructure too, so that servers in
+// which many arenas use copies of the same template files keep only one of them in memory.
+// Structures stay in the cache until ReleaseShared is called for every file that holds them.
+func ReadFileShared(file string) (FrozenStructure, error) {
+	info, err := os.Stat(file)
+	if err != nil {
+		return FrozenStructure{}, fmt.Errorf("stat file: %w", err)
+	}
+	sharedCache.mu.Lock()
+	if f, ok := sharedCache.files[file]; ok && f.modTime.Equal(info.ModTime()) && f.size == info.Size() {
+		s := sharedCache.structures[f.hash].s
+		sharedCache.mu.Unlock()
+		return s, nil
+	}
+	sharedCache.mu.Unlock()
+
+	// The structure is read without holding the lock, so that reading a large structure does not block reading
+	// other structures.
+	s, err := ReadFile(file)
+	if err != nil {
+		return FrozenStructure{}, err
+	}
+	hash := s.Hash()
+
+	sharedCache.mu.Lock()
+	defer sharedCache.mu.Unlock()
+	releaseShared(file)
+	shared, ok := sharedCache.structures[hash]
+	if !ok {
+		shared = &sharedStructure{s: s.Frozen()}
+		sharedCache.structures[hash] = shared
+	}
+	shared.files++
+	sharedCache.files[file] = sharedFile{hash: hash, modTime: info.ModTime(), size: info.Size()}
+	return shared.s, nil
+}
+
+// ReleaseShared removes the file at the path passed from the cache used by ReadFileShared. Once no file read
+// using ReadFileShared holds a structure anymore, the structure is removed from the cache, so that it may be
+// garbage collected once it is no longer used. Releasing a file that was not read using ReadFileShared has no
+// effect.
+func ReleaseShared(file string) {
+	sharedCache.mu.Lock()
+	defer sharedCache.mu.Unlock()
+	releaseShared(file)
+}
+
+// releaseShared removes the file passed from the shared cache. It must be called while holding the lock of the
+// shared cache.
+func releaseShared(file string) {
+	f, ok := sharedCache.files[file]
+	if !ok {
+		return
+	}
+	delete(sharedCache.files, file)
+	shared := sharedCache.structures[f.hash]
+	if shared.files--; shared.files == 0 {
+		delete(sharedCache.structures, f.hash)
+	}
+}
@@ -0,0 +1,45 @@
+package structure
+
+import (
+	df "github.com/df-mc/dragonfly/server/block"
+	"path/filepath"
+	"sync"
+	"testing"
+)
+
+func TestReadFileSharedConcurrentThaw(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "arena.mcstructure")
+	s := New([3]int{4, 4, 4})
+	s.Set(1, 1, 1, df.Stone{}, nil)
+	if err := WriteFile(file, s); err != nil {
+		t.Fatalf("write structure: %v", err)
+	}
+	defer ReleaseShared(file)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			f, err := ReadFileShared(file)
+			if err != nil {
+				t.Errorf("read shared structure: %v", err)
+				return
+			}
+			thawed := f.Thaw()
+			thawed.Set(i%4, 0, 0, df.Planks{}, nil)
+			if got, _ := thawed.At(1, 1, 1, nil); !sameBlock(got, df.Stone{}) {
+				t.Errorf("block at [1 1 1] = %v, want stone", got)
+			}
+		}(i)
+	}
+	wg.Wait()
+
+	f, err := ReadFileShared(file)
+	if err != nil {
+		t.Fatalf("read shared structure: %v", err)
+	}
+	if got, _ := f.At(0, 0, 0, nil); !sameBlock(got, air()) {
+		t.Fatalf("block at [0 0 0] = %v after modifying thawed copies, want air", got)
+	}
+}
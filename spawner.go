@@ -0,0 +1,54 @@
+package structure
+
+import "github.com/df-mc/dragonfly/server/block/cube"
+
+// Spawner is a mob spawner found in a Structure using Spawners.
+type Spawner struct {
+	// Position is the position of the spawner relative to the origin of the Structure.
+	Position cube.Pos
+	// EntityType is the identifier of the type of entity spawned, such as 'minecraft:zombie'. It is empty if the
+	// spawner does not spawn any entity.
+	EntityType string
+	// Delay is the number of ticks until the spawner next spawns entities.
+	Delay int16
+	// MinSpawnDelay and MaxSpawnDelay are the bounds of the random number of ticks the spawner waits between
+	// spawning entities.
+	MinSpawnDelay, MaxSpawnDelay int16
+	// SpawnCount is the maximum number of entities spawned at once.
+	SpawnCount int16
+	// MaxNearbyEntities is the maximum number of entities of the type spawned that may be near the spawner for it
+	// to spawn more.
+	MaxNearbyEntities int16
+	// RequiredPlayerRange is the distance within which a player must be for the spawner to be active.
+	RequiredPlayerRange int16
+	// SpawnRange is the distance from the spawner within which entities are spawned.
+	SpawnRange int16
+	// Data is the full block entity data of the spawner, which may be nil. It must not be modified.
+	Data map[string]interface{}
+}
+
+// Spawners returns all mob spawners in the structure, ordered by their position, x first, then y, then z. The
+// block entity data of spawners is kept as is when a structure is read, apart from entity types stored by older
+// versions of Minecraft, which are upgraded, so spawners keep working when the structure is built.
+func (s *structure) Spawners() []Spawner {
+	var spawners []Spawner
+	for offset, index := range s.blocks {
+		if index == -1 || s.palette.BlockPalette[index].Name != "minecraft:mob_spawner" {
+			continue
+		}
+		sp := Spawner{Position: s.posOf(offset)}
+		if data, ok := s.positionData(offset); ok {
+			sp.Data = data.BlockEntityData
+			sp.EntityType, _ = sp.Data["EntityIdentifier"].(string)
+			sp.Delay, _ = sp.Data["Delay"].(int16)
+			sp.MinSpawnDelay, _ = sp.Data["MinSpawnDelay"].(int16)
+			sp.MaxSpawnDelay, _ = sp.Data["MaxSpawnDelay"].(int16)
+			sp.SpawnCount, _ = sp.Data["SpawnCount"].(int16)
+			sp.MaxNearbyEntities, _ = sp.Data["MaxNearbyEntities"].(int16)
+			sp.RequiredPlayerRange, _ = sp.Data["RequiredPlayerRange"].(int16)
+			sp.SpawnRange, _ = sp.Data["SpawnRange"].(int16)
+		}
+		spawners = append(spawners, sp)
+	}
+	return spawners
+}
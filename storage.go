@@ -0,0 +1,197 @@
+package structure
+
+import (
+	"archive/zip"
+	"bytes"
+	"fmt"
+	"github.com/df-mc/goleveldb/leveldb"
+	"github.com/df-mc/goleveldb/leveldb/opt"
+	"github.com/df-mc/goleveldb/leveldb/util"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// structureTemplatePrefix is the prefix of the keys under which structures are stored in the database of a
+// world.
+const structureTemplatePrefix = "structuretemplate_"
+
+// WriteToWorld writes a Structure to the structure storage of the world saved in the directory passed, under the
+// name passed. Structures in this storage may be loaded in-game using the /structure load command or using a
+// structure block. If the name has no namespace, such as in 'house', the 'mystructure' namespace is used, which
+// is the namespace Minecraft uses by default.
+// WriteToWorld opens the database of the world directly, so the world must not be in use, for example by a
+// running Dragonfly server, while calling WriteToWorld.
+func WriteToWorld(dir, name string, s Structure) error {
+	db, err := leveldb.OpenFile(filepath.Join(dir, "db"), &opt.Options{
+		Compression: opt.FlateCompression,
+		BlockSize:   16 * opt.KiB,
+	})
+	if err != nil {
+		return fmt.Errorf("open world database: %w", err)
+	}
+	defer db.Close()
+	return writeToDB(db, name, s)
+}
+
+// writeToDB writes a Structure to the structure storage held in the leveldb.DB passed, under the name passed.
+func writeToDB(db *leveldb.DB, name string, s Structure) error {
+	buf := bytes.NewBuffer(nil)
+	if err := Write(buf, s); err != nil {
+		return err
+	}
+	if err := db.Put([]byte(structureTemplateKey(name)), buf.Bytes(), nil); err != nil {
+		return fmt.Errorf("write structure %v: %w", name, err)
+	}
+	return nil
+}
+
+// structureTemplateKey returns the database key under which a structure with the name passed is stored.
+func structureTemplateKey(name string) string {
+	if !strings.Contains(name, ":") {
+		name = "mystructure:" + name
+	}
+	return structureTemplatePrefix + name
+}
+
+// WorldStorage is the structure storage of a world, which holds the structures saved in-game using the /structure
+// save command or using structure blocks. A WorldStorage is opened read-only: structures may be listed and loaded,
+// but not changed.
+type WorldStorage struct {
+	db *leveldb.DB
+	// tmp is the temporary directory a world archive was extracted to, or an empty string if the world was not
+	// opened from an archive.
+	tmp string
+}
+
+// OpenWorldStorage opens the structure storage of the world saved in the directory passed. The WorldStorage
+// returned must be closed using WorldStorage.Close once it is no longer used. The world must not be in use, for
+// example by a running Dragonfly server, while it is open.
+func OpenWorldStorage(dir string) (*WorldStorage, error) {
+	db, err := leveldb.OpenFile(filepath.Join(dir, "db"), &opt.Options{ReadOnly: true, ErrorIfMissing: true})
+	if err != nil {
+		return nil, fmt.Errorf("open world database: %w", err)
+	}
+	return &WorldStorage{db: db}, nil
+}
+
+// OpenWorldArchive opens the structure storage of the world in the world archive at the path passed, such as an
+// .mcworld or .mctemplate file exported by Minecraft. The database of the world is extracted to a temporary
+// directory, which is removed again when the WorldStorage returned is closed using WorldStorage.Close.
+func OpenWorldArchive(file string) (*WorldStorage, error) {
+	zr, err := zip.OpenReader(file)
+	if err != nil {
+		return nil, fmt.Errorf("open zip: %w", err)
+	}
+	defer zr.Close()
+
+	// Archives usually hold the world at their root, but some tools export it in a directory. We find the world
+	// by its level.dat file.
+	root := ""
+	found := false
+	for _, f := range zr.File {
+		if path.Base(f.Name) == "level.dat" && (!found || len(f.Name) < len(root)) {
+			root, found = strings.TrimSuffix(f.Name, "level.dat"), true
+		}
+	}
+	if !found {
+		return nil, fmt.Errorf("archive holds no world: no level.dat found")
+	}
+
+	tmp, err := os.MkdirTemp("", "structure-world-")
+	if err != nil {
+		return nil, fmt.Errorf("create temporary directory: %w", err)
+	}
+	if err := extractDB(zr.File, root+"db/", filepath.Join(tmp, "db")); err != nil {
+		_ = os.RemoveAll(tmp)
+		return nil, err
+	}
+	st, err := OpenWorldStorage(tmp)
+	if err != nil {
+		_ = os.RemoveAll(tmp)
+		return nil, err
+	}
+	st.tmp = tmp
+	return st, nil
+}
+
+// extractDB extracts all files in the zip files passed of which the name starts with the prefix passed to the
+// directory passed. The database of a world holds no subdirectories, so neither does the directory.
+func extractDB(files []*zip.File, prefix, dir string) error {
+	if err := os.MkdirAll(dir, 0777); err != nil {
+		return fmt.Errorf("create database directory: %w", err)
+	}
+	for _, f := range files {
+		name := strings.TrimPrefix(f.Name, prefix)
+		if name == f.Name || name == "" || name == ".." || strings.ContainsAny(name, `/\`) {
+			continue
+		}
+		if err := extractFile(f, filepath.Join(dir, name)); err != nil {
+			return fmt.Errorf("extract %v: %w", f.Name, err)
+		}
+	}
+	return nil
+}
+
+// extractFile writes the contents of the zip file passed to a new file at the path passed.
+func extractFile(f *zip.File, file string) error {
+	r, err := f.Open()
+	if err != nil {
+		return err
+	}
+	defer r.Close()
+	w, err := os.Create(file)
+	if err != nil {
+		return err
+	}
+	if _, err := io.Copy(w, r); err != nil {
+		_ = w.Close()
+		return err
+	}
+	return w.Close()
+}
+
+// Names returns the names of all structures in the WorldStorage, including their namespace, such as
+// 'mystructure:house', sorted alphabetically.
+func (st *WorldStorage) Names() []string {
+	var names []string
+	it := st.db.NewIterator(util.BytesPrefix([]byte(structureTemplatePrefix)), nil)
+	defer it.Release()
+	for it.Next() {
+		names = append(names, strings.TrimPrefix(string(it.Key()), structureTemplatePrefix))
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Load loads the structure with the name passed from the WorldStorage. If the name has no namespace, the
+// 'mystructure' namespace is used, like WriteToWorld does. If no structure with the name exists, an error is
+// returned.
+func (st *WorldStorage) Load(name string) (Structure, error) {
+	data, err := st.db.Get([]byte(structureTemplateKey(name)), nil)
+	if err == leveldb.ErrNotFound {
+		return Structure{}, fmt.Errorf("no structure named %v in world", name)
+	} else if err != nil {
+		return Structure{}, fmt.Errorf("read structure %v: %w", name, err)
+	}
+	s, err := FromBytes(data)
+	if err != nil {
+		return Structure{}, fmt.Errorf("read structure %v: %w", name, err)
+	}
+	return s, nil
+}
+
+// Close closes the database of the world and removes the temporary directory it was extracted to, if it was opened
+// using OpenWorldArchive.
+func (st *WorldStorage) Close() error {
+	err := st.db.Close()
+	if st.tmp != "" {
+		if rmErr := os.RemoveAll(st.tmp); err == nil {
+			err = rmErr
+		}
+	}
+	return err
+}
@@ -2,14 +2,18 @@ package structure
 
 import (
 	"bufio"
+	"bytes"
 	"fmt"
+	"github.com/df-mc/dragonfly/server/block/cube"
 	"github.com/df-mc/dragonfly/server/world"
 	"github.com/df-mc/dragonfly/server/world/chunk"
 	"github.com/sandertv/gophertunnel/minecraft/nbt"
 	"go/ast"
 	"io"
+	"math"
 	"os"
 	"reflect"
+	"strconv"
 )
 
 // Structure holds the data of an .mcstructure file. Structure implements the world.Structure interface. It
@@ -24,16 +28,47 @@ type Structure struct {
 // Read uses a palette name of 'default' by default. UsePalette may be used to change the name of the
 // palette to use.
 func Read(r io.Reader) (Structure, error) {
+	return read(&nbtScanner{src: r})
+}
+
+// read reads a Structure using the nbtScanner passed. The NBT of the structure is scanned completely and checked
+// against MaxVolume before it is decoded.
+func read(sc *nbtScanner) (Structure, error) {
+	if err := scanStructure(sc); err != nil {
+		return Structure{}, fmt.Errorf("decode structure: %w", err)
+	}
 	s := &structure{}
-	if err := nbt.NewDecoderWithEncoding(r, nbt.LittleEndian).Decode(s); err != nil {
+	if err := nbt.NewDecoderWithEncoding(bytes.NewReader(sc.data[:sc.off]), nbt.LittleEndian).Decode(s); err != nil {
 		return Structure{}, fmt.Errorf("decode structure: %v", err.Error())
 	}
+	if s.FormatVersion != version && len(migrations) != 0 {
+		// The structure was written in a different format, so we decode it again, migrating it to the current
+		// format first.
+		s = &structure{}
+		if err := migrateStructure(sc.data[:sc.off], s); err != nil {
+			return Structure{}, fmt.Errorf("migrate structure: %w", err)
+		}
+	}
 	if err := s.check(); err != nil {
 		return Structure{}, fmt.Errorf("verify structure: %w", err)
 	}
+	// Entities and block entities may have been written by an older version of Minecraft, so we upgrade them the
+	// same way blocks in the palette are upgraded.
+	s.Structure.Entities = upgradeEntities(s.Structure.Entities)
+	upgradeBlockEntities(s.Structure.Palettes)
+	s.canonicalisePalettes()
+	// Entities are stored with their position in the world the structure was captured in. We make these
+	// positions relative to the structure, so that they remain valid wherever the structure is built.
+	s.Structure.Entities = translateEntities(s.Structure.Entities, s.origin().Mul(-1))
 	str := Structure{structure: s}
 	str.UsePalette("default")
 	str.prepare()
+	if err := str.checkPositionData(str.blockIndex); err != nil {
+		return Structure{}, fmt.Errorf("verify structure: %w", err)
+	}
+	if err := str.checkLiquids(str.liquidIndex); err != nil {
+		return Structure{}, fmt.Errorf("verify structure: %w", err)
+	}
 	return str, nil
 }
 
@@ -50,9 +85,29 @@ func ReadFile(file string) (Structure, error) {
 	return Read(bufio.NewReader(f))
 }
 
-// Write writes a Structure to the io.Writer passed. If successful, the error returned is nil.
-func Write(w io.Writer, s Structure) error {
+// Write writes a Structure to the io.Writer passed. If successful, the error returned is nil. WriteOptions may
+// be passed to change the way the Structure is written.
+func Write(w io.Writer, s Structure, opts ...WriteOption) error {
+	conf := &writeConfig{}
+	for _, opt := range opts {
+		opt(conf)
+	}
 	s.Structure.Palettes[s.paletteName] = *s.palette
+	if conf.convert != nil {
+		palettes := s.Structure.Palettes
+		s.Structure.Palettes = convertPalettes(palettes, conf.convert)
+		defer func() {
+			s.Structure.Palettes = palettes
+		}()
+	}
+
+	// Entity positions are relative to the structure in memory, but Minecraft expects them to be positions in
+	// the world the structure was captured in.
+	entities := s.Structure.Entities
+	s.Structure.Entities = translateEntities(entities, s.origin())
+	defer func() {
+		s.Structure.Entities = entities
+	}()
 
 	if err := nbt.NewEncoderWithEncoding(w, nbt.LittleEndian).Encode(s.structure); err != nil {
 		return fmt.Errorf("encode structure: %w", err)
@@ -61,8 +116,9 @@ func Write(w io.Writer, s Structure) error {
 }
 
 // WriteFile writes a Structure to the file passed. If successful, the error returned is nil. WriteFile
-// creates a file if it doesn't yet exist and truncates it if one does exist.
-func WriteFile(file string, s Structure) error {
+// creates a file if it doesn't yet exist and truncates it if one does exist. WriteOptions may be passed to change
+// the way the Structure is written.
+func WriteFile(file string, s Structure, opts ...WriteOption) error {
 	f, err := os.OpenFile(file, os.O_TRUNC|os.O_CREATE|os.O_WRONLY, 0644)
 	if err != nil {
 		return fmt.Errorf("open file: %w", err)
@@ -72,7 +128,80 @@ func WriteFile(file string, s Structure) error {
 		_ = w.Flush()
 		_ = f.Close()
 	}()
-	return Write(w, s)
+	return Write(w, s, opts...)
+}
+
+// FromBytes reads a Structure from the byte slice passed, which holds the data of an .mcstructure file. If
+// successful, the Structure returned is valid and the error is nil.
+// FromBytes, like Read, uses a palette name of 'default' by default.
+func FromBytes(b []byte) (Structure, error) {
+	return read(&nbtScanner{data: b})
+}
+
+// MarshalBinary encodes the Structure to the .mcstructure format. MarshalBinary implements the
+// encoding.BinaryMarshaler interface.
+func (s Structure) MarshalBinary() ([]byte, error) {
+	return s.AppendBinary(nil)
+}
+
+// AppendBinary encodes the Structure to the .mcstructure format and appends the result to the byte slice
+// passed. AppendBinary implements the encoding.BinaryAppender interface.
+func (s Structure) AppendBinary(b []byte) ([]byte, error) {
+	buf := bytes.NewBuffer(b)
+	if err := Write(buf, s); err != nil {
+		return b, err
+	}
+	return buf.Bytes(), nil
+}
+
+// UnmarshalBinary decodes a Structure in the .mcstructure format from the byte slice passed into s.
+// UnmarshalBinary implements the encoding.BinaryUnmarshaler interface.
+func (s *Structure) UnmarshalBinary(b []byte) error {
+	str, err := FromBytes(b)
+	if err != nil {
+		return err
+	}
+	*s = str
+	return nil
+}
+
+// WriteTo writes the Structure to the io.Writer passed in the .mcstructure format. It returns the number of
+// bytes written. WriteTo implements the io.WriterTo interface.
+func (s Structure) WriteTo(w io.Writer) (int64, error) {
+	cw := &countingWriter{w: w}
+	err := Write(cw, s)
+	return cw.n, err
+}
+
+// ReadFrom reads a Structure in the .mcstructure format from the io.Reader passed into s. It returns the number
+// of bytes read. ReadFrom implements the io.ReaderFrom interface.
+func (s *Structure) ReadFrom(r io.Reader) (int64, error) {
+	cr := &countingReader{r: r}
+	str, err := Read(cr)
+	if err != nil {
+		return cr.n, err
+	}
+	*s = str
+	return cr.n, nil
+}
+
+// String returns a concise summary of the Structure, holding its dimensions, the size of its palette, the
+// number of non-air blocks, the number of entities and the number of blocks with block entity data. String
+// implements the fmt.Stringer interface and may safely be called on a zero Structure.
+func (s Structure) String() string {
+	if s.structure == nil || s.palette == nil {
+		return "Structure(nil)"
+	}
+	air, blocks := s.airEntries(), 0
+	for _, index := range s.blocks {
+		if index != -1 && !air[index] {
+			blocks++
+		}
+	}
+	dims := s.Dimensions()
+	return fmt.Sprintf("Structure(%vx%vx%v, palette: %v, blocks: %v, entities: %v, block entities: %v)",
+		dims[0], dims[1], dims[2], len(s.palette.BlockPalette), blocks, len(s.Structure.Entities),
+		len(s.palette.BlockPositionData))
 }
 
 // New creates a new Structure and initialises it with air blocks. The Structure returned may be written to
@@ -98,6 +227,83 @@ func New(dimensions [3]int) Structure {
 	return s
 }
 
+// NewChecked creates a new Structure like New, but first verifies that the dimensions passed are valid. An
+// error is returned if any of the dimensions is zero or negative, or if the total volume of the Structure is
+// too large to be represented.
+func NewChecked(dimensions [3]int) (Structure, error) {
+	volume := int64(1)
+	for i, d := range dimensions {
+		if d <= 0 {
+			return Structure{}, fmt.Errorf("structure dimensions must be positive, but got %v for axis %v (%v)", d, i, dimensions)
+		}
+		if volume *= int64(d); volume > math.MaxInt32 {
+			return Structure{}, fmt.Errorf("structure with dimensions %v exceeds the maximum volume of %v blocks", dimensions, math.MaxInt32)
+		}
+	}
+	return New(dimensions), nil
+}
+
+// NewFromBlocks creates a new Structure from the blocks passed. The blocks are indexed as blocks[x][y][z], and
+// the dimensions of the Structure are derived from the length of each of these slices, so every slice of
+// the same depth must have the same length. A nil block results in a position that does not hold a block.
+func NewFromBlocks(blocks [][][]world.Block) Structure {
+	var dimensions [3]int
+	if dimensions[0] = len(blocks); dimensions[0] > 0 {
+		if dimensions[1] = len(blocks[0]); dimensions[1] > 0 {
+			dimensions[2] = len(blocks[0][0])
+		}
+	}
+	s := New(dimensions)
+	cache := make(map[uint64]int32)
+
+	offset := 0
+	for x := 0; x < dimensions[0]; x++ {
+		for y := 0; y < dimensions[1]; y++ {
+			for z := 0; z < dimensions[2]; z, offset = z+1, offset+1 {
+				b := blocks[x][y][z]
+				if b == nil {
+					s.blocks[offset] = -1
+					continue
+				}
+				s.blocks[offset] = s.cachedPtrFor(cache, b)
+				if nbtBlock, ok := b.(world.NBTer); ok {
+					s.palette.BlockPositionData[strconv.Itoa(offset)] = blockPositionData{BlockEntityData: nbtBlock.EncodeNBT()}
+				}
+			}
+		}
+	}
+	return s
+}
+
+// NewFromFunc creates a new Structure with the dimensions passed and fills it by calling fn for every position
+// within these dimensions. The block and liquid returned by fn are placed at that position. A nil block
+// results in a position that does not hold a block, and a nil liquid results in a position without liquid.
+func NewFromFunc(dimensions [3]int, fn func(x, y, z int) (world.Block, world.Liquid)) Structure {
+	s := New(dimensions)
+	cache := make(map[uint64]int32)
+
+	offset := 0
+	for x := 0; x < dimensions[0]; x++ {
+		for y := 0; y < dimensions[1]; y++ {
+			for z := 0; z < dimensions[2]; z, offset = z+1, offset+1 {
+				b, liq := fn(x, y, z)
+				if liq != nil {
+					s.liquids[offset] = s.cachedPtrFor(cache, liq)
+				}
+				if b == nil {
+					s.blocks[offset] = -1
+					continue
+				}
+				s.blocks[offset] = s.cachedPtrFor(cache, b)
+				if nbtBlock, ok := b.(world.NBTer); ok {
+					s.palette.BlockPositionData[strconv.Itoa(offset)] = blockPositionData{BlockEntityData: nbtBlock.EncodeNBT()}
+				}
+			}
+		}
+	}
+	return s
+}
+
 // UsePalette changes the palette name to use for the Structure. When reading a Structure, this will change
 // the palette used to read blocks from. When writing a Structure, the palette will be written with this name,
 // so that subsequent readers of the Structure must first call UsePalette with this name to get the right
@@ -120,7 +326,8 @@ func (s Structure) UsePalette(name string) {
 			Version: chunk.CurrentBlockVersion,
 		}}
 	}
-	s.parsePalette()
+	// The palette is parsed lazily, once it is first needed.
+	s.parsedPalette = nil
 	s.prepare()
 }
 
@@ -136,8 +343,11 @@ func (s Structure) RotateRight() Structure {
 
 // rotate returns a new structure with the same contents but rotated 90 degrees in the specificed direction.
 func (s Structure) rotate(direction int) Structure {
+	s.ensureParsed()
 	sizeX, sizeY, sizeZ := int(s.Size[0]), int(s.Size[1]), int(s.Size[2])
 	newStructure := New([3]int{sizeZ, sizeY, sizeX})
+	newStructure.paletteName, newStructure.registry, newStructure.match = s.paletteName, s.registry, s.match
+	newStructure.Structure.Entities = rotateEntities(s.Structure.Entities, sizeX, sizeZ, direction)
 
 	maxX, maxZ := sizeX-1, sizeZ-1
 	for x := 0; x < sizeX; x++ {
@@ -151,44 +361,185 @@ func (s Structure) rotate(direction int) Structure {
 					newX = z
 					newZ = -x + maxX
 				}
-				b, l := s.At(x, y, z, nil)
-				newStructure.Set(newX, y, newZ, b, l)
+				offset := (x * s.l * s.h) + (y * s.l) + z
+				newOffset := (newX * newStructure.l * newStructure.h) + (y * newStructure.l) + newZ
+
+				newStructure.blocks[newOffset] = s.blocks[offset]
+				newStructure.liquids[newOffset] = s.liquids[offset]
+				if data, ok := s.positionData(offset); ok {
+					if index := s.blocks[offset]; index != -1 {
+						data.BlockEntityData = rotateBlockEntity(s.palette.BlockPalette[index], data.BlockEntityData, direction)
+					}
+					newStructure.palette.BlockPositionData[strconv.Itoa(newOffset)] = data
+				}
 			}
 		}
 	}
-	newStructure.palette = s.palette
 	newStructure.palette.BlockPalette = make([]block, len(s.palette.BlockPalette))
+	newStructure.parsedPalette = make([]parsedBlock, len(s.parsedPalette))
 
+	methodName := "RotateLeft"
+	if direction == 1 {
+		methodName = "RotateRight"
+	}
 	for i, b := range s.parsedPalette {
-		origin := reflect.ValueOf(b.b)
-		t := reflect.TypeOf(b.b)
-		v := reflect.New(t).Elem()
-
-		for i := 0; i < v.NumField(); i++ {
-			fieldV := v.Field(i)
-			if !ast.IsExported(t.Field(i).Name) {
-				continue
-			}
-			fieldV.Set(origin.Field(i))
-
-			methodName := "RotateLeft"
-			if direction == 1 {
-				methodName = "RotateRight"
-			}
-			method := fieldV.MethodByName(methodName)
-			if !method.IsZero() {
-				fieldV.Set(method.Call(nil)[0])
-			}
+		// Entries that are not rotated are shared with the original structure, so that they do not need to be
+		// resolved again.
+		newStructure.palette.BlockPalette[i], newStructure.parsedPalette[i] = s.palette.BlockPalette[i], b
+		if entry, ok := rotateEntry(s.palette.BlockPalette[i], direction); ok {
+			newStructure.palette.BlockPalette[i], newStructure.parsedPalette[i] = entry, newStructure.resolveEntry(entry)
+			continue
 		}
-
-		name, states := v.Interface().(world.Block).EncodeBlock()
-		newStructure.palette.BlockPalette[i] = block{
+		r, ok := rotateBlock(b.b, methodName)
+		if !ok {
+			// The block could not be parsed or has no fields that could be rotated, so we keep the entry as is.
+			continue
+		}
+		name, states := r.EncodeBlock()
+		entry := block{
 			Name:    name,
 			States:  states,
 			Version: chunk.CurrentBlockVersion,
 		}
+		newStructure.palette.BlockPalette[i], newStructure.parsedPalette[i] = entry, newStructure.resolveEntry(entry)
 	}
-	newStructure.parsePalette()
 	newStructure.prepare()
 	return newStructure
 }
+
+// rotateBlock returns the world.Block passed with the method passed, RotateLeft or RotateRight, called on all of its
+// exported fields that have such a method. If the block is nil or has no such fields, false is returned.
+func rotateBlock(b world.Block, methodName string) (world.Block, bool) {
+	if b == nil || reflect.TypeOf(b).Kind() != reflect.Struct {
+		return nil, false
+	}
+	origin := reflect.ValueOf(b)
+	t := reflect.TypeOf(b)
+	v := reflect.New(t).Elem()
+
+	rotated := false
+	for i := 0; i < v.NumField(); i++ {
+		fieldV := v.Field(i)
+		if !ast.IsExported(t.Field(i).Name) {
+			continue
+		}
+		fieldV.Set(origin.Field(i))
+
+		method := fieldV.MethodByName(methodName)
+		if method.IsValid() {
+			fieldV.Set(method.Call(nil)[0])
+			rotated = true
+		}
+	}
+	if !rotated {
+		return nil, false
+	}
+	return v.Interface().(world.Block), true
+}
+
+// SplitChunks splits the structure into pieces that each occupy only a single chunk when the structure is
+// placed at the origin passed. The pieces returned span the full height of the structure and have their
+// world origin set to the position of their lowest corner in the world.
+func (s Structure) SplitChunks(origin cube.Pos) map[world.ChunkPos]Structure {
+	dims := s.Dimensions()
+	pieces := make(map[world.ChunkPos]Structure)
+	for chunkX := origin[0] >> 4; chunkX <= (origin[0]+dims[0]-1)>>4; chunkX++ {
+		for chunkZ := origin[2] >> 4; chunkZ <= (origin[2]+dims[2]-1)>>4; chunkZ++ {
+			min := [3]int{chunkX<<4 - origin[0], 0, chunkZ<<4 - origin[2]}
+			max := [3]int{min[0] + 16, dims[1], min[2] + 16}
+			for _, i := range [2]int{0, 2} {
+				if min[i] < 0 {
+					min[i] = 0
+				}
+				if max[i] > dims[i] {
+					max[i] = dims[i]
+				}
+			}
+			piece := s.copyRegion(min, max)
+			piece.Origin = []int32{int32(origin[0] + min[0]), int32(origin[1]), int32(origin[2] + min[2])}
+			pieces[world.ChunkPos{int32(chunkX), int32(chunkZ)}] = piece
+		}
+	}
+	return pieces
+}
+
+// copyRegion returns a new structure holding a copy of the blocks, liquids and block entity data in the
+// cuboid spanning from min (inclusive) to max (exclusive). The palette of the structure is shared with the new
+// structure until either of them adds a palette entry.
+func (s Structure) copyRegion(min, max [3]int) Structure {
+	newStructure := New([3]int{max[0] - min[0], max[1] - min[1], max[2] - min[2]})
+	newStructure.paletteName, newStructure.registry, newStructure.match = s.paletteName, s.registry, s.match
+	newStructure.sharePalette(s.structure)
+	newStructure.prepare()
+
+	for x := min[0]; x < max[0]; x++ {
+		for y := min[1]; y < max[1]; y++ {
+			for z := min[2]; z < max[2]; z++ {
+				offset := (x * s.l * s.h) + (y * s.l) + z
+				newOffset := ((x - min[0]) * newStructure.l * newStructure.h) + ((y - min[1]) * newStructure.l) + (z - min[2])
+
+				newStructure.blocks[newOffset] = s.blocks[offset]
+				newStructure.liquids[newOffset] = s.liquids[offset]
+				if data, ok := s.positionData(offset); ok {
+					newStructure.palette.BlockPositionData[strconv.Itoa(newOffset)] = data
+				}
+			}
+		}
+	}
+	return newStructure
+}
+
+// clone returns a copy of the structure. Modifying the copy does not affect the structure and vice versa. The
+// palettes of the structure are shared with the copy until either of them adds a palette entry.
+func (s Structure) clone() Structure {
+	return s.copyStructure(true)
+}
+
+// copyStructure returns a copy of the structure like clone. If indices is false, the block indices of the structure
+// are not copied, so that the copy holds everything but its blocks and liquids.
+// copyStructure does not modify the structure, so that it may be called from multiple goroutines at the same time
+// for structures that are not modified otherwise, such as the Structure held by a FrozenStructure.
+func (s Structure) copyStructure(indices bool) Structure {
+	c := &structure{
+		FormatVersion: s.FormatVersion,
+		Size:          append([]int32(nil), s.Size...),
+		Origin:        append([]int32(nil), s.Origin...),
+		Structure: structureData{
+			Entities: append([]map[string]interface{}(nil), s.Structure.Entities...),
+			Palettes: make(map[string]palette, len(s.Structure.Palettes)),
+		},
+		paletteName:   s.paletteName,
+		parsedPalette: s.parsedPalette[:len(s.parsedPalette):len(s.parsedPalette)],
+		registry:      s.registry,
+		match:         s.match,
+	}
+	if indices {
+		c.Structure.BlockIndices = make([][]int32, len(s.Structure.BlockIndices))
+		for i, indices := range s.Structure.BlockIndices {
+			c.Structure.BlockIndices[i] = append([]int32(nil), indices...)
+		}
+	}
+	palettes := make(map[string]palette, len(s.Structure.Palettes)+1)
+	for name, p := range s.Structure.Palettes {
+		palettes[name] = p
+	}
+	// The palette currently used may have had entries added since it was last stored in the palettes of the
+	// structure, so it is taken from the structure directly.
+	palettes[s.paletteName] = *s.palette
+	for name, p := range palettes {
+		positionData := make(map[string]blockPositionData, len(p.BlockPositionData))
+		for k, v := range p.BlockPositionData {
+			positionData[k] = v
+		}
+		c.Structure.Palettes[name] = palette{
+			// The block palette is shared until either structure adds an entry to it. Capping the capacity
+			// ensures that appending to it allocates a new array.
+			BlockPalette:      p.BlockPalette[:len(p.BlockPalette):len(p.BlockPalette)],
+			BlockPositionData: positionData,
+		}
+	}
+	p := c.Structure.Palettes[c.paletteName]
+	c.palette = &p
+	c.prepare()
+	return Structure{structure: c}
+}
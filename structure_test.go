@@ -0,0 +1,54 @@
+package structure
+
+import (
+	"bytes"
+	df "github.com/df-mc/dragonfly/server/block"
+	"github.com/go-gl/mathgl/mgl64"
+	"testing"
+)
+
+func TestWriteReadRoundTrip(t *testing.T) {
+	s := New([3]int{3, 2, 3})
+	s.Set(0, 0, 0, df.Stone{}, nil)
+	s.Set(1, 0, 0, df.NewChest(), nil)
+	s.Set(2, 1, 2, df.Water{Depth: 8, Still: true}, nil)
+	s.AddEntity("minecraft:pig", mgl64.Vec3{1.5, 1, 1.5}, nil)
+
+	var buf bytes.Buffer
+	if err := Write(&buf, s); err != nil {
+		t.Fatalf("write structure: %v", err)
+	}
+	r, err := Read(&buf)
+	if err != nil {
+		t.Fatalf("read structure: %v", err)
+	}
+	if r.Dimensions() != s.Dimensions() {
+		t.Fatalf("dimensions = %v, want %v", r.Dimensions(), s.Dimensions())
+	}
+	if r.Hash() != s.Hash() {
+		t.Fatalf("structure read differs from the structure written")
+	}
+	if got, _ := r.At(1, 0, 0, nil); !sameBlock(got, df.NewChest()) {
+		t.Fatalf("block at [1 0 0] = %v, want chest", got)
+	}
+}
+
+func TestReadUnimplementedBlockEntity(t *testing.T) {
+	// Hoppers are not implemented by Dragonfly, but hold block entity data in Minecraft. Structures holding them
+	// must still be read, keeping their block entity data.
+	s := New([3]int{1, 1, 1})
+	hopper := s.ptrForEntry(block{Name: "minecraft:hopper", States: map[string]interface{}{"facing_direction": int32(0), "toggle_bit": uint8(0)}})
+	s.setIndex(0, hopper, map[string]interface{}{"id": "Hopper", "Items": []interface{}{}})
+
+	var buf bytes.Buffer
+	if err := Write(&buf, s); err != nil {
+		t.Fatalf("write structure: %v", err)
+	}
+	r, err := Read(&buf)
+	if err != nil {
+		t.Fatalf("read structure: %v", err)
+	}
+	if data, ok := r.positionData(0); !ok || data.BlockEntityData["id"] != "Hopper" {
+		t.Fatalf("block entity data of hopper = %v, want data with id Hopper", data.BlockEntityData)
+	}
+}
@@ -0,0 +1,103 @@
+package structure
+
+import (
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"math"
+)
+
+// rotateEntry returns the palette entry passed rotated by 90 degrees around the Y axis for every turn passed,
+// clockwise if turns is positive and anti-clockwise if it is negative. Only banners are rotated this way: they are
+// attached to blocks using a block.Attachment, of which RotateRight rotates anti-clockwise and which leaves the
+// orientation of standing banners outside the range [0, 16), so rotating the world.Block of a banner produces an
+// invalid block. If the entry passed is not a banner, false is returned.
+func rotateEntry(bl block, turns int) (block, bool) {
+	switch bl.Name {
+	case "minecraft:standing_banner":
+		// Standing banners have one of 16 orientations, starting at south and turning clockwise.
+		rot, ok := bl.States["ground_sign_direction"].(int32)
+		if !ok {
+			return bl, false
+		}
+		return withState(bl, "ground_sign_direction", int32(((int(rot)+4*turns)%16+16)%16)), true
+	case "minecraft:wall_banner":
+		// The facing direction of wall banners is a cube.Direction offset by 2, as the values 0 and 1 are used
+		// for down and up.
+		facing, ok := bl.States["facing_direction"].(int32)
+		if !ok || facing < 2 || facing > 5 {
+			return bl, false
+		}
+		d := cube.Direction(facing - 2)
+		for ; turns > 0; turns-- {
+			d = d.RotateRight()
+		}
+		for ; turns < 0; turns++ {
+			d = d.RotateLeft()
+		}
+		return withState(bl, "facing_direction", int32(d)+2), true
+	}
+	return bl, false
+}
+
+// withState returns a copy of the palette entry passed with the state passed set to the value passed. The states
+// of the entry passed are not modified.
+func withState(bl block, name string, value interface{}) block {
+	states := make(map[string]interface{}, len(bl.States))
+	for k, v := range bl.States {
+		states[k] = v
+	}
+	states[name] = value
+	bl.States = states
+	return bl
+}
+
+// rotateBlockEntity returns the block entity data passed for a block with the palette entry passed, rotated by 90
+// degrees around the Y axis in the direction passed. A direction of 1 rotates clockwise and a direction of -1
+// rotates anti-clockwise. Only data of which the meaning depends on the orientation of the block is changed, and
+// the map passed is never modified.
+func rotateBlockEntity(bl block, data map[string]interface{}, direction int) map[string]interface{} {
+	switch bl.Name {
+	case "minecraft:skull":
+		data = copyBlockEntity(data)
+		// Dragonfly stores the rotation of skulls as one of 16 orientations, whereas Minecraft stores it as an
+		// angle in degrees.
+		if rot, ok := data["Rot"].(byte); ok {
+			data["Rot"] = byte((int(rot) + 4*direction + 16) % 16)
+		}
+		if rot, ok := data["Rotation"].(float32); ok {
+			data["Rotation"] = float32(normaliseAngle(float64(rot) + 90*float64(direction)))
+		}
+	case "minecraft:frame", "minecraft:glow_frame":
+		if facing, _ := bl.States["facing_direction"].(int32); facing > 1 {
+			// Item frames attached to a wall rotate along with the wall, so their item rotation, which is
+			// relative to the frame, is unchanged.
+			break
+		}
+		data = copyBlockEntity(data)
+		// Items in item frames lying on the floor or hanging from the ceiling can have one of 8 rotations.
+		if rot, ok := data["ItemRotation"].(byte); ok {
+			data["ItemRotation"] = byte((int(rot) + 2*direction + 8) % 8)
+		}
+		if rot, ok := data["ItemRotation"].(float32); ok {
+			data["ItemRotation"] = float32(normaliseAngle(float64(rot) + 90*float64(direction)))
+		}
+	}
+	return data
+}
+
+// copyBlockEntity returns a shallow copy of the block entity data passed.
+func copyBlockEntity(data map[string]interface{}) map[string]interface{} {
+	m := make(map[string]interface{}, len(data))
+	for k, v := range data {
+		m[k] = v
+	}
+	return m
+}
+
+// normaliseAngle normalises an angle in degrees to the range [0, 360).
+func normaliseAngle(angle float64) float64 {
+	angle = math.Mod(angle, 360)
+	if angle < 0 {
+		angle += 360
+	}
+	return angle
+}
@@ -0,0 +1,35 @@
+package structure
+
+import (
+	df "github.com/df-mc/dragonfly/server/block"
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"testing"
+)
+
+func TestRotateBanner(t *testing.T) {
+	tests := []struct {
+		b           df.Banner
+		right, left df.Banner
+	}{
+		{
+			b:     df.Banner{Attach: df.StandingAttachment(1)},
+			right: df.Banner{Attach: df.StandingAttachment(5)},
+			left:  df.Banner{Attach: df.StandingAttachment(13)},
+		},
+		{
+			b:     df.Banner{Attach: df.WallAttachment(cube.North)},
+			right: df.Banner{Attach: df.WallAttachment(cube.East)},
+			left:  df.Banner{Attach: df.WallAttachment(cube.West)},
+		},
+	}
+	for _, test := range tests {
+		s := New([3]int{1, 1, 1})
+		s.Set(0, 0, 0, test.b, nil)
+		if got, _ := s.RotateRight().At(0, 0, 0, nil); !sameBlock(got, test.right) {
+			t.Errorf("%v rotated right = %v, want %v", test.b.Attach, got, test.right.Attach)
+		}
+		if got, _ := s.RotateLeft().At(0, 0, 0, nil); !sameBlock(got, test.left) {
+			t.Errorf("%v rotated left = %v, want %v", test.b.Attach, got, test.left.Attach)
+		}
+	}
+}
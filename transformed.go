@@ -0,0 +1,344 @@
+package structure
+
+import (
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/world"
+	"strconv"
+)
+
+// transformed is a view of a Structure as it is built by Build: mirrored, rotated and masked as configured by the
+// BuildOptions passed. transformed implements world.Structure, so that it may be built directly, and transforms
+// every block only once it is requested, so that the Structure does not need to be copied.
+type transformed struct {
+	s Structure
+	// pos is the world position of the lowest corner of the transformed structure and dims are its dimensions.
+	pos  cube.Pos
+	dims [3]int
+
+	mirror bool
+	plane  Plane
+	// turns is the number of times the structure is rotated by 90 degrees clockwise after mirroring it.
+	turns int
+
+	// denied holds for every position in the transformed structure, indexed like the structure itself, whether
+	// the placement or region checks deny building it. It is nil if no position is denied.
+	denied []bool
+	// deniedChunks holds the chunks of which the region check denies building.
+	deniedChunks map[world.ChunkPos]bool
+	allowBlock   func(pos cube.Pos) bool
+
+	// hook is called for every block in the transformed structure as it is placed, if not nil.
+	hook func(pos cube.Pos, b world.Block) world.Block
+	// markers holds the function registered for every palette entry that is a marker, or nil for other palette
+	// entries. It is nil if the structure holds no markers. found holds the positions of the markers found while
+	// building the transformed structure, along with their function.
+	markers []func(w *world.World, pos cube.Pos) world.Block
+	found   []foundMarker
+
+	// loot holds the loot tables to fill containers with, if not nil. containers holds the containers found while
+	// building the transformed structure that are filled with loot once built.
+	loot       *lootConfig
+	containers []foundContainer
+
+	// blocks holds the transformed block of every palette entry of the structure, which is only set once the
+	// palette entry is transformed, as recorded in done.
+	blocks []world.Block
+	done   []bool
+}
+
+// foundMarker is a marker found while building a transformed structure.
+type foundMarker struct {
+	pos     cube.Pos
+	replace func(w *world.World, pos cube.Pos) world.Block
+}
+
+// foundContainer is a container found while building a transformed structure that is filled with loot.
+type foundContainer struct {
+	pos   cube.Pos
+	table LootTable
+}
+
+// Check to ensure that *transformed implements the world.Structure interface.
+var _ world.Structure = (*transformed)(nil)
+
+// newTransformed returns a view of the Structure passed as it is built at the position passed with the buildConfig
+// passed. The placement and region checks of the buildConfig are called immediately.
+func newTransformed(s Structure, pos cube.Pos, conf *buildConfig) *transformed {
+	s.ensureParsed()
+	t := &transformed{
+		s:            s,
+		mirror:       conf.mirror,
+		plane:        conf.plane,
+		turns:        (conf.turns%4 + 4) % 4,
+		deniedChunks: map[world.ChunkPos]bool{},
+		allowBlock:   conf.allowBlock,
+		hook:         conf.hook,
+		loot:         conf.loot,
+		blocks:       make([]world.Block, len(s.parsedPalette)),
+		done:         make([]bool, len(s.parsedPalette)),
+	}
+	t.pos, t.dims = conf.place(s.Dimensions(), pos)
+	for _, m := range conf.markers {
+		name, properties := m.b.EncodeBlock()
+		for _, index := range s.lookupAll(name, properties) {
+			if t.markers == nil {
+				t.markers = make([]func(w *world.World, pos cube.Pos) world.Block, len(s.parsedPalette))
+			}
+			t.markers[index] = m.replace
+		}
+	}
+	if conf.allowBlock != nil || conf.allowRegion != nil {
+		t.check(conf.allowRegion)
+	}
+	return t
+}
+
+// identity checks if the view holds the structure exactly as it is, in which case the structure itself may be
+// built instead of the view.
+func (t *transformed) identity() bool {
+	return !t.mirror && t.turns == 0 && t.denied == nil && t.hook == nil && t.markers == nil && t.loot == nil
+}
+
+// check calls the region check passed, if not nil, and the placement check of the view, if not nil, for the
+// transformed structure and records the positions that may not be built at.
+func (t *transformed) check(allowRegion func(min, max cube.Pos) bool) {
+	dims := t.dims
+	t.denied = make([]bool, dims[0]*dims[1]*dims[2])
+	if allowRegion != nil {
+		for x := 0; x < dims[0]; x = nextChunk(t.pos[0], x, dims[0]) {
+			for z := 0; z < dims[2]; z = nextChunk(t.pos[2], z, dims[2]) {
+				endX, endZ := nextChunk(t.pos[0], x, dims[0]), nextChunk(t.pos[2], z, dims[2])
+				if allowRegion(t.pos.Add(cube.Pos{x, 0, z}), t.pos.Add(cube.Pos{endX - 1, dims[1] - 1, endZ - 1})) {
+					continue
+				}
+				t.deniedChunks[world.ChunkPos{int32((t.pos[0] + x) >> 4), int32((t.pos[2] + z) >> 4)}] = true
+				for rx := x; rx < endX; rx++ {
+					for y := 0; y < dims[1]; y++ {
+						for rz := z; rz < endZ; rz++ {
+							t.denied[(rx*dims[1]+y)*dims[2]+rz] = true
+						}
+					}
+				}
+			}
+		}
+	}
+	if t.allowBlock != nil {
+		for x := 0; x < dims[0]; x++ {
+			for y := 0; y < dims[1]; y++ {
+				for z := 0; z < dims[2]; z++ {
+					i := (x*dims[1]+y)*dims[2] + z
+					if t.denied[i] {
+						continue
+					}
+					offset := t.source(x, y, z)
+					if t.s.blocks[offset] == -1 && t.s.liquids[offset] == -1 {
+						continue
+					}
+					t.denied[i] = !t.allowBlock(t.pos.Add(cube.Pos{x, y, z}))
+				}
+			}
+		}
+	}
+}
+
+// Dimensions returns the dimensions of the transformed structure.
+func (t *transformed) Dimensions() [3]int {
+	return t.dims
+}
+
+// At returns the block and liquid at the x, y and z passed in the transformed structure.
+func (t *transformed) At(x, y, z int, _ func(x int, y int, z int) world.Block) (world.Block, world.Liquid) {
+	if t.denied != nil && t.denied[(x*t.dims[1]+y)*t.dims[2]+z] {
+		return nil, nil
+	}
+	offset := t.source(x, y, z)
+	var b world.Block
+	if index := t.s.blocks[offset]; index != -1 && t.markers != nil && t.markers[index] != nil {
+		t.found = append(t.found, foundMarker{pos: t.pos.Add(cube.Pos{x, y, z}), replace: t.markers[index]})
+	} else if index != -1 {
+		b = t.block(index)
+		if t.s.parsedPalette[index].hasNBT {
+			data, ok := t.s.positionData(offset)
+			if ok {
+				b = b.(world.NBTer).DecodeNBT(t.blockEntity(index, data.BlockEntityData)).(world.Block)
+			}
+			if t.loot != nil {
+				if table, ok := t.loot.table(t.s.posOf(offset), data.BlockEntityData); ok {
+					t.containers = append(t.containers, foundContainer{pos: t.pos.Add(cube.Pos{x, y, z}), table: table})
+				}
+			}
+		}
+		if t.hook != nil && b != nil {
+			if b = t.hook(t.pos.Add(cube.Pos{x, y, z}), b); b == nil {
+				return nil, nil
+			}
+		}
+	}
+	if index := t.s.liquids[offset]; index != -1 {
+		return b, t.s.parsedPalette[index].liq
+	}
+	return b, nil
+}
+
+// replaceMarkers calls the function of every marker found while building the transformed structure and places the
+// blocks returned in the world.World passed.
+func (t *transformed) replaceMarkers(w *world.World) {
+	for _, m := range t.found {
+		if b := m.replace(w, m.pos); b != nil {
+			w.SetBlock(m.pos, b, nil)
+		}
+	}
+	t.found = nil
+}
+
+// fillContainers fills the containers found while building the transformed structure with loot.
+func (t *transformed) fillContainers(w *world.World) {
+	for _, c := range t.containers {
+		c.table.fill(w, c.pos, t.loot.seed)
+	}
+	t.containers = nil
+}
+
+// source returns the offset in the structure of the block found at the x, y and z passed in the transformed
+// structure.
+func (t *transformed) source(x, y, z int) int {
+	d := t.dims
+	for i := 0; i < t.turns; i++ {
+		// Undo a clockwise rotation, which moved a position at (x, z) to (l-1-z, x), l being the length of the
+		// structure before rotating it and the width after.
+		x, z = z, d[0]-1-x
+		d[0], d[2] = d[2], d[0]
+	}
+	if t.mirror {
+		if t.plane == PlaneZ {
+			z = d[2] - 1 - z
+		} else {
+			x = d[0] - 1 - x
+		}
+	}
+	return (x * t.s.l * t.s.h) + (y * t.s.l) + z
+}
+
+// block returns the transformed block of the palette entry at the index passed.
+func (t *transformed) block(index int32) world.Block {
+	if t.done[index] {
+		return t.blocks[index]
+	}
+	b := t.s.parsedPalette[index].b
+	if bl, ok := rotateEntry(t.s.palette.BlockPalette[index], t.turns); ok && t.turns != 0 {
+		// Banners are not changed by mirroring, so only the rotation of their palette entry is needed.
+		b = t.s.resolveEntry(bl).b
+		t.blocks[index], t.done[index] = b, true
+		return b
+	}
+	if t.mirror {
+		if m, ok := mirrorBlock(b, t.plane); ok {
+			b = m
+		}
+	}
+	for i := 0; i < t.turns; i++ {
+		if r, ok := rotateBlock(b, "RotateRight"); ok {
+			b = r
+		}
+	}
+	t.blocks[index], t.done[index] = b, true
+	return b
+}
+
+// blockEntity returns the block entity data passed, of a block with the palette entry at the index passed,
+// transformed along with the block.
+func (t *transformed) blockEntity(index int32, data map[string]interface{}) map[string]interface{} {
+	bl := t.s.palette.BlockPalette[index]
+	if t.mirror {
+		data = mirrorBlockEntity(bl, data, t.plane)
+	}
+	for i := 0; i < t.turns; i++ {
+		data = rotateBlockEntity(bl, data, 1)
+	}
+	return data
+}
+
+// entities returns the entities of the structure transformed along with it. Entities positioned in a part of the
+// structure that may not be built at are left out.
+func (t *transformed) entities() []map[string]interface{} {
+	entities, d := t.s.Structure.Entities, t.s.Dimensions()
+	if t.mirror {
+		mirrored := make([]map[string]interface{}, len(entities))
+		for i, e := range entities {
+			mirrored[i] = e
+			if p, ok := entityPos(e); ok {
+				mirrored[i] = mirrorEntity(e, p, d[t.plane.axis()], t.plane)
+			}
+		}
+		entities = mirrored
+	}
+	for i := 0; i < t.turns; i++ {
+		entities = rotateEntities(entities, d[0], d[2], 1)
+		d[0], d[2] = d[2], d[0]
+	}
+	if t.denied == nil {
+		return entities
+	}
+	allowed := make([]map[string]interface{}, 0, len(entities))
+	for _, e := range entities {
+		if p, ok := entityPos(e); ok {
+			pos := t.pos.Add(cube.PosFromVec3(p))
+			if t.deniedChunks[world.ChunkPos{int32(pos[0] >> 4), int32(pos[2] >> 4)}] {
+				continue
+			}
+			if t.allowBlock != nil && !t.allowBlock(pos) {
+				continue
+			}
+		}
+		allowed = append(allowed, e)
+	}
+	return allowed
+}
+
+// settlePositions returns the world positions of all liquids and blocks affected by gravity in the transformed
+// structure, ordered from the bottom of the structure to the top.
+func (t *transformed) settlePositions() []cube.Pos {
+	settle := make([]bool, len(t.s.parsedPalette))
+	for i, entry := range t.s.parsedPalette {
+		_, gravity := entry.b.(gravityAffected)
+		settle[i] = entry.liq != nil || gravity
+	}
+	var positions []cube.Pos
+	for y := 0; y < t.dims[1]; y++ {
+		for x := 0; x < t.dims[0]; x++ {
+			for z := 0; z < t.dims[2]; z++ {
+				if t.denied != nil && t.denied[(x*t.dims[1]+y)*t.dims[2]+z] {
+					continue
+				}
+				offset := t.source(x, y, z)
+				if b, l := t.s.blocks[offset], t.s.liquids[offset]; (b != -1 && settle[b]) || l != -1 {
+					positions = append(positions, t.pos.Add(cube.Pos{x, y, z}))
+				}
+			}
+		}
+	}
+	return positions
+}
+
+// snapshot captures the blocks in the world.World passed that would be replaced when building the transformed
+// structure.
+func (t *transformed) snapshot(w *world.World) Structure {
+	dims := t.dims
+	// The snapshot must restore the world exactly as it was, so only liquids actually present are captured.
+	snapshot := Capture(w, t.pos, t.pos.Add(cube.Pos{dims[0] - 1, dims[1] - 1, dims[2] - 1}), WithLiquidPolicy(nil))
+	for x := 0; x < dims[0]; x++ {
+		for y := 0; y < dims[1]; y++ {
+			for z := 0; z < dims[2]; z++ {
+				i := (x*dims[1]+y)*dims[2] + z
+				if (t.denied == nil || !t.denied[i]) && t.s.blocks[t.source(x, y, z)] != -1 {
+					continue
+				}
+				snapshot.blocks[i], snapshot.liquids[i] = -1, -1
+				if len(snapshot.palette.BlockPositionData) != 0 {
+					delete(snapshot.palette.BlockPositionData, strconv.Itoa(i))
+				}
+			}
+		}
+	}
+	return snapshot
+}
@@ -0,0 +1,96 @@
+package structure
+
+import (
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/world"
+)
+
+// Variant is a block that replaces another block at random, used to break up the uniform look of a Structure
+// using Structure.Vary or WithVariants.
+type Variant struct {
+	// From is the block replaced. It is compared to the blocks in the Structure including its properties.
+	From world.Block
+	// To is the block that replaces From.
+	To world.Block
+	// Chance is the chance that a From block is replaced with To, between 0 and 1. Multiple Variants may have the
+	// same From block, in which case their chances are added up: a Variant replacing cobblestone with mossy
+	// cobblestone at a chance of 0.2 and one replacing it with gravel at a chance of 0.1 leave 70% of the
+	// cobblestone unchanged.
+	Chance float64
+}
+
+// Vary returns a copy of the Structure in which blocks are replaced by the Variants passed at random. Whether a
+// block is replaced depends only on the seed and its position in the Structure, so varying the same Structure
+// twice with the same seed results in the same Structure. Liquids at the position of replaced blocks are kept.
+func (s Structure) Vary(seed int64, variants ...Variant) Structure {
+	c := s.clone()
+
+	type choice struct {
+		ptr    int32
+		data   map[string]interface{}
+		chance float64
+	}
+	choices := map[int32][]choice{}
+	for _, v := range variants {
+		if v.Chance <= 0 {
+			continue
+		}
+		name, properties := v.From.EncodeBlock()
+		from := c.lookup(name, properties)
+		if from == -1 {
+			continue
+		}
+		choices[from] = append(choices[from], choice{ptr: c.ptrFor(v.To), data: encodeNBT(v.To), chance: v.Chance})
+	}
+	if len(choices) == 0 {
+		return c
+	}
+	for offset, index := range c.blocks {
+		options, ok := choices[index]
+		if !ok {
+			continue
+		}
+		pos := c.posOf(offset)
+		r := positionRand(seed, pos[0], pos[1], pos[2])
+		for _, o := range options {
+			if r -= o.chance; r < 0 {
+				liq := c.liquids[offset]
+				c.setIndex(offset, o.ptr, o.data)
+				c.liquids[offset] = liq
+				break
+			}
+		}
+	}
+	return c
+}
+
+// ReplaceGradient returns a copy of the Structure in which all blocks equal to the world.Block from are replaced
+// with a blend of the blocks passed along an axis, as with the Pattern returned by Gradient. The gradient spans the
+// full size of the Structure on the axis, so that the lowest layer holds the first block and the highest layer the
+// last. ReplaceGradient(block.Sandstone{}, cube.Y, seed, block.Sandstone{}, block.Sandstone{Red: true}) for example
+// turns a sandstone tower into one that turns from sandstone at the bottom to red sandstone at the top. Liquids at
+// the position of replaced blocks are kept. If no blocks are passed, the copy returned is unchanged.
+func (s Structure) ReplaceGradient(from world.Block, axis cube.Axis, seed int64, blocks ...world.Block) Structure {
+	c := s.clone()
+	name, properties := from.EncodeBlock()
+	fromPtr := c.lookup(name, properties)
+	if fromPtr == -1 || len(blocks) == 0 {
+		return c
+	}
+	p := Gradient(axis, 0, c.Dimensions()[axisIndex(axis)]-1, seed, blocks...)
+	cache := map[uint64]int32{}
+	for offset, index := range c.blocks {
+		if index != fromPtr {
+			continue
+		}
+		pos := c.posOf(offset)
+		b := p.At(pos[0], pos[1], pos[2])
+		if b == nil {
+			continue
+		}
+		liq := c.liquids[offset]
+		c.setIndex(offset, c.cachedPtrFor(cache, b), encodeNBT(b))
+		c.liquids[offset] = liq
+	}
+	return c
+}
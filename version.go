@@ -0,0 +1,91 @@
+package structure
+
+//go:generate go run gen_version_table.go
+
+import (
+	"fmt"
+	"github.com/df-mc/worldupgrader/blockupgrader"
+)
+
+// GameVersion is a version of Minecraft: Bedrock Edition, such as 1.19.70, as held by the version of block states.
+type GameVersion struct {
+	Major, Minor, Patch, Revision int
+}
+
+// gameVersion returns the GameVersion encoded in the block state version passed, which holds the major, minor,
+// patch and revision numbers of the version in its four bytes, from most to least significant.
+func gameVersion(v int32) GameVersion {
+	return GameVersion{Major: int(v>>24) & 0xff, Minor: int(v>>16) & 0xff, Patch: int(v>>8) & 0xff, Revision: int(v) & 0xff}
+}
+
+// String returns the GameVersion in the form Minecraft displays it, such as 1.19.70. The revision is only included
+// if it is not 0, which is the case for beta versions.
+func (v GameVersion) String() string {
+	if v.Revision != 0 {
+		return fmt.Sprintf("%v.%v.%v.%v", v.Major, v.Minor, v.Patch, v.Revision)
+	}
+	return fmt.Sprintf("%v.%v.%v", v.Major, v.Minor, v.Patch)
+}
+
+// RequiredVersion returns the lowest version of Minecraft: Bedrock Edition needed to represent the Structure
+// faithfully. The version is worked out from the names and block states of the palette entries used by the
+// Structure: every block state change made since Minecraft 1.10, such as a block being renamed or given new
+// properties, is known, so the version needed for an entry is that of the last change to its name or states.
+// Palette entries read from a file in the format of an older version, which the block upgrader upgrades when
+// reading them, instead need the version they were written with, as held by the entry.
+// Blocks that were added to the game without any change to existing block states, such as deepslate, are not
+// known, and neither are blocks that never changed, apart from air. For palette entries of such blocks the version
+// the entry was written with is used, so the version returned may be higher than actually needed, but never lower.
+// Palette entries not used by any block or liquid are ignored. If none of the entries used require a specific
+// version, the zero GameVersion is returned.
+// The version returned is a block state version rather than the version of a released game: block states
+// changed in a beta, for example, hold the version of that beta, such as 1.19.70.15.
+func (s Structure) RequiredVersion() GameVersion {
+	used := make([]bool, len(s.palette.BlockPalette))
+	for _, layer := range [2][]int32{s.blocks, s.liquids} {
+		for _, index := range layer {
+			if index >= 0 && int(index) < len(used) {
+				used[index] = true
+			}
+		}
+	}
+	var max int32
+	for i, bl := range s.palette.BlockPalette {
+		if !used[i] {
+			continue
+		}
+		if v := requiredVersion(bl); v > max {
+			max = v
+		}
+	}
+	return gameVersion(max)
+}
+
+// requiredVersion returns the lowest block state version needed to represent the palette entry passed. Entries in
+// the format of the current version are looked up by their name and states in the tables generated from the block
+// upgrader schemas. Entries in the format of an older version can only have been read from a file, so the
+// version they were written with is returned for them, as it is for entries of blocks not found in the tables.
+func requiredVersion(bl block) int32 {
+	// The block upgrader may change the properties passed to it, so a copy is passed.
+	properties := make(map[string]interface{}, len(bl.States))
+	for k, v := range bl.States {
+		properties[k] = v
+	}
+	upgraded := blockupgrader.Upgrade(blockupgrader.BlockState{Name: bl.Name, Properties: properties, Version: bl.Version})
+	if upgraded.Name != bl.Name || !statesEqual(canonicalStates(upgraded.Properties), canonicalStates(bl.States)) {
+		return bl.Version
+	}
+	if _, ok := knownBlocks[bl.Name]; !ok && bl.Name != "minecraft:air" {
+		return bl.Version
+	}
+	v := blockIntroductions[bl.Name]
+	if c := stateChanges[bl.Name]; c > v {
+		v = c
+	}
+	for k, val := range canonicalStates(bl.States) {
+		if c := valueIntroductions[stateValue{name: bl.Name, property: k, value: val}]; c > v {
+			v = c
+		}
+	}
+	return v
+}
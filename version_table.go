@@ -0,0 +1,1289 @@
+// Code generated by gen_version_table.go; DO NOT EDIT.
+
+package structure
+
+// The tables below are generated from the block state upgrade schemas of github.com/df-mc/worldupgrader, which
+// describe every change made to block states since Minecraft 1.10. The versions are the block state versions
+// of the schemas in which the changes were made. These are not always the versions of released games: changes
+// made in a beta, for example, hold the version of the beta.
+
+const (
+	version1_10_0_50  = 1<<24 | 10<<16 | 0<<8 | 50
+	version1_12_0_1   = 1<<24 | 12<<16 | 0<<8 | 1
+	version1_14_0_3   = 1<<24 | 14<<16 | 0<<8 | 3
+	version1_15_0_0   = 1<<24 | 15<<16 | 0<<8 | 0
+	version1_16_0_0   = 1<<24 | 16<<16 | 0<<8 | 0
+	version1_16_0_9   = 1<<24 | 16<<16 | 0<<8 | 9
+	version1_16_0_14  = 1<<24 | 16<<16 | 0<<8 | 14
+	version1_16_0_16  = 1<<24 | 16<<16 | 0<<8 | 16
+	version1_16_210_3 = 1<<24 | 16<<16 | 210<<8 | 3
+	version1_18_10_1  = 1<<24 | 18<<16 | 10<<8 | 1
+	version1_19_70_15 = 1<<24 | 19<<16 | 70<<8 | 15
+)
+
+// knownBlocks holds the names of all blocks changed by any of the schemas, by either their old or new name.
+var knownBlocks = map[string]struct{}{
+	"minecraft:acacia_button":                  {},
+	"minecraft:acacia_wall_sign":               {},
+	"minecraft:activator_rail":                 {},
+	"minecraft:barrel":                         {},
+	"minecraft:basalt":                         {},
+	"minecraft:basalt_block":                   {},
+	"minecraft:bee_nest":                       {},
+	"minecraft:beehive":                        {},
+	"minecraft:bell":                           {},
+	"minecraft:birch_button":                   {},
+	"minecraft:birch_wall_sign":                {},
+	"minecraft:black_glazed_terracotta":        {},
+	"minecraft:black_wool":                     {},
+	"minecraft:blackstone_wall":                {},
+	"minecraft:blast_furnace":                  {},
+	"minecraft:blue_fire":                      {},
+	"minecraft:blue_glazed_terracotta":         {},
+	"minecraft:blue_nether_wart_block":         {},
+	"minecraft:blue_wool":                      {},
+	"minecraft:bone_block":                     {},
+	"minecraft:brown_glazed_terracotta":        {},
+	"minecraft:brown_mushroom_block":           {},
+	"minecraft:brown_wool":                     {},
+	"minecraft:cake":                           {},
+	"minecraft:campfire":                       {},
+	"minecraft:cauldron":                       {},
+	"minecraft:chain":                          {},
+	"minecraft:chain_command_block":            {},
+	"minecraft:chemistry_table":                {},
+	"minecraft:chest":                          {},
+	"minecraft:chiseled_bookshelf":             {},
+	"minecraft:chorus_flower":                  {},
+	"minecraft:cobblestone_wall":               {},
+	"minecraft:cocoa":                          {},
+	"minecraft:command_block":                  {},
+	"minecraft:composter":                      {},
+	"minecraft:concretePowder":                 {},
+	"minecraft:concrete_powder":                {},
+	"minecraft:coral":                          {},
+	"minecraft:coral_block":                    {},
+	"minecraft:coral_fan":                      {},
+	"minecraft:coral_fan_dead":                 {},
+	"minecraft:coral_fan_hang":                 {},
+	"minecraft:coral_fan_hang2":                {},
+	"minecraft:coral_fan_hang3":                {},
+	"minecraft:crimson_trap_door":              {},
+	"minecraft:crimson_trapdoor":               {},
+	"minecraft:cyan_glazed_terracotta":         {},
+	"minecraft:cyan_wool":                      {},
+	"minecraft:dark_oak_button":                {},
+	"minecraft:darkoak_wall_sign":              {},
+	"minecraft:detector_rail":                  {},
+	"minecraft:dirt":                           {},
+	"minecraft:dispenser":                      {},
+	"minecraft:double_plant":                   {},
+	"minecraft:double_stone_block_slab":        {},
+	"minecraft:double_stone_block_slab2":       {},
+	"minecraft:double_stone_block_slab3":       {},
+	"minecraft:double_stone_block_slab4":       {},
+	"minecraft:double_stone_slab":              {},
+	"minecraft:double_stone_slab2":             {},
+	"minecraft:double_stone_slab3":             {},
+	"minecraft:double_stone_slab4":             {},
+	"minecraft:double_wooden_slab":             {},
+	"minecraft:dropper":                        {},
+	"minecraft:element_0":                      {},
+	"minecraft:element_1":                      {},
+	"minecraft:element_10":                     {},
+	"minecraft:element_100":                    {},
+	"minecraft:element_101":                    {},
+	"minecraft:element_102":                    {},
+	"minecraft:element_103":                    {},
+	"minecraft:element_104":                    {},
+	"minecraft:element_105":                    {},
+	"minecraft:element_106":                    {},
+	"minecraft:element_107":                    {},
+	"minecraft:element_108":                    {},
+	"minecraft:element_109":                    {},
+	"minecraft:element_11":                     {},
+	"minecraft:element_110":                    {},
+	"minecraft:element_111":                    {},
+	"minecraft:element_112":                    {},
+	"minecraft:element_113":                    {},
+	"minecraft:element_114":                    {},
+	"minecraft:element_115":                    {},
+	"minecraft:element_116":                    {},
+	"minecraft:element_117":                    {},
+	"minecraft:element_118":                    {},
+	"minecraft:element_12":                     {},
+	"minecraft:element_13":                     {},
+	"minecraft:element_14":                     {},
+	"minecraft:element_15":                     {},
+	"minecraft:element_16":                     {},
+	"minecraft:element_17":                     {},
+	"minecraft:element_18":                     {},
+	"minecraft:element_19":                     {},
+	"minecraft:element_2":                      {},
+	"minecraft:element_20":                     {},
+	"minecraft:element_21":                     {},
+	"minecraft:element_22":                     {},
+	"minecraft:element_23":                     {},
+	"minecraft:element_24":                     {},
+	"minecraft:element_25":                     {},
+	"minecraft:element_26":                     {},
+	"minecraft:element_27":                     {},
+	"minecraft:element_28":                     {},
+	"minecraft:element_29":                     {},
+	"minecraft:element_3":                      {},
+	"minecraft:element_30":                     {},
+	"minecraft:element_31":                     {},
+	"minecraft:element_32":                     {},
+	"minecraft:element_33":                     {},
+	"minecraft:element_34":                     {},
+	"minecraft:element_35":                     {},
+	"minecraft:element_36":                     {},
+	"minecraft:element_37":                     {},
+	"minecraft:element_38":                     {},
+	"minecraft:element_39":                     {},
+	"minecraft:element_4":                      {},
+	"minecraft:element_40":                     {},
+	"minecraft:element_41":                     {},
+	"minecraft:element_42":                     {},
+	"minecraft:element_43":                     {},
+	"minecraft:element_44":                     {},
+	"minecraft:element_45":                     {},
+	"minecraft:element_46":                     {},
+	"minecraft:element_47":                     {},
+	"minecraft:element_48":                     {},
+	"minecraft:element_49":                     {},
+	"minecraft:element_5":                      {},
+	"minecraft:element_50":                     {},
+	"minecraft:element_51":                     {},
+	"minecraft:element_52":                     {},
+	"minecraft:element_53":                     {},
+	"minecraft:element_54":                     {},
+	"minecraft:element_55":                     {},
+	"minecraft:element_56":                     {},
+	"minecraft:element_57":                     {},
+	"minecraft:element_58":                     {},
+	"minecraft:element_59":                     {},
+	"minecraft:element_6":                      {},
+	"minecraft:element_60":                     {},
+	"minecraft:element_61":                     {},
+	"minecraft:element_62":                     {},
+	"minecraft:element_63":                     {},
+	"minecraft:element_64":                     {},
+	"minecraft:element_65":                     {},
+	"minecraft:element_66":                     {},
+	"minecraft:element_67":                     {},
+	"minecraft:element_68":                     {},
+	"minecraft:element_69":                     {},
+	"minecraft:element_7":                      {},
+	"minecraft:element_70":                     {},
+	"minecraft:element_71":                     {},
+	"minecraft:element_72":                     {},
+	"minecraft:element_73":                     {},
+	"minecraft:element_74":                     {},
+	"minecraft:element_75":                     {},
+	"minecraft:element_76":                     {},
+	"minecraft:element_77":                     {},
+	"minecraft:element_78":                     {},
+	"minecraft:element_79":                     {},
+	"minecraft:element_8":                      {},
+	"minecraft:element_80":                     {},
+	"minecraft:element_81":                     {},
+	"minecraft:element_82":                     {},
+	"minecraft:element_83":                     {},
+	"minecraft:element_84":                     {},
+	"minecraft:element_85":                     {},
+	"minecraft:element_86":                     {},
+	"minecraft:element_87":                     {},
+	"minecraft:element_88":                     {},
+	"minecraft:element_89":                     {},
+	"minecraft:element_9":                      {},
+	"minecraft:element_90":                     {},
+	"minecraft:element_91":                     {},
+	"minecraft:element_92":                     {},
+	"minecraft:element_93":                     {},
+	"minecraft:element_94":                     {},
+	"minecraft:element_95":                     {},
+	"minecraft:element_96":                     {},
+	"minecraft:element_97":                     {},
+	"minecraft:element_98":                     {},
+	"minecraft:element_99":                     {},
+	"minecraft:end_rod":                        {},
+	"minecraft:ender_chest":                    {},
+	"minecraft:fence":                          {},
+	"minecraft:frame":                          {},
+	"minecraft:frog_egg":                       {},
+	"minecraft:frog_spawn":                     {},
+	"minecraft:furnace":                        {},
+	"minecraft:glow_frame":                     {},
+	"minecraft:glow_lichen":                    {},
+	"minecraft:golden_rail":                    {},
+	"minecraft:grass":                          {},
+	"minecraft:gray_glazed_terracotta":         {},
+	"minecraft:gray_wool":                      {},
+	"minecraft:green_glazed_terracotta":        {},
+	"minecraft:green_wool":                     {},
+	"minecraft:grindstone":                     {},
+	"minecraft:hay_block":                      {},
+	"minecraft:hopper":                         {},
+	"minecraft:invisibleBedrock":               {},
+	"minecraft:invisible_bedrock":              {},
+	"minecraft:jigsaw":                         {},
+	"minecraft:jungle_button":                  {},
+	"minecraft:jungle_wall_sign":               {},
+	"minecraft:kelp":                           {},
+	"minecraft:ladder":                         {},
+	"minecraft:lava_cauldron":                  {},
+	"minecraft:leaves":                         {},
+	"minecraft:leaves2":                        {},
+	"minecraft:lectern":                        {},
+	"minecraft:lever":                          {},
+	"minecraft:light_block":                    {},
+	"minecraft:light_blue_glazed_terracotta":   {},
+	"minecraft:light_blue_wool":                {},
+	"minecraft:light_gray_wool":                {},
+	"minecraft:lime_glazed_terracotta":         {},
+	"minecraft:lime_wool":                      {},
+	"minecraft:lit_blast_furnace":              {},
+	"minecraft:lit_furnace":                    {},
+	"minecraft:lit_smoker":                     {},
+	"minecraft:lodestone":                      {},
+	"minecraft:lodestone_block":                {},
+	"minecraft:log":                            {},
+	"minecraft:log2":                           {},
+	"minecraft:magenta_glazed_terracotta":      {},
+	"minecraft:magenta_wool":                   {},
+	"minecraft:mangrove_propagule":             {},
+	"minecraft:mangrove_propagule_hanging":     {},
+	"minecraft:melon_stem":                     {},
+	"minecraft:monster_egg":                    {},
+	"minecraft:movingBlock":                    {},
+	"minecraft:moving_block":                   {},
+	"minecraft:muddy_mangrove_roots":           {},
+	"minecraft:mysterious_frame":               {},
+	"minecraft:mysterious_frame_slot":          {},
+	"minecraft:nether_brick_fence":             {},
+	"minecraft:observer":                       {},
+	"minecraft:ochre_froglight":                {},
+	"minecraft:orange_glazed_terracotta":       {},
+	"minecraft:orange_wool":                    {},
+	"minecraft:pearlescent_froglight":          {},
+	"minecraft:pink_glazed_terracotta":         {},
+	"minecraft:pink_wool":                      {},
+	"minecraft:piston":                         {},
+	"minecraft:pistonArmCollision":             {},
+	"minecraft:piston_arm_collision":           {},
+	"minecraft:planks":                         {},
+	"minecraft:polished_basalt":                {},
+	"minecraft:polished_basalt_block":          {},
+	"minecraft:polished_blackstone_brick_wall": {},
+	"minecraft:polished_blackstone_wall":       {},
+	"minecraft:portal":                         {},
+	"minecraft:prismarine":                     {},
+	"minecraft:pumpkin_stem":                   {},
+	"minecraft:purple_glazed_terracotta":       {},
+	"minecraft:purple_wool":                    {},
+	"minecraft:purpur_block":                   {},
+	"minecraft:quartz_block":                   {},
+	"minecraft:rail":                           {},
+	"minecraft:red_flower":                     {},
+	"minecraft:red_glazed_terracotta":          {},
+	"minecraft:red_mushroom_block":             {},
+	"minecraft:red_sandstone":                  {},
+	"minecraft:red_wool":                       {},
+	"minecraft:reinforced_deepslate":           {},
+	"minecraft:repeating_command_block":        {},
+	"minecraft:sand":                           {},
+	"minecraft:sandstone":                      {},
+	"minecraft:sculk_catalyst":                 {},
+	"minecraft:sculk_shrieker":                 {},
+	"minecraft:sculk_vein":                     {},
+	"minecraft:seaLantern":                     {},
+	"minecraft:sea_lantern":                    {},
+	"minecraft:seagrass":                       {},
+	"minecraft:shroomlight":                    {},
+	"minecraft:shroomlight_block":              {},
+	"minecraft:silver_glazed_terracotta":       {},
+	"minecraft:skull":                          {},
+	"minecraft:smoker":                         {},
+	"minecraft:soul_fire":                      {},
+	"minecraft:soul_soil":                      {},
+	"minecraft:soul_soil_block":                {},
+	"minecraft:sponge":                         {},
+	"minecraft:spruce_button":                  {},
+	"minecraft:spruce_wall_sign":               {},
+	"minecraft:stickyPistonArmCollision":       {},
+	"minecraft:sticky_piston":                  {},
+	"minecraft:sticky_piston_arm_collision":    {},
+	"minecraft:stone":                          {},
+	"minecraft:stone_block_slab":               {},
+	"minecraft:stone_block_slab2":              {},
+	"minecraft:stone_block_slab3":              {},
+	"minecraft:stone_block_slab4":              {},
+	"minecraft:stone_button":                   {},
+	"minecraft:stone_slab":                     {},
+	"minecraft:stone_slab2":                    {},
+	"minecraft:stone_slab3":                    {},
+	"minecraft:stone_slab4":                    {},
+	"minecraft:stonebrick":                     {},
+	"minecraft:stonecutter_block":              {},
+	"minecraft:stripped_acacia_log":            {},
+	"minecraft:stripped_birch_log":             {},
+	"minecraft:stripped_crimson_hyphae":        {},
+	"minecraft:stripped_crimson_stem":          {},
+	"minecraft:stripped_dark_oak_log":          {},
+	"minecraft:stripped_jungle_log":            {},
+	"minecraft:stripped_oak_log":               {},
+	"minecraft:stripped_spruce_log":            {},
+	"minecraft:stripped_warped_hyphae":         {},
+	"minecraft:stripped_warped_stem":           {},
+	"minecraft:structure_block":                {},
+	"minecraft:tallgrass":                      {},
+	"minecraft:target":                         {},
+	"minecraft:target_block":                   {},
+	"minecraft:trapped_chest":                  {},
+	"minecraft:tripWire":                       {},
+	"minecraft:trip_wire":                      {},
+	"minecraft:twisting_vines":                 {},
+	"minecraft:twisting_vines_block":           {},
+	"minecraft:verdant_froglight":              {},
+	"minecraft:wall_banner":                    {},
+	"minecraft:wall_sign":                      {},
+	"minecraft:warped_wart_block":              {},
+	"minecraft:weeping_vines":                  {},
+	"minecraft:weeping_vines_block":            {},
+	"minecraft:white_glazed_terracotta":        {},
+	"minecraft:white_wool":                     {},
+	"minecraft:wood":                           {},
+	"minecraft:wooden_button":                  {},
+	"minecraft:wooden_slab":                    {},
+	"minecraft:wool":                           {},
+	"minecraft:yellow_flower":                  {},
+	"minecraft:yellow_glazed_terracotta":       {},
+	"minecraft:yellow_wool":                    {},
+}
+
+// blockIntroductions holds the block state version in which blocks were given their current name, by that name.
+var blockIntroductions = map[string]int32{
+	"minecraft:basalt":                      version1_16_0_9,
+	"minecraft:black_wool":                  version1_19_70_15,
+	"minecraft:blue_wool":                   version1_19_70_15,
+	"minecraft:brown_wool":                  version1_19_70_15,
+	"minecraft:concrete_powder":             version1_18_10_1,
+	"minecraft:crimson_trapdoor":            version1_16_0_9,
+	"minecraft:cyan_wool":                   version1_19_70_15,
+	"minecraft:double_stone_block_slab":     version1_18_10_1,
+	"minecraft:double_stone_block_slab2":    version1_18_10_1,
+	"minecraft:double_stone_block_slab3":    version1_18_10_1,
+	"minecraft:double_stone_block_slab4":    version1_18_10_1,
+	"minecraft:frog_spawn":                  version1_18_10_1,
+	"minecraft:gray_wool":                   version1_19_70_15,
+	"minecraft:green_wool":                  version1_19_70_15,
+	"minecraft:invisible_bedrock":           version1_18_10_1,
+	"minecraft:light_block":                 version1_14_0_3,
+	"minecraft:light_blue_wool":             version1_19_70_15,
+	"minecraft:light_gray_wool":             version1_19_70_15,
+	"minecraft:lime_wool":                   version1_19_70_15,
+	"minecraft:lodestone":                   version1_16_0_14,
+	"minecraft:magenta_wool":                version1_19_70_15,
+	"minecraft:moving_block":                version1_18_10_1,
+	"minecraft:orange_wool":                 version1_19_70_15,
+	"minecraft:pink_wool":                   version1_19_70_15,
+	"minecraft:piston_arm_collision":        version1_18_10_1,
+	"minecraft:polished_basalt":             version1_16_0_9,
+	"minecraft:purple_wool":                 version1_19_70_15,
+	"minecraft:red_wool":                    version1_19_70_15,
+	"minecraft:reinforced_deepslate":        version1_18_10_1,
+	"minecraft:sea_lantern":                 version1_18_10_1,
+	"minecraft:shroomlight":                 version1_16_0_9,
+	"minecraft:soul_fire":                   version1_16_0_9,
+	"minecraft:soul_soil":                   version1_16_0_9,
+	"minecraft:sticky_piston_arm_collision": version1_18_10_1,
+	"minecraft:stone_block_slab":            version1_18_10_1,
+	"minecraft:stone_block_slab2":           version1_18_10_1,
+	"minecraft:stone_block_slab3":           version1_18_10_1,
+	"minecraft:stone_block_slab4":           version1_18_10_1,
+	"minecraft:target":                      version1_16_0_9,
+	"minecraft:trip_wire":                   version1_18_10_1,
+	"minecraft:twisting_vines":              version1_16_0_14,
+	"minecraft:warped_wart_block":           version1_16_0_9,
+	"minecraft:weeping_vines":               version1_16_0_9,
+	"minecraft:white_wool":                  version1_19_70_15,
+	"minecraft:wood":                        version1_10_0_50,
+	"minecraft:yellow_wool":                 version1_19_70_15,
+}
+
+// stateChanges holds the last block state version in which properties were added to, removed from or renamed in
+// the block states of blocks, by the current name of the block.
+var stateChanges = map[string]int32{
+	"minecraft:barrel":                         version1_12_0_1,
+	"minecraft:bee_nest":                       version1_16_0_16,
+	"minecraft:beehive":                        version1_16_0_16,
+	"minecraft:bell":                           version1_12_0_1,
+	"minecraft:blackstone_wall":                version1_16_0_14,
+	"minecraft:blast_furnace":                  version1_12_0_1,
+	"minecraft:bone_block":                     version1_14_0_3,
+	"minecraft:brown_mushroom_block":           version1_10_0_50,
+	"minecraft:campfire":                       version1_12_0_1,
+	"minecraft:chain":                          version1_16_0_16,
+	"minecraft:chemistry_table":                version1_10_0_50,
+	"minecraft:chiseled_bookshelf":             version1_18_10_1,
+	"minecraft:cobblestone_wall":               version1_16_0_0,
+	"minecraft:composter":                      version1_12_0_1,
+	"minecraft:coral":                          version1_14_0_3,
+	"minecraft:coral_block":                    version1_10_0_50,
+	"minecraft:coral_fan":                      version1_12_0_1,
+	"minecraft:coral_fan_dead":                 version1_12_0_1,
+	"minecraft:coral_fan_hang":                 version1_10_0_50,
+	"minecraft:coral_fan_hang2":                version1_10_0_50,
+	"minecraft:coral_fan_hang3":                version1_10_0_50,
+	"minecraft:dirt":                           version1_10_0_50,
+	"minecraft:double_plant":                   version1_10_0_50,
+	"minecraft:double_stone_slab":              version1_10_0_50,
+	"minecraft:double_stone_slab2":             version1_10_0_50,
+	"minecraft:double_stone_slab3":             version1_10_0_50,
+	"minecraft:double_stone_slab4":             version1_10_0_50,
+	"minecraft:double_wooden_slab":             version1_10_0_50,
+	"minecraft:element_0":                      version1_10_0_50,
+	"minecraft:element_1":                      version1_10_0_50,
+	"minecraft:element_10":                     version1_10_0_50,
+	"minecraft:element_100":                    version1_10_0_50,
+	"minecraft:element_101":                    version1_10_0_50,
+	"minecraft:element_102":                    version1_10_0_50,
+	"minecraft:element_103":                    version1_10_0_50,
+	"minecraft:element_104":                    version1_10_0_50,
+	"minecraft:element_105":                    version1_10_0_50,
+	"minecraft:element_106":                    version1_10_0_50,
+	"minecraft:element_107":                    version1_10_0_50,
+	"minecraft:element_108":                    version1_10_0_50,
+	"minecraft:element_109":                    version1_10_0_50,
+	"minecraft:element_11":                     version1_10_0_50,
+	"minecraft:element_110":                    version1_10_0_50,
+	"minecraft:element_111":                    version1_10_0_50,
+	"minecraft:element_112":                    version1_10_0_50,
+	"minecraft:element_113":                    version1_10_0_50,
+	"minecraft:element_114":                    version1_10_0_50,
+	"minecraft:element_115":                    version1_10_0_50,
+	"minecraft:element_116":                    version1_10_0_50,
+	"minecraft:element_117":                    version1_10_0_50,
+	"minecraft:element_118":                    version1_10_0_50,
+	"minecraft:element_12":                     version1_10_0_50,
+	"minecraft:element_13":                     version1_10_0_50,
+	"minecraft:element_14":                     version1_10_0_50,
+	"minecraft:element_15":                     version1_10_0_50,
+	"minecraft:element_16":                     version1_10_0_50,
+	"minecraft:element_17":                     version1_10_0_50,
+	"minecraft:element_18":                     version1_10_0_50,
+	"minecraft:element_19":                     version1_10_0_50,
+	"minecraft:element_2":                      version1_10_0_50,
+	"minecraft:element_20":                     version1_10_0_50,
+	"minecraft:element_21":                     version1_10_0_50,
+	"minecraft:element_22":                     version1_10_0_50,
+	"minecraft:element_23":                     version1_10_0_50,
+	"minecraft:element_24":                     version1_10_0_50,
+	"minecraft:element_25":                     version1_10_0_50,
+	"minecraft:element_26":                     version1_10_0_50,
+	"minecraft:element_27":                     version1_10_0_50,
+	"minecraft:element_28":                     version1_10_0_50,
+	"minecraft:element_29":                     version1_10_0_50,
+	"minecraft:element_3":                      version1_10_0_50,
+	"minecraft:element_30":                     version1_10_0_50,
+	"minecraft:element_31":                     version1_10_0_50,
+	"minecraft:element_32":                     version1_10_0_50,
+	"minecraft:element_33":                     version1_10_0_50,
+	"minecraft:element_34":                     version1_10_0_50,
+	"minecraft:element_35":                     version1_10_0_50,
+	"minecraft:element_36":                     version1_10_0_50,
+	"minecraft:element_37":                     version1_10_0_50,
+	"minecraft:element_38":                     version1_10_0_50,
+	"minecraft:element_39":                     version1_10_0_50,
+	"minecraft:element_4":                      version1_10_0_50,
+	"minecraft:element_40":                     version1_10_0_50,
+	"minecraft:element_41":                     version1_10_0_50,
+	"minecraft:element_42":                     version1_10_0_50,
+	"minecraft:element_43":                     version1_10_0_50,
+	"minecraft:element_44":                     version1_10_0_50,
+	"minecraft:element_45":                     version1_10_0_50,
+	"minecraft:element_46":                     version1_10_0_50,
+	"minecraft:element_47":                     version1_10_0_50,
+	"minecraft:element_48":                     version1_10_0_50,
+	"minecraft:element_49":                     version1_10_0_50,
+	"minecraft:element_5":                      version1_10_0_50,
+	"minecraft:element_50":                     version1_10_0_50,
+	"minecraft:element_51":                     version1_10_0_50,
+	"minecraft:element_52":                     version1_10_0_50,
+	"minecraft:element_53":                     version1_10_0_50,
+	"minecraft:element_54":                     version1_10_0_50,
+	"minecraft:element_55":                     version1_10_0_50,
+	"minecraft:element_56":                     version1_10_0_50,
+	"minecraft:element_57":                     version1_10_0_50,
+	"minecraft:element_58":                     version1_10_0_50,
+	"minecraft:element_59":                     version1_10_0_50,
+	"minecraft:element_6":                      version1_10_0_50,
+	"minecraft:element_60":                     version1_10_0_50,
+	"minecraft:element_61":                     version1_10_0_50,
+	"minecraft:element_62":                     version1_10_0_50,
+	"minecraft:element_63":                     version1_10_0_50,
+	"minecraft:element_64":                     version1_10_0_50,
+	"minecraft:element_65":                     version1_10_0_50,
+	"minecraft:element_66":                     version1_10_0_50,
+	"minecraft:element_67":                     version1_10_0_50,
+	"minecraft:element_68":                     version1_10_0_50,
+	"minecraft:element_69":                     version1_10_0_50,
+	"minecraft:element_7":                      version1_10_0_50,
+	"minecraft:element_70":                     version1_10_0_50,
+	"minecraft:element_71":                     version1_10_0_50,
+	"minecraft:element_72":                     version1_10_0_50,
+	"minecraft:element_73":                     version1_10_0_50,
+	"minecraft:element_74":                     version1_10_0_50,
+	"minecraft:element_75":                     version1_10_0_50,
+	"minecraft:element_76":                     version1_10_0_50,
+	"minecraft:element_77":                     version1_10_0_50,
+	"minecraft:element_78":                     version1_10_0_50,
+	"minecraft:element_79":                     version1_10_0_50,
+	"minecraft:element_8":                      version1_10_0_50,
+	"minecraft:element_80":                     version1_10_0_50,
+	"minecraft:element_81":                     version1_10_0_50,
+	"minecraft:element_82":                     version1_10_0_50,
+	"minecraft:element_83":                     version1_10_0_50,
+	"minecraft:element_84":                     version1_10_0_50,
+	"minecraft:element_85":                     version1_10_0_50,
+	"minecraft:element_86":                     version1_10_0_50,
+	"minecraft:element_87":                     version1_10_0_50,
+	"minecraft:element_88":                     version1_10_0_50,
+	"minecraft:element_89":                     version1_10_0_50,
+	"minecraft:element_9":                      version1_10_0_50,
+	"minecraft:element_90":                     version1_10_0_50,
+	"minecraft:element_91":                     version1_10_0_50,
+	"minecraft:element_92":                     version1_10_0_50,
+	"minecraft:element_93":                     version1_10_0_50,
+	"minecraft:element_94":                     version1_10_0_50,
+	"minecraft:element_95":                     version1_10_0_50,
+	"minecraft:element_96":                     version1_10_0_50,
+	"minecraft:element_97":                     version1_10_0_50,
+	"minecraft:element_98":                     version1_10_0_50,
+	"minecraft:element_99":                     version1_10_0_50,
+	"minecraft:fence":                          version1_10_0_50,
+	"minecraft:frame":                          version1_16_210_3,
+	"minecraft:glow_frame":                     version1_16_210_3,
+	"minecraft:grass":                          version1_10_0_50,
+	"minecraft:grindstone":                     version1_10_0_50,
+	"minecraft:hay_block":                      version1_14_0_3,
+	"minecraft:jigsaw":                         version1_16_0_0,
+	"minecraft:kelp":                           version1_15_0_0,
+	"minecraft:leaves":                         version1_10_0_50,
+	"minecraft:leaves2":                        version1_10_0_50,
+	"minecraft:lectern":                        version1_12_0_1,
+	"minecraft:lever":                          version1_14_0_3,
+	"minecraft:log":                            version1_14_0_3,
+	"minecraft:log2":                           version1_14_0_3,
+	"minecraft:mangrove_propagule":             version1_18_10_1,
+	"minecraft:melon_stem":                     version1_16_0_14,
+	"minecraft:monster_egg":                    version1_10_0_50,
+	"minecraft:muddy_mangrove_roots":           version1_18_10_1,
+	"minecraft:nether_brick_fence":             version1_10_0_50,
+	"minecraft:ochre_froglight":                version1_18_10_1,
+	"minecraft:pearlescent_froglight":          version1_18_10_1,
+	"minecraft:planks":                         version1_10_0_50,
+	"minecraft:polished_blackstone_brick_wall": version1_16_0_14,
+	"minecraft:polished_blackstone_wall":       version1_16_0_14,
+	"minecraft:portal":                         version1_10_0_50,
+	"minecraft:prismarine":                     version1_10_0_50,
+	"minecraft:pumpkin_stem":                   version1_16_0_14,
+	"minecraft:purpur_block":                   version1_14_0_3,
+	"minecraft:quartz_block":                   version1_14_0_3,
+	"minecraft:red_flower":                     version1_10_0_50,
+	"minecraft:red_mushroom_block":             version1_10_0_50,
+	"minecraft:red_sandstone":                  version1_10_0_50,
+	"minecraft:sand":                           version1_10_0_50,
+	"minecraft:sandstone":                      version1_10_0_50,
+	"minecraft:sculk_catalyst":                 version1_16_210_3,
+	"minecraft:sculk_shrieker":                 version1_18_10_1,
+	"minecraft:seagrass":                       version1_10_0_50,
+	"minecraft:skull":                          version1_18_10_1,
+	"minecraft:smoker":                         version1_12_0_1,
+	"minecraft:sponge":                         version1_10_0_50,
+	"minecraft:stone":                          version1_10_0_50,
+	"minecraft:stone_slab":                     version1_10_0_50,
+	"minecraft:stone_slab2":                    version1_10_0_50,
+	"minecraft:stone_slab3":                    version1_10_0_50,
+	"minecraft:stone_slab4":                    version1_10_0_50,
+	"minecraft:stonebrick":                     version1_10_0_50,
+	"minecraft:stripped_acacia_log":            version1_14_0_3,
+	"minecraft:stripped_birch_log":             version1_14_0_3,
+	"minecraft:stripped_crimson_hyphae":        version1_16_210_3,
+	"minecraft:stripped_crimson_stem":          version1_16_210_3,
+	"minecraft:stripped_dark_oak_log":          version1_14_0_3,
+	"minecraft:stripped_jungle_log":            version1_14_0_3,
+	"minecraft:stripped_oak_log":               version1_14_0_3,
+	"minecraft:stripped_spruce_log":            version1_14_0_3,
+	"minecraft:stripped_warped_hyphae":         version1_16_210_3,
+	"minecraft:stripped_warped_stem":           version1_16_210_3,
+	"minecraft:structure_block":                version1_10_0_50,
+	"minecraft:tallgrass":                      version1_10_0_50,
+	"minecraft:verdant_froglight":              version1_18_10_1,
+	"minecraft:wood":                           version1_14_0_3,
+	"minecraft:wooden_slab":                    version1_10_0_50,
+	"minecraft:yellow_flower":                  version1_10_0_50,
+}
+
+// stateValue is a value of a block state property of a block.
+type stateValue struct {
+	name, property string
+	value          interface{}
+}
+
+// valueIntroductions holds the block state version in which property values were introduced, by the block,
+// property and value.
+var valueIntroductions = map[stateValue]int32{
+	{"minecraft:acacia_button", "facing_direction", int32(0)}:                version1_14_0_3,
+	{"minecraft:acacia_wall_sign", "facing_direction", int32(0)}:             version1_14_0_3,
+	{"minecraft:activator_rail", "rail_direction", int32(0)}:                 version1_14_0_3,
+	{"minecraft:barrel", "facing_direction", int32(0)}:                       version1_14_0_3,
+	{"minecraft:bee_nest", "facing_direction", int32(0)}:                     version1_16_0_16,
+	{"minecraft:beehive", "facing_direction", int32(0)}:                      version1_16_0_16,
+	{"minecraft:birch_button", "facing_direction", int32(0)}:                 version1_14_0_3,
+	{"minecraft:birch_wall_sign", "facing_direction", int32(0)}:              version1_14_0_3,
+	{"minecraft:black_glazed_terracotta", "facing_direction", int32(0)}:      version1_14_0_3,
+	{"minecraft:blast_furnace", "direction", int32(4)}:                       version1_12_0_1,
+	{"minecraft:blast_furnace", "direction", int32(5)}:                       version1_12_0_1,
+	{"minecraft:blast_furnace", "facing_direction", int32(0)}:                version1_14_0_3,
+	{"minecraft:blast_furnace", "facing_direction", int32(2)}:                version1_10_0_50,
+	{"minecraft:blue_glazed_terracotta", "facing_direction", int32(0)}:       version1_14_0_3,
+	{"minecraft:bone_block", "direction", "x"}:                               version1_14_0_3,
+	{"minecraft:bone_block", "direction", "y"}:                               version1_14_0_3,
+	{"minecraft:bone_block", "direction", "z"}:                               version1_14_0_3,
+	{"minecraft:bone_block", "direction", int32(3)}:                          version1_10_0_50,
+	{"minecraft:bone_block", "mapped_type", int32(0)}:                        version1_10_0_50,
+	{"minecraft:brown_glazed_terracotta", "facing_direction", int32(0)}:      version1_14_0_3,
+	{"minecraft:cake", "bite_counter", int32(0)}:                             version1_14_0_3,
+	{"minecraft:cauldron", "fill_level", int32(6)}:                           version1_14_0_3,
+	{"minecraft:chain_command_block", "facing_direction", int32(0)}:          version1_14_0_3,
+	{"minecraft:chemistry_table", "mapped_type", "compound_creator"}:         version1_10_0_50,
+	{"minecraft:chemistry_table", "mapped_type", "element_constructor"}:      version1_10_0_50,
+	{"minecraft:chemistry_table", "mapped_type", "lab_table"}:                version1_10_0_50,
+	{"minecraft:chemistry_table", "mapped_type", "material_reducer"}:         version1_10_0_50,
+	{"minecraft:chest", "facing_direction", int32(0)}:                        version1_14_0_3,
+	{"minecraft:chorus_flower", "age", int32(0)}:                             version1_14_0_3,
+	{"minecraft:cobblestone_wall", "mapped_type", "andesite"}:                version1_10_0_50,
+	{"minecraft:cobblestone_wall", "mapped_type", "brick"}:                   version1_10_0_50,
+	{"minecraft:cobblestone_wall", "mapped_type", "cobblestone"}:             version1_10_0_50,
+	{"minecraft:cobblestone_wall", "mapped_type", "diorite"}:                 version1_10_0_50,
+	{"minecraft:cobblestone_wall", "mapped_type", "end_brick"}:               version1_10_0_50,
+	{"minecraft:cobblestone_wall", "mapped_type", "granite"}:                 version1_10_0_50,
+	{"minecraft:cobblestone_wall", "mapped_type", "mossy_cobblestone"}:       version1_10_0_50,
+	{"minecraft:cobblestone_wall", "mapped_type", "mossy_stone_brick"}:       version1_10_0_50,
+	{"minecraft:cobblestone_wall", "mapped_type", "nether_brick"}:            version1_10_0_50,
+	{"minecraft:cobblestone_wall", "mapped_type", "prismarine"}:              version1_10_0_50,
+	{"minecraft:cobblestone_wall", "mapped_type", "red_nether_brick"}:        version1_10_0_50,
+	{"minecraft:cobblestone_wall", "mapped_type", "red_sandstone"}:           version1_10_0_50,
+	{"minecraft:cobblestone_wall", "mapped_type", "sandstone"}:               version1_10_0_50,
+	{"minecraft:cobblestone_wall", "mapped_type", "stone_brick"}:             version1_10_0_50,
+	{"minecraft:cobblestone_wall", "wall_block_type", "cobblestone"}:         version1_16_0_14,
+	{"minecraft:cocoa", "age", int32(0)}:                                     version1_14_0_3,
+	{"minecraft:command_block", "facing_direction", int32(0)}:                version1_14_0_3,
+	{"minecraft:composter", "composter_fill_level", int32(0)}:                version1_14_0_3,
+	{"minecraft:coral", "mapped_type", "blue"}:                               version1_10_0_50,
+	{"minecraft:coral", "mapped_type", "pink"}:                               version1_10_0_50,
+	{"minecraft:coral", "mapped_type", "purple"}:                             version1_10_0_50,
+	{"minecraft:coral", "mapped_type", "red"}:                                version1_10_0_50,
+	{"minecraft:coral", "mapped_type", "yellow"}:                             version1_10_0_50,
+	{"minecraft:coral_block", "mapped_type", "blue"}:                         version1_10_0_50,
+	{"minecraft:coral_block", "mapped_type", "pink"}:                         version1_10_0_50,
+	{"minecraft:coral_block", "mapped_type", "purple"}:                       version1_10_0_50,
+	{"minecraft:coral_block", "mapped_type", "red"}:                          version1_10_0_50,
+	{"minecraft:coral_block", "mapped_type", "yellow"}:                       version1_10_0_50,
+	{"minecraft:coral_fan", "mapped_type", "blue"}:                           version1_10_0_50,
+	{"minecraft:coral_fan", "mapped_type", "pink"}:                           version1_10_0_50,
+	{"minecraft:coral_fan", "mapped_type", "purple"}:                         version1_10_0_50,
+	{"minecraft:coral_fan", "mapped_type", "red"}:                            version1_10_0_50,
+	{"minecraft:coral_fan", "mapped_type", "yellow"}:                         version1_10_0_50,
+	{"minecraft:coral_fan_dead", "mapped_type", "blue"}:                      version1_10_0_50,
+	{"minecraft:coral_fan_dead", "mapped_type", "pink"}:                      version1_10_0_50,
+	{"minecraft:coral_fan_dead", "mapped_type", "purple"}:                    version1_10_0_50,
+	{"minecraft:coral_fan_dead", "mapped_type", "red"}:                       version1_10_0_50,
+	{"minecraft:coral_fan_dead", "mapped_type", "yellow"}:                    version1_10_0_50,
+	{"minecraft:coral_fan_hang", "mapped_type", uint8(0)}:                    version1_10_0_50,
+	{"minecraft:coral_fan_hang", "mapped_type", uint8(1)}:                    version1_10_0_50,
+	{"minecraft:coral_fan_hang2", "mapped_type", uint8(0)}:                   version1_10_0_50,
+	{"minecraft:coral_fan_hang2", "mapped_type", uint8(1)}:                   version1_10_0_50,
+	{"minecraft:coral_fan_hang3", "mapped_type", uint8(0)}:                   version1_10_0_50,
+	{"minecraft:coral_fan_hang3", "mapped_type", uint8(1)}:                   version1_10_0_50,
+	{"minecraft:cyan_glazed_terracotta", "facing_direction", int32(0)}:       version1_14_0_3,
+	{"minecraft:dark_oak_button", "facing_direction", int32(0)}:              version1_14_0_3,
+	{"minecraft:darkoak_wall_sign", "facing_direction", int32(0)}:            version1_14_0_3,
+	{"minecraft:detector_rail", "rail_direction", int32(0)}:                  version1_14_0_3,
+	{"minecraft:dirt", "mapped_type", "coarse"}:                              version1_10_0_50,
+	{"minecraft:dirt", "mapped_type", "normal"}:                              version1_10_0_50,
+	{"minecraft:dispenser", "facing_direction", int32(0)}:                    version1_14_0_3,
+	{"minecraft:double_plant", "mapped_type", "fern"}:                        version1_10_0_50,
+	{"minecraft:double_plant", "mapped_type", "grass"}:                       version1_10_0_50,
+	{"minecraft:double_plant", "mapped_type", "paeonia"}:                     version1_10_0_50,
+	{"minecraft:double_plant", "mapped_type", "rose"}:                        version1_10_0_50,
+	{"minecraft:double_plant", "mapped_type", "sunflower"}:                   version1_10_0_50,
+	{"minecraft:double_plant", "mapped_type", "syringa"}:                     version1_10_0_50,
+	{"minecraft:double_stone_slab", "mapped_type", "brick"}:                  version1_10_0_50,
+	{"minecraft:double_stone_slab", "mapped_type", "cobblestone"}:            version1_10_0_50,
+	{"minecraft:double_stone_slab", "mapped_type", "nether_brick"}:           version1_10_0_50,
+	{"minecraft:double_stone_slab", "mapped_type", "quartz"}:                 version1_10_0_50,
+	{"minecraft:double_stone_slab", "mapped_type", "sandstone"}:              version1_10_0_50,
+	{"minecraft:double_stone_slab", "mapped_type", "smooth_stone"}:           version1_10_0_50,
+	{"minecraft:double_stone_slab", "mapped_type", "stone_brick"}:            version1_10_0_50,
+	{"minecraft:double_stone_slab", "mapped_type", "wood"}:                   version1_10_0_50,
+	{"minecraft:double_stone_slab2", "mapped_type", "mossy_cobblestone"}:     version1_10_0_50,
+	{"minecraft:double_stone_slab2", "mapped_type", "prismarine_brick"}:      version1_10_0_50,
+	{"minecraft:double_stone_slab2", "mapped_type", "prismarine_dark"}:       version1_10_0_50,
+	{"minecraft:double_stone_slab2", "mapped_type", "prismarine_rough"}:      version1_10_0_50,
+	{"minecraft:double_stone_slab2", "mapped_type", "purpur"}:                version1_10_0_50,
+	{"minecraft:double_stone_slab2", "mapped_type", "red_nether_brick"}:      version1_10_0_50,
+	{"minecraft:double_stone_slab2", "mapped_type", "red_sandstone"}:         version1_10_0_50,
+	{"minecraft:double_stone_slab2", "mapped_type", "smooth_sandstone"}:      version1_10_0_50,
+	{"minecraft:double_stone_slab3", "mapped_type", "andesite"}:              version1_10_0_50,
+	{"minecraft:double_stone_slab3", "mapped_type", "diorite"}:               version1_10_0_50,
+	{"minecraft:double_stone_slab3", "mapped_type", "end_stone_brick"}:       version1_10_0_50,
+	{"minecraft:double_stone_slab3", "mapped_type", "granite"}:               version1_10_0_50,
+	{"minecraft:double_stone_slab3", "mapped_type", "polished_andesite"}:     version1_10_0_50,
+	{"minecraft:double_stone_slab3", "mapped_type", "polished_diorite"}:      version1_10_0_50,
+	{"minecraft:double_stone_slab3", "mapped_type", "polished_granite"}:      version1_10_0_50,
+	{"minecraft:double_stone_slab3", "mapped_type", "smooth_red_sandstone"}:  version1_10_0_50,
+	{"minecraft:double_stone_slab4", "mapped_type", "cut_red_sandstone"}:     version1_10_0_50,
+	{"minecraft:double_stone_slab4", "mapped_type", "cut_sandstone"}:         version1_10_0_50,
+	{"minecraft:double_stone_slab4", "mapped_type", "mossy_stone_brick"}:     version1_10_0_50,
+	{"minecraft:double_stone_slab4", "mapped_type", "smooth_quartz"}:         version1_10_0_50,
+	{"minecraft:double_stone_slab4", "mapped_type", "stone"}:                 version1_10_0_50,
+	{"minecraft:double_wooden_slab", "mapped_type", "acacia"}:                version1_10_0_50,
+	{"minecraft:double_wooden_slab", "mapped_type", "birch"}:                 version1_10_0_50,
+	{"minecraft:double_wooden_slab", "mapped_type", "dark_oak"}:              version1_10_0_50,
+	{"minecraft:double_wooden_slab", "mapped_type", "jungle"}:                version1_10_0_50,
+	{"minecraft:double_wooden_slab", "mapped_type", "oak"}:                   version1_10_0_50,
+	{"minecraft:double_wooden_slab", "mapped_type", "spruce"}:                version1_10_0_50,
+	{"minecraft:dropper", "facing_direction", int32(0)}:                      version1_14_0_3,
+	{"minecraft:ender_chest", "facing_direction", int32(0)}:                  version1_14_0_3,
+	{"minecraft:fence", "mapped_type", "acacia"}:                             version1_10_0_50,
+	{"minecraft:fence", "mapped_type", "birch"}:                              version1_10_0_50,
+	{"minecraft:fence", "mapped_type", "dark_oak"}:                           version1_10_0_50,
+	{"minecraft:fence", "mapped_type", "jungle"}:                             version1_10_0_50,
+	{"minecraft:fence", "mapped_type", "oak"}:                                version1_10_0_50,
+	{"minecraft:fence", "mapped_type", "spruce"}:                             version1_10_0_50,
+	{"minecraft:frame", "weirdo_direction", int32(4)}:                        version1_14_0_3,
+	{"minecraft:frame", "weirdo_direction", int32(5)}:                        version1_14_0_3,
+	{"minecraft:furnace", "facing_direction", int32(0)}:                      version1_14_0_3,
+	{"minecraft:golden_rail", "rail_direction", int32(0)}:                    version1_14_0_3,
+	{"minecraft:gray_glazed_terracotta", "facing_direction", int32(0)}:       version1_14_0_3,
+	{"minecraft:green_glazed_terracotta", "facing_direction", int32(0)}:      version1_14_0_3,
+	{"minecraft:hay_block", "direction", "x"}:                                version1_14_0_3,
+	{"minecraft:hay_block", "direction", "y"}:                                version1_14_0_3,
+	{"minecraft:hay_block", "direction", "z"}:                                version1_14_0_3,
+	{"minecraft:hay_block", "direction", int32(3)}:                           version1_10_0_50,
+	{"minecraft:hay_block", "mapped_type", int32(0)}:                         version1_10_0_50,
+	{"minecraft:hopper", "facing_direction", int32(0)}:                       version1_14_0_3,
+	{"minecraft:jigsaw", "facing_direction", int32(0)}:                       version1_14_0_3,
+	{"minecraft:jungle_button", "facing_direction", int32(0)}:                version1_14_0_3,
+	{"minecraft:jungle_wall_sign", "facing_direction", int32(0)}:             version1_14_0_3,
+	{"minecraft:ladder", "facing_direction", int32(0)}:                       version1_14_0_3,
+	{"minecraft:lava_cauldron", "fill_level", int32(6)}:                      version1_14_0_3,
+	{"minecraft:leaves", "mapped_type", "birch"}:                             version1_10_0_50,
+	{"minecraft:leaves", "mapped_type", "jungle"}:                            version1_10_0_50,
+	{"minecraft:leaves", "mapped_type", "oak"}:                               version1_10_0_50,
+	{"minecraft:leaves", "mapped_type", "spruce"}:                            version1_10_0_50,
+	{"minecraft:leaves2", "mapped_type", "acacia"}:                           version1_10_0_50,
+	{"minecraft:leaves2", "mapped_type", "dark_oak"}:                         version1_10_0_50,
+	{"minecraft:lever", "facing_direction", "down_east_west"}:                version1_14_0_3,
+	{"minecraft:lever", "facing_direction", "down_north_south"}:              version1_14_0_3,
+	{"minecraft:lever", "facing_direction", "east"}:                          version1_14_0_3,
+	{"minecraft:lever", "facing_direction", "north"}:                         version1_14_0_3,
+	{"minecraft:lever", "facing_direction", "south"}:                         version1_14_0_3,
+	{"minecraft:lever", "facing_direction", "up_east_west"}:                  version1_14_0_3,
+	{"minecraft:lever", "facing_direction", "up_north_south"}:                version1_14_0_3,
+	{"minecraft:lever", "facing_direction", "west"}:                          version1_14_0_3,
+	{"minecraft:light_blue_glazed_terracotta", "facing_direction", int32(0)}: version1_14_0_3,
+	{"minecraft:lime_glazed_terracotta", "facing_direction", int32(0)}:       version1_14_0_3,
+	{"minecraft:lit_blast_furnace", "facing_direction", int32(0)}:            version1_14_0_3,
+	{"minecraft:lit_furnace", "facing_direction", int32(0)}:                  version1_14_0_3,
+	{"minecraft:lit_smoker", "facing_direction", int32(0)}:                   version1_14_0_3,
+	{"minecraft:log2", "mapped_type", "acacia"}:                              version1_10_0_50,
+	{"minecraft:log2", "mapped_type", "dark_oak"}:                            version1_10_0_50,
+	{"minecraft:magenta_glazed_terracotta", "facing_direction", int32(0)}:    version1_14_0_3,
+	{"minecraft:monster_egg", "mapped_type", "chiseled_stone_brick"}:         version1_10_0_50,
+	{"minecraft:monster_egg", "mapped_type", "cobblestone"}:                  version1_10_0_50,
+	{"minecraft:monster_egg", "mapped_type", "cracked_stone_brick"}:          version1_10_0_50,
+	{"minecraft:monster_egg", "mapped_type", "mossy_stone_brick"}:            version1_10_0_50,
+	{"minecraft:monster_egg", "mapped_type", "stone"}:                        version1_10_0_50,
+	{"minecraft:monster_egg", "mapped_type", "stone_brick"}:                  version1_10_0_50,
+	{"minecraft:observer", "facing_direction", int32(0)}:                     version1_14_0_3,
+	{"minecraft:orange_glazed_terracotta", "facing_direction", int32(0)}:     version1_14_0_3,
+	{"minecraft:pink_glazed_terracotta", "facing_direction", int32(0)}:       version1_14_0_3,
+	{"minecraft:piston", "facing_direction", int32(0)}:                       version1_14_0_3,
+	{"minecraft:pistonArmCollision", "facing_direction", int32(0)}:           version1_14_0_3,
+	{"minecraft:planks", "mapped_type", "acacia"}:                            version1_10_0_50,
+	{"minecraft:planks", "mapped_type", "birch"}:                             version1_10_0_50,
+	{"minecraft:planks", "mapped_type", "dark_oak"}:                          version1_10_0_50,
+	{"minecraft:planks", "mapped_type", "jungle"}:                            version1_10_0_50,
+	{"minecraft:planks", "mapped_type", "oak"}:                               version1_10_0_50,
+	{"minecraft:planks", "mapped_type", "spruce"}:                            version1_10_0_50,
+	{"minecraft:prismarine", "mapped_type", "bricks"}:                        version1_10_0_50,
+	{"minecraft:prismarine", "mapped_type", "dark"}:                          version1_10_0_50,
+	{"minecraft:prismarine", "mapped_type", "default"}:                       version1_10_0_50,
+	{"minecraft:purple_glazed_terracotta", "facing_direction", int32(0)}:     version1_14_0_3,
+	{"minecraft:purpur_block", "direction", "x"}:                             version1_14_0_3,
+	{"minecraft:purpur_block", "direction", "y"}:                             version1_14_0_3,
+	{"minecraft:purpur_block", "direction", "z"}:                             version1_14_0_3,
+	{"minecraft:purpur_block", "mapped_type", "chiseled"}:                    version1_10_0_50,
+	{"minecraft:purpur_block", "mapped_type", "default"}:                     version1_10_0_50,
+	{"minecraft:purpur_block", "mapped_type", "lines"}:                       version1_10_0_50,
+	{"minecraft:purpur_block", "mapped_type", "smooth"}:                      version1_10_0_50,
+	{"minecraft:quartz_block", "direction", "x"}:                             version1_14_0_3,
+	{"minecraft:quartz_block", "direction", "y"}:                             version1_14_0_3,
+	{"minecraft:quartz_block", "direction", "z"}:                             version1_14_0_3,
+	{"minecraft:quartz_block", "mapped_type", "chiseled"}:                    version1_10_0_50,
+	{"minecraft:quartz_block", "mapped_type", "default"}:                     version1_10_0_50,
+	{"minecraft:quartz_block", "mapped_type", "lines"}:                       version1_10_0_50,
+	{"minecraft:quartz_block", "mapped_type", "smooth"}:                      version1_10_0_50,
+	{"minecraft:rail", "rail_direction", int32(0)}:                           version1_14_0_3,
+	{"minecraft:red_flower", "mapped_type", "allium"}:                        version1_10_0_50,
+	{"minecraft:red_flower", "mapped_type", "cornflower"}:                    version1_10_0_50,
+	{"minecraft:red_flower", "mapped_type", "houstonia"}:                     version1_10_0_50,
+	{"minecraft:red_flower", "mapped_type", "lily_of_the_valley"}:            version1_10_0_50,
+	{"minecraft:red_flower", "mapped_type", "orchid"}:                        version1_10_0_50,
+	{"minecraft:red_flower", "mapped_type", "oxeye"}:                         version1_10_0_50,
+	{"minecraft:red_flower", "mapped_type", "poppy"}:                         version1_10_0_50,
+	{"minecraft:red_flower", "mapped_type", "tulip_orange"}:                  version1_10_0_50,
+	{"minecraft:red_flower", "mapped_type", "tulip_pink"}:                    version1_10_0_50,
+	{"minecraft:red_flower", "mapped_type", "tulip_red"}:                     version1_10_0_50,
+	{"minecraft:red_flower", "mapped_type", "tulip_white"}:                   version1_10_0_50,
+	{"minecraft:red_glazed_terracotta", "facing_direction", int32(0)}:        version1_14_0_3,
+	{"minecraft:red_sandstone", "mapped_type", "cut"}:                        version1_10_0_50,
+	{"minecraft:red_sandstone", "mapped_type", "default"}:                    version1_10_0_50,
+	{"minecraft:red_sandstone", "mapped_type", "heiroglyphs"}:                version1_10_0_50,
+	{"minecraft:red_sandstone", "mapped_type", "smooth"}:                     version1_10_0_50,
+	{"minecraft:repeating_command_block", "facing_direction", int32(0)}:      version1_14_0_3,
+	{"minecraft:sand", "mapped_type", "normal"}:                              version1_10_0_50,
+	{"minecraft:sand", "mapped_type", "red"}:                                 version1_10_0_50,
+	{"minecraft:sandstone", "mapped_type", "cut"}:                            version1_10_0_50,
+	{"minecraft:sandstone", "mapped_type", "default"}:                        version1_10_0_50,
+	{"minecraft:sandstone", "mapped_type", "heiroglyphs"}:                    version1_10_0_50,
+	{"minecraft:sandstone", "mapped_type", "smooth"}:                         version1_10_0_50,
+	{"minecraft:seagrass", "mapped_type", "default"}:                         version1_10_0_50,
+	{"minecraft:seagrass", "mapped_type", "double_bot"}:                      version1_10_0_50,
+	{"minecraft:seagrass", "mapped_type", "double_top"}:                      version1_10_0_50,
+	{"minecraft:silver_glazed_terracotta", "facing_direction", int32(0)}:     version1_14_0_3,
+	{"minecraft:skull", "facing_direction", int32(0)}:                        version1_14_0_3,
+	{"minecraft:smoker", "direction", int32(4)}:                              version1_12_0_1,
+	{"minecraft:smoker", "direction", int32(5)}:                              version1_12_0_1,
+	{"minecraft:smoker", "facing_direction", int32(0)}:                       version1_14_0_3,
+	{"minecraft:smoker", "facing_direction", int32(2)}:                       version1_10_0_50,
+	{"minecraft:sponge", "mapped_type", "dry"}:                               version1_10_0_50,
+	{"minecraft:sponge", "mapped_type", "wet"}:                               version1_10_0_50,
+	{"minecraft:spruce_button", "facing_direction", int32(0)}:                version1_14_0_3,
+	{"minecraft:spruce_wall_sign", "facing_direction", int32(0)}:             version1_14_0_3,
+	{"minecraft:sticky_piston", "facing_direction", int32(0)}:                version1_14_0_3,
+	{"minecraft:stone", "mapped_type", "andesite"}:                           version1_10_0_50,
+	{"minecraft:stone", "mapped_type", "andesite_smooth"}:                    version1_10_0_50,
+	{"minecraft:stone", "mapped_type", "diorite"}:                            version1_10_0_50,
+	{"minecraft:stone", "mapped_type", "diorite_smooth"}:                     version1_10_0_50,
+	{"minecraft:stone", "mapped_type", "granite"}:                            version1_10_0_50,
+	{"minecraft:stone", "mapped_type", "granite_smooth"}:                     version1_10_0_50,
+	{"minecraft:stone", "mapped_type", "stone"}:                              version1_10_0_50,
+	{"minecraft:stone_button", "facing_direction", int32(0)}:                 version1_14_0_3,
+	{"minecraft:stone_slab", "mapped_type", "brick"}:                         version1_10_0_50,
+	{"minecraft:stone_slab", "mapped_type", "cobblestone"}:                   version1_10_0_50,
+	{"minecraft:stone_slab", "mapped_type", "nether_brick"}:                  version1_10_0_50,
+	{"minecraft:stone_slab", "mapped_type", "quartz"}:                        version1_10_0_50,
+	{"minecraft:stone_slab", "mapped_type", "sandstone"}:                     version1_10_0_50,
+	{"minecraft:stone_slab", "mapped_type", "smooth_stone"}:                  version1_10_0_50,
+	{"minecraft:stone_slab", "mapped_type", "stone_brick"}:                   version1_10_0_50,
+	{"minecraft:stone_slab", "mapped_type", "wood"}:                          version1_10_0_50,
+	{"minecraft:stone_slab2", "mapped_type", "mossy_cobblestone"}:            version1_10_0_50,
+	{"minecraft:stone_slab2", "mapped_type", "prismarine_brick"}:             version1_10_0_50,
+	{"minecraft:stone_slab2", "mapped_type", "prismarine_dark"}:              version1_10_0_50,
+	{"minecraft:stone_slab2", "mapped_type", "prismarine_rough"}:             version1_10_0_50,
+	{"minecraft:stone_slab2", "mapped_type", "purpur"}:                       version1_10_0_50,
+	{"minecraft:stone_slab2", "mapped_type", "red_nether_brick"}:             version1_10_0_50,
+	{"minecraft:stone_slab2", "mapped_type", "red_sandstone"}:                version1_10_0_50,
+	{"minecraft:stone_slab2", "mapped_type", "smooth_sandstone"}:             version1_10_0_50,
+	{"minecraft:stone_slab3", "mapped_type", "andesite"}:                     version1_10_0_50,
+	{"minecraft:stone_slab3", "mapped_type", "diorite"}:                      version1_10_0_50,
+	{"minecraft:stone_slab3", "mapped_type", "end_stone_brick"}:              version1_10_0_50,
+	{"minecraft:stone_slab3", "mapped_type", "granite"}:                      version1_10_0_50,
+	{"minecraft:stone_slab3", "mapped_type", "polished_andesite"}:            version1_10_0_50,
+	{"minecraft:stone_slab3", "mapped_type", "polished_diorite"}:             version1_10_0_50,
+	{"minecraft:stone_slab3", "mapped_type", "polished_granite"}:             version1_10_0_50,
+	{"minecraft:stone_slab3", "mapped_type", "smooth_red_sandstone"}:         version1_10_0_50,
+	{"minecraft:stone_slab4", "mapped_type", "cut_red_sandstone"}:            version1_10_0_50,
+	{"minecraft:stone_slab4", "mapped_type", "cut_sandstone"}:                version1_10_0_50,
+	{"minecraft:stone_slab4", "mapped_type", "mossy_stone_brick"}:            version1_10_0_50,
+	{"minecraft:stone_slab4", "mapped_type", "smooth_quartz"}:                version1_10_0_50,
+	{"minecraft:stone_slab4", "mapped_type", "stone"}:                        version1_10_0_50,
+	{"minecraft:stonebrick", "mapped_type", "chiseled"}:                      version1_10_0_50,
+	{"minecraft:stonebrick", "mapped_type", "cracked"}:                       version1_10_0_50,
+	{"minecraft:stonebrick", "mapped_type", "default"}:                       version1_10_0_50,
+	{"minecraft:stonebrick", "mapped_type", "mossy"}:                         version1_10_0_50,
+	{"minecraft:stonebrick", "mapped_type", "smooth"}:                        version1_10_0_50,
+	{"minecraft:stonecutter_block", "facing_direction", int32(0)}:            version1_14_0_3,
+	{"minecraft:stripped_acacia_log", "direction", "x"}:                      version1_14_0_3,
+	{"minecraft:stripped_acacia_log", "direction", "y"}:                      version1_14_0_3,
+	{"minecraft:stripped_acacia_log", "direction", "z"}:                      version1_14_0_3,
+	{"minecraft:stripped_birch_log", "direction", "x"}:                       version1_14_0_3,
+	{"minecraft:stripped_birch_log", "direction", "y"}:                       version1_14_0_3,
+	{"minecraft:stripped_birch_log", "direction", "z"}:                       version1_14_0_3,
+	{"minecraft:stripped_dark_oak_log", "direction", "x"}:                    version1_14_0_3,
+	{"minecraft:stripped_dark_oak_log", "direction", "y"}:                    version1_14_0_3,
+	{"minecraft:stripped_dark_oak_log", "direction", "z"}:                    version1_14_0_3,
+	{"minecraft:stripped_jungle_log", "direction", "x"}:                      version1_14_0_3,
+	{"minecraft:stripped_jungle_log", "direction", "y"}:                      version1_14_0_3,
+	{"minecraft:stripped_jungle_log", "direction", "z"}:                      version1_14_0_3,
+	{"minecraft:stripped_oak_log", "direction", "x"}:                         version1_14_0_3,
+	{"minecraft:stripped_oak_log", "direction", "y"}:                         version1_14_0_3,
+	{"minecraft:stripped_oak_log", "direction", "z"}:                         version1_14_0_3,
+	{"minecraft:stripped_spruce_log", "direction", "x"}:                      version1_14_0_3,
+	{"minecraft:stripped_spruce_log", "direction", "y"}:                      version1_14_0_3,
+	{"minecraft:stripped_spruce_log", "direction", "z"}:                      version1_14_0_3,
+	{"minecraft:structure_block", "mapped_type", "corner"}:                   version1_10_0_50,
+	{"minecraft:structure_block", "mapped_type", "data"}:                     version1_10_0_50,
+	{"minecraft:structure_block", "mapped_type", "export"}:                   version1_10_0_50,
+	{"minecraft:structure_block", "mapped_type", "invalid"}:                  version1_10_0_50,
+	{"minecraft:structure_block", "mapped_type", "load"}:                     version1_10_0_50,
+	{"minecraft:structure_block", "mapped_type", "save"}:                     version1_10_0_50,
+	{"minecraft:tallgrass", "mapped_type", "default"}:                        version1_10_0_50,
+	{"minecraft:tallgrass", "mapped_type", "fern"}:                           version1_10_0_50,
+	{"minecraft:tallgrass", "mapped_type", "snow"}:                           version1_10_0_50,
+	{"minecraft:tallgrass", "mapped_type", "tall"}:                           version1_10_0_50,
+	{"minecraft:trapped_chest", "facing_direction", int32(0)}:                version1_14_0_3,
+	{"minecraft:wall_banner", "facing_direction", int32(0)}:                  version1_14_0_3,
+	{"minecraft:wall_sign", "facing_direction", int32(0)}:                    version1_14_0_3,
+	{"minecraft:white_glazed_terracotta", "facing_direction", int32(0)}:      version1_14_0_3,
+	{"minecraft:wooden_button", "facing_direction", int32(0)}:                version1_14_0_3,
+	{"minecraft:wooden_slab", "mapped_type", "acacia"}:                       version1_10_0_50,
+	{"minecraft:wooden_slab", "mapped_type", "birch"}:                        version1_10_0_50,
+	{"minecraft:wooden_slab", "mapped_type", "dark_oak"}:                     version1_10_0_50,
+	{"minecraft:wooden_slab", "mapped_type", "jungle"}:                       version1_10_0_50,
+	{"minecraft:wooden_slab", "mapped_type", "oak"}:                          version1_10_0_50,
+	{"minecraft:wooden_slab", "mapped_type", "spruce"}:                       version1_10_0_50,
+	{"minecraft:yellow_glazed_terracotta", "facing_direction", int32(0)}:     version1_14_0_3,
+}
+
+// blockRename is a rename of a block made by a schema. If states is nil, the block was renamed regardless of its
+// states, which were kept. Otherwise, the block was renamed only if its states were equal to states, which were
+// then replaced with newStates.
+type blockRename struct {
+	name              string
+	states, newStates map[string]interface{}
+}
+
+// schemaRenames holds the renames of blocks made by a single schema, by the new name of the blocks.
+type schemaRenames struct {
+	version int32
+	renames map[string][]blockRename
+}
+
+// blockRenames holds the renames made by every schema that renames blocks, in the order of the schemas.
+var blockRenames = []schemaRenames{
+	{version: version1_10_0_50, renames: map[string][]blockRename{
+		"minecraft:log": {
+			{name: "minecraft:log", states: map[string]interface{}{"direction": int32(1), "mapped_type": int32(2)}, newStates: map[string]interface{}{"direction": int32(1), "old_log_type": "birch"}},
+			{name: "minecraft:log", states: map[string]interface{}{"direction": int32(2), "mapped_type": int32(2)}, newStates: map[string]interface{}{"direction": int32(2), "old_log_type": "birch"}},
+			{name: "minecraft:log", states: map[string]interface{}{"direction": int32(0), "mapped_type": int32(2)}, newStates: map[string]interface{}{"direction": int32(0), "old_log_type": "birch"}},
+			{name: "minecraft:log", states: map[string]interface{}{"direction": int32(2), "mapped_type": int32(3)}, newStates: map[string]interface{}{"direction": int32(2), "old_log_type": "jungle"}},
+			{name: "minecraft:log", states: map[string]interface{}{"direction": int32(0), "mapped_type": int32(1)}, newStates: map[string]interface{}{"direction": int32(0), "old_log_type": "spruce"}},
+			{name: "minecraft:log", states: map[string]interface{}{"direction": int32(0), "mapped_type": int32(0)}, newStates: map[string]interface{}{"direction": int32(0), "old_log_type": "oak"}},
+			{name: "minecraft:log", states: map[string]interface{}{"direction": int32(1), "mapped_type": int32(3)}, newStates: map[string]interface{}{"direction": int32(1), "old_log_type": "jungle"}},
+			{name: "minecraft:log", states: map[string]interface{}{"direction": int32(2), "mapped_type": int32(0)}, newStates: map[string]interface{}{"direction": int32(2), "old_log_type": "oak"}},
+			{name: "minecraft:log", states: map[string]interface{}{"direction": int32(1), "mapped_type": int32(0)}, newStates: map[string]interface{}{"direction": int32(1), "old_log_type": "oak"}},
+			{name: "minecraft:log", states: map[string]interface{}{"direction": int32(1), "mapped_type": int32(1)}, newStates: map[string]interface{}{"direction": int32(1), "old_log_type": "spruce"}},
+			{name: "minecraft:log", states: map[string]interface{}{"direction": int32(2), "mapped_type": int32(1)}, newStates: map[string]interface{}{"direction": int32(2), "old_log_type": "spruce"}},
+			{name: "minecraft:log", states: map[string]interface{}{"direction": int32(0), "mapped_type": int32(3)}, newStates: map[string]interface{}{"direction": int32(0), "old_log_type": "jungle"}},
+			{name: "minecraft:log", states: map[string]interface{}{"direction": int32(3), "mapped_type": int32(3)}, newStates: map[string]interface{}{"direction": int32(3), "old_log_type": "jungle"}},
+			{name: "minecraft:log", states: map[string]interface{}{"direction": int32(3), "mapped_type": int32(0)}, newStates: map[string]interface{}{"direction": int32(3), "old_log_type": "oak"}},
+		},
+		"minecraft:wood": {
+			{name: "minecraft:log", states: map[string]interface{}{"direction": int32(3), "mapped_type": int32(2)}, newStates: map[string]interface{}{"stripped_bit": uint8(0), "wood_type": "birch"}},
+			{name: "minecraft:log", states: map[string]interface{}{"direction": int32(3), "mapped_type": int32(1)}, newStates: map[string]interface{}{"stripped_bit": uint8(0), "wood_type": "spruce"}},
+		},
+	}},
+	{version: version1_14_0_3, renames: map[string][]blockRename{
+		"minecraft:light_block": {
+			{name: "minecraft:end_rod", states: map[string]interface{}{"facing_direction": int32(6)}, newStates: map[string]interface{}{"block_light_level": int32(14)}},
+			{name: "minecraft:end_rod", states: map[string]interface{}{"facing_direction": int32(7)}, newStates: map[string]interface{}{"block_light_level": int32(14)}},
+		},
+		"minecraft:log": {
+			{name: "minecraft:log", states: map[string]interface{}{"direction": int32(1), "old_log_type": "spruce"}, newStates: map[string]interface{}{"old_log_type": "spruce", "pillar_axis": "x"}},
+			{name: "minecraft:log", states: map[string]interface{}{"direction": int32(0), "old_log_type": "jungle"}, newStates: map[string]interface{}{"old_log_type": "jungle", "pillar_axis": "y"}},
+			{name: "minecraft:log", states: map[string]interface{}{"direction": int32(1), "old_log_type": "oak"}, newStates: map[string]interface{}{"old_log_type": "oak", "pillar_axis": "x"}},
+			{name: "minecraft:log", states: map[string]interface{}{"direction": int32(0), "old_log_type": "oak"}, newStates: map[string]interface{}{"old_log_type": "oak", "pillar_axis": "y"}},
+			{name: "minecraft:log", states: map[string]interface{}{"direction": int32(2), "old_log_type": "spruce"}, newStates: map[string]interface{}{"old_log_type": "spruce", "pillar_axis": "z"}},
+			{name: "minecraft:log", states: map[string]interface{}{"direction": int32(1), "old_log_type": "birch"}, newStates: map[string]interface{}{"old_log_type": "birch", "pillar_axis": "x"}},
+			{name: "minecraft:log", states: map[string]interface{}{"direction": int32(2), "old_log_type": "birch"}, newStates: map[string]interface{}{"old_log_type": "birch", "pillar_axis": "z"}},
+			{name: "minecraft:log", states: map[string]interface{}{"direction": int32(0), "old_log_type": "spruce"}, newStates: map[string]interface{}{"old_log_type": "spruce", "pillar_axis": "y"}},
+			{name: "minecraft:log", states: map[string]interface{}{"direction": int32(2), "old_log_type": "jungle"}, newStates: map[string]interface{}{"old_log_type": "jungle", "pillar_axis": "z"}},
+			{name: "minecraft:log", states: map[string]interface{}{"direction": int32(0), "old_log_type": "birch"}, newStates: map[string]interface{}{"old_log_type": "birch", "pillar_axis": "y"}},
+			{name: "minecraft:log", states: map[string]interface{}{"direction": int32(1), "old_log_type": "jungle"}, newStates: map[string]interface{}{"old_log_type": "jungle", "pillar_axis": "x"}},
+			{name: "minecraft:log", states: map[string]interface{}{"direction": int32(2), "old_log_type": "oak"}, newStates: map[string]interface{}{"old_log_type": "oak", "pillar_axis": "z"}},
+		},
+		"minecraft:log2": {
+			{name: "minecraft:log2", states: map[string]interface{}{"direction": int32(1), "new_log_type": "acacia"}, newStates: map[string]interface{}{"new_log_type": "acacia", "pillar_axis": "x"}},
+			{name: "minecraft:log2", states: map[string]interface{}{"direction": int32(0), "new_log_type": "acacia"}, newStates: map[string]interface{}{"new_log_type": "acacia", "pillar_axis": "y"}},
+			{name: "minecraft:log2", states: map[string]interface{}{"direction": int32(0), "new_log_type": "dark_oak"}, newStates: map[string]interface{}{"new_log_type": "dark_oak", "pillar_axis": "y"}},
+			{name: "minecraft:log2", states: map[string]interface{}{"direction": int32(0), "new_log_type": "acacia"}, newStates: map[string]interface{}{"new_log_type": "acacia", "pillar_axis": "y"}},
+			{name: "minecraft:log2", states: map[string]interface{}{"direction": int32(2), "new_log_type": "acacia"}, newStates: map[string]interface{}{"new_log_type": "acacia", "pillar_axis": "z"}},
+			{name: "minecraft:log2", states: map[string]interface{}{"direction": int32(2), "new_log_type": "acacia"}, newStates: map[string]interface{}{"new_log_type": "acacia", "pillar_axis": "z"}},
+			{name: "minecraft:log2", states: map[string]interface{}{"direction": int32(2), "new_log_type": "acacia"}, newStates: map[string]interface{}{"new_log_type": "acacia", "pillar_axis": "z"}},
+			{name: "minecraft:log2", states: map[string]interface{}{"direction": int32(1), "new_log_type": "dark_oak"}, newStates: map[string]interface{}{"new_log_type": "dark_oak", "pillar_axis": "x"}},
+			{name: "minecraft:log2", states: map[string]interface{}{"direction": int32(2), "new_log_type": "dark_oak"}, newStates: map[string]interface{}{"new_log_type": "dark_oak", "pillar_axis": "z"}},
+			{name: "minecraft:log2", states: map[string]interface{}{"direction": int32(0), "new_log_type": "acacia"}, newStates: map[string]interface{}{"new_log_type": "acacia", "pillar_axis": "y"}},
+			{name: "minecraft:log2", states: map[string]interface{}{"direction": int32(1), "new_log_type": "acacia"}, newStates: map[string]interface{}{"new_log_type": "acacia", "pillar_axis": "x"}},
+			{name: "minecraft:log2", states: map[string]interface{}{"direction": int32(1), "new_log_type": "acacia"}, newStates: map[string]interface{}{"new_log_type": "acacia", "pillar_axis": "x"}},
+		},
+		"minecraft:wood": {
+			{name: "minecraft:log", states: map[string]interface{}{"direction": int32(3), "old_log_type": "spruce"}, newStates: map[string]interface{}{"pillar_axis": "y", "stripped_bit": uint8(0), "wood_type": "spruce"}},
+			{name: "minecraft:log", states: map[string]interface{}{"direction": int32(3), "old_log_type": "oak"}, newStates: map[string]interface{}{"pillar_axis": "y", "stripped_bit": uint8(0), "wood_type": "oak"}},
+			{name: "minecraft:log", states: map[string]interface{}{"direction": int32(3), "old_log_type": "jungle"}, newStates: map[string]interface{}{"pillar_axis": "y", "stripped_bit": uint8(0), "wood_type": "jungle"}},
+			{name: "minecraft:log", states: map[string]interface{}{"direction": int32(3), "old_log_type": "birch"}, newStates: map[string]interface{}{"pillar_axis": "y", "stripped_bit": uint8(0), "wood_type": "birch"}},
+			{name: "minecraft:log2", states: map[string]interface{}{"direction": int32(3), "new_log_type": "acacia"}, newStates: map[string]interface{}{"pillar_axis": "y", "stripped_bit": uint8(0), "wood_type": "acacia"}},
+			{name: "minecraft:log2", states: map[string]interface{}{"direction": int32(3), "new_log_type": "dark_oak"}, newStates: map[string]interface{}{"pillar_axis": "y", "stripped_bit": uint8(0), "wood_type": "dark_oak"}},
+			{name: "minecraft:log2", states: map[string]interface{}{"direction": int32(3), "new_log_type": "acacia"}, newStates: map[string]interface{}{"pillar_axis": "y", "stripped_bit": uint8(0), "wood_type": "acacia"}},
+			{name: "minecraft:log2", states: map[string]interface{}{"direction": int32(3), "new_log_type": "acacia"}, newStates: map[string]interface{}{"pillar_axis": "y", "stripped_bit": uint8(0), "wood_type": "acacia"}},
+		},
+	}},
+	{version: version1_16_0_9, renames: map[string][]blockRename{
+		"minecraft:basalt": {
+			{name: "minecraft:basalt_block"},
+		},
+		"minecraft:crimson_trapdoor": {
+			{name: "minecraft:crimson_trap_door"},
+		},
+		"minecraft:polished_basalt": {
+			{name: "minecraft:polished_basalt_block"},
+		},
+		"minecraft:shroomlight": {
+			{name: "minecraft:shroomlight_block"},
+		},
+		"minecraft:soul_fire": {
+			{name: "minecraft:blue_fire"},
+		},
+		"minecraft:soul_soil": {
+			{name: "minecraft:soul_soil_block"},
+		},
+		"minecraft:target": {
+			{name: "minecraft:target_block"},
+		},
+		"minecraft:warped_wart_block": {
+			{name: "minecraft:blue_nether_wart_block"},
+		},
+		"minecraft:weeping_vines": {
+			{name: "minecraft:weeping_vines_block"},
+		},
+	}},
+	{version: version1_16_0_14, renames: map[string][]blockRename{
+		"minecraft:lodestone": {
+			{name: "minecraft:lodestone_block"},
+		},
+		"minecraft:twisting_vines": {
+			{name: "minecraft:twisting_vines_block"},
+		},
+	}},
+	{version: version1_18_10_1, renames: map[string][]blockRename{
+		"minecraft:frog_spawn": {
+			{name: "minecraft:frog_egg"},
+		},
+	}},
+	{version: version1_18_10_1, renames: map[string][]blockRename{
+		"minecraft:concrete_powder": {
+			{name: "minecraft:concretePowder"},
+		},
+		"minecraft:invisible_bedrock": {
+			{name: "minecraft:invisibleBedrock"},
+		},
+		"minecraft:moving_block": {
+			{name: "minecraft:movingBlock"},
+		},
+		"minecraft:piston_arm_collision": {
+			{name: "minecraft:pistonArmCollision"},
+		},
+		"minecraft:reinforced_deepslate": {
+			{name: "minecraft:mysterious_frame"},
+			{name: "minecraft:mysterious_frame_slot"},
+		},
+		"minecraft:sea_lantern": {
+			{name: "minecraft:seaLantern"},
+		},
+		"minecraft:sticky_piston_arm_collision": {
+			{name: "minecraft:stickyPistonArmCollision"},
+		},
+		"minecraft:trip_wire": {
+			{name: "minecraft:tripWire"},
+		},
+	}},
+	{version: version1_18_10_1, renames: map[string][]blockRename{
+		"minecraft:double_stone_block_slab": {
+			{name: "minecraft:double_stone_slab"},
+		},
+		"minecraft:double_stone_block_slab2": {
+			{name: "minecraft:double_stone_slab2"},
+		},
+		"minecraft:double_stone_block_slab3": {
+			{name: "minecraft:double_stone_slab3"},
+		},
+		"minecraft:double_stone_block_slab4": {
+			{name: "minecraft:double_stone_slab4"},
+		},
+		"minecraft:mangrove_propagule": {
+			{name: "minecraft:mangrove_propagule", states: map[string]interface{}{"facing_direction": int32(0), "growth": int32(0)}, newStates: map[string]interface{}{"hanging": uint8(0), "propagule_stage": int32(0)}},
+			{name: "minecraft:mangrove_propagule", states: map[string]interface{}{"facing_direction": int32(0), "growth": int32(1)}, newStates: map[string]interface{}{"hanging": uint8(0), "propagule_stage": int32(0)}},
+			{name: "minecraft:mangrove_propagule", states: map[string]interface{}{"facing_direction": int32(0), "growth": int32(2)}, newStates: map[string]interface{}{"hanging": uint8(0), "propagule_stage": int32(0)}},
+			{name: "minecraft:mangrove_propagule", states: map[string]interface{}{"facing_direction": int32(0), "growth": int32(3)}, newStates: map[string]interface{}{"hanging": uint8(0), "propagule_stage": int32(0)}},
+			{name: "minecraft:mangrove_propagule", states: map[string]interface{}{"facing_direction": int32(0), "growth": int32(4)}, newStates: map[string]interface{}{"hanging": uint8(0), "propagule_stage": int32(0)}},
+			{name: "minecraft:mangrove_propagule", states: map[string]interface{}{"facing_direction": int32(0), "growth": int32(5)}, newStates: map[string]interface{}{"hanging": uint8(0), "propagule_stage": int32(0)}},
+			{name: "minecraft:mangrove_propagule", states: map[string]interface{}{"facing_direction": int32(0), "growth": int32(6)}, newStates: map[string]interface{}{"hanging": uint8(0), "propagule_stage": int32(0)}},
+			{name: "minecraft:mangrove_propagule", states: map[string]interface{}{"facing_direction": int32(0), "growth": int32(7)}, newStates: map[string]interface{}{"hanging": uint8(0), "propagule_stage": int32(0)}},
+			{name: "minecraft:mangrove_propagule", states: map[string]interface{}{"facing_direction": int32(1), "growth": int32(0)}, newStates: map[string]interface{}{"hanging": uint8(0), "propagule_stage": int32(0)}},
+			{name: "minecraft:mangrove_propagule", states: map[string]interface{}{"facing_direction": int32(1), "growth": int32(1)}, newStates: map[string]interface{}{"hanging": uint8(0), "propagule_stage": int32(0)}},
+			{name: "minecraft:mangrove_propagule", states: map[string]interface{}{"facing_direction": int32(1), "growth": int32(2)}, newStates: map[string]interface{}{"hanging": uint8(0), "propagule_stage": int32(0)}},
+			{name: "minecraft:mangrove_propagule", states: map[string]interface{}{"facing_direction": int32(1), "growth": int32(3)}, newStates: map[string]interface{}{"hanging": uint8(0), "propagule_stage": int32(0)}},
+			{name: "minecraft:mangrove_propagule", states: map[string]interface{}{"facing_direction": int32(1), "growth": int32(4)}, newStates: map[string]interface{}{"hanging": uint8(0), "propagule_stage": int32(0)}},
+			{name: "minecraft:mangrove_propagule", states: map[string]interface{}{"facing_direction": int32(1), "growth": int32(5)}, newStates: map[string]interface{}{"hanging": uint8(0), "propagule_stage": int32(0)}},
+			{name: "minecraft:mangrove_propagule", states: map[string]interface{}{"facing_direction": int32(1), "growth": int32(6)}, newStates: map[string]interface{}{"hanging": uint8(0), "propagule_stage": int32(0)}},
+			{name: "minecraft:mangrove_propagule", states: map[string]interface{}{"facing_direction": int32(1), "growth": int32(7)}, newStates: map[string]interface{}{"hanging": uint8(0), "propagule_stage": int32(0)}},
+			{name: "minecraft:mangrove_propagule", states: map[string]interface{}{"facing_direction": int32(2), "growth": int32(0)}, newStates: map[string]interface{}{"hanging": uint8(0), "propagule_stage": int32(0)}},
+			{name: "minecraft:mangrove_propagule", states: map[string]interface{}{"facing_direction": int32(2), "growth": int32(1)}, newStates: map[string]interface{}{"hanging": uint8(0), "propagule_stage": int32(0)}},
+			{name: "minecraft:mangrove_propagule", states: map[string]interface{}{"facing_direction": int32(2), "growth": int32(2)}, newStates: map[string]interface{}{"hanging": uint8(0), "propagule_stage": int32(0)}},
+			{name: "minecraft:mangrove_propagule", states: map[string]interface{}{"facing_direction": int32(2), "growth": int32(3)}, newStates: map[string]interface{}{"hanging": uint8(0), "propagule_stage": int32(0)}},
+			{name: "minecraft:mangrove_propagule", states: map[string]interface{}{"facing_direction": int32(2), "growth": int32(4)}, newStates: map[string]interface{}{"hanging": uint8(0), "propagule_stage": int32(0)}},
+			{name: "minecraft:mangrove_propagule", states: map[string]interface{}{"facing_direction": int32(2), "growth": int32(5)}, newStates: map[string]interface{}{"hanging": uint8(0), "propagule_stage": int32(0)}},
+			{name: "minecraft:mangrove_propagule", states: map[string]interface{}{"facing_direction": int32(2), "growth": int32(6)}, newStates: map[string]interface{}{"hanging": uint8(0), "propagule_stage": int32(0)}},
+			{name: "minecraft:mangrove_propagule", states: map[string]interface{}{"facing_direction": int32(2), "growth": int32(7)}, newStates: map[string]interface{}{"hanging": uint8(0), "propagule_stage": int32(0)}},
+			{name: "minecraft:mangrove_propagule", states: map[string]interface{}{"facing_direction": int32(3), "growth": int32(0)}, newStates: map[string]interface{}{"hanging": uint8(0), "propagule_stage": int32(0)}},
+			{name: "minecraft:mangrove_propagule", states: map[string]interface{}{"facing_direction": int32(3), "growth": int32(1)}, newStates: map[string]interface{}{"hanging": uint8(0), "propagule_stage": int32(0)}},
+			{name: "minecraft:mangrove_propagule", states: map[string]interface{}{"facing_direction": int32(3), "growth": int32(2)}, newStates: map[string]interface{}{"hanging": uint8(0), "propagule_stage": int32(0)}},
+			{name: "minecraft:mangrove_propagule", states: map[string]interface{}{"facing_direction": int32(3), "growth": int32(3)}, newStates: map[string]interface{}{"hanging": uint8(0), "propagule_stage": int32(0)}},
+			{name: "minecraft:mangrove_propagule", states: map[string]interface{}{"facing_direction": int32(3), "growth": int32(4)}, newStates: map[string]interface{}{"hanging": uint8(0), "propagule_stage": int32(0)}},
+			{name: "minecraft:mangrove_propagule", states: map[string]interface{}{"facing_direction": int32(3), "growth": int32(5)}, newStates: map[string]interface{}{"hanging": uint8(0), "propagule_stage": int32(0)}},
+			{name: "minecraft:mangrove_propagule", states: map[string]interface{}{"facing_direction": int32(3), "growth": int32(6)}, newStates: map[string]interface{}{"hanging": uint8(0), "propagule_stage": int32(0)}},
+			{name: "minecraft:mangrove_propagule", states: map[string]interface{}{"facing_direction": int32(3), "growth": int32(7)}, newStates: map[string]interface{}{"hanging": uint8(0), "propagule_stage": int32(0)}},
+			{name: "minecraft:mangrove_propagule", states: map[string]interface{}{"facing_direction": int32(4), "growth": int32(0)}, newStates: map[string]interface{}{"hanging": uint8(0), "propagule_stage": int32(0)}},
+			{name: "minecraft:mangrove_propagule", states: map[string]interface{}{"facing_direction": int32(4), "growth": int32(1)}, newStates: map[string]interface{}{"hanging": uint8(0), "propagule_stage": int32(0)}},
+			{name: "minecraft:mangrove_propagule", states: map[string]interface{}{"facing_direction": int32(4), "growth": int32(2)}, newStates: map[string]interface{}{"hanging": uint8(0), "propagule_stage": int32(0)}},
+			{name: "minecraft:mangrove_propagule", states: map[string]interface{}{"facing_direction": int32(4), "growth": int32(3)}, newStates: map[string]interface{}{"hanging": uint8(0), "propagule_stage": int32(0)}},
+			{name: "minecraft:mangrove_propagule", states: map[string]interface{}{"facing_direction": int32(4), "growth": int32(4)}, newStates: map[string]interface{}{"hanging": uint8(0), "propagule_stage": int32(0)}},
+			{name: "minecraft:mangrove_propagule", states: map[string]interface{}{"facing_direction": int32(4), "growth": int32(5)}, newStates: map[string]interface{}{"hanging": uint8(0), "propagule_stage": int32(0)}},
+			{name: "minecraft:mangrove_propagule", states: map[string]interface{}{"facing_direction": int32(4), "growth": int32(6)}, newStates: map[string]interface{}{"hanging": uint8(0), "propagule_stage": int32(0)}},
+			{name: "minecraft:mangrove_propagule", states: map[string]interface{}{"facing_direction": int32(4), "growth": int32(7)}, newStates: map[string]interface{}{"hanging": uint8(0), "propagule_stage": int32(0)}},
+			{name: "minecraft:mangrove_propagule", states: map[string]interface{}{"facing_direction": int32(5), "growth": int32(0)}, newStates: map[string]interface{}{"hanging": uint8(0), "propagule_stage": int32(0)}},
+			{name: "minecraft:mangrove_propagule", states: map[string]interface{}{"facing_direction": int32(5), "growth": int32(1)}, newStates: map[string]interface{}{"hanging": uint8(0), "propagule_stage": int32(0)}},
+			{name: "minecraft:mangrove_propagule", states: map[string]interface{}{"facing_direction": int32(5), "growth": int32(2)}, newStates: map[string]interface{}{"hanging": uint8(0), "propagule_stage": int32(0)}},
+			{name: "minecraft:mangrove_propagule", states: map[string]interface{}{"facing_direction": int32(5), "growth": int32(3)}, newStates: map[string]interface{}{"hanging": uint8(0), "propagule_stage": int32(0)}},
+			{name: "minecraft:mangrove_propagule", states: map[string]interface{}{"facing_direction": int32(5), "growth": int32(4)}, newStates: map[string]interface{}{"hanging": uint8(0), "propagule_stage": int32(0)}},
+			{name: "minecraft:mangrove_propagule", states: map[string]interface{}{"facing_direction": int32(5), "growth": int32(5)}, newStates: map[string]interface{}{"hanging": uint8(0), "propagule_stage": int32(0)}},
+			{name: "minecraft:mangrove_propagule", states: map[string]interface{}{"facing_direction": int32(5), "growth": int32(6)}, newStates: map[string]interface{}{"hanging": uint8(0), "propagule_stage": int32(0)}},
+			{name: "minecraft:mangrove_propagule", states: map[string]interface{}{"facing_direction": int32(5), "growth": int32(7)}, newStates: map[string]interface{}{"hanging": uint8(0), "propagule_stage": int32(0)}},
+			{name: "minecraft:mangrove_propagule_hanging", states: map[string]interface{}{"facing_direction": int32(0), "growth": int32(0)}, newStates: map[string]interface{}{"hanging": uint8(1), "propagule_stage": int32(0)}},
+			{name: "minecraft:mangrove_propagule_hanging", states: map[string]interface{}{"facing_direction": int32(0), "growth": int32(1)}, newStates: map[string]interface{}{"hanging": uint8(1), "propagule_stage": int32(0)}},
+			{name: "minecraft:mangrove_propagule_hanging", states: map[string]interface{}{"facing_direction": int32(0), "growth": int32(2)}, newStates: map[string]interface{}{"hanging": uint8(1), "propagule_stage": int32(0)}},
+			{name: "minecraft:mangrove_propagule_hanging", states: map[string]interface{}{"facing_direction": int32(0), "growth": int32(3)}, newStates: map[string]interface{}{"hanging": uint8(1), "propagule_stage": int32(0)}},
+			{name: "minecraft:mangrove_propagule_hanging", states: map[string]interface{}{"facing_direction": int32(0), "growth": int32(4)}, newStates: map[string]interface{}{"hanging": uint8(1), "propagule_stage": int32(0)}},
+			{name: "minecraft:mangrove_propagule_hanging", states: map[string]interface{}{"facing_direction": int32(0), "growth": int32(5)}, newStates: map[string]interface{}{"hanging": uint8(1), "propagule_stage": int32(0)}},
+			{name: "minecraft:mangrove_propagule_hanging", states: map[string]interface{}{"facing_direction": int32(0), "growth": int32(6)}, newStates: map[string]interface{}{"hanging": uint8(1), "propagule_stage": int32(0)}},
+			{name: "minecraft:mangrove_propagule_hanging", states: map[string]interface{}{"facing_direction": int32(0), "growth": int32(7)}, newStates: map[string]interface{}{"hanging": uint8(1), "propagule_stage": int32(0)}},
+			{name: "minecraft:mangrove_propagule_hanging", states: map[string]interface{}{"facing_direction": int32(1), "growth": int32(0)}, newStates: map[string]interface{}{"hanging": uint8(1), "propagule_stage": int32(0)}},
+			{name: "minecraft:mangrove_propagule_hanging", states: map[string]interface{}{"facing_direction": int32(1), "growth": int32(1)}, newStates: map[string]interface{}{"hanging": uint8(1), "propagule_stage": int32(0)}},
+			{name: "minecraft:mangrove_propagule_hanging", states: map[string]interface{}{"facing_direction": int32(1), "growth": int32(2)}, newStates: map[string]interface{}{"hanging": uint8(1), "propagule_stage": int32(0)}},
+			{name: "minecraft:mangrove_propagule_hanging", states: map[string]interface{}{"facing_direction": int32(1), "growth": int32(3)}, newStates: map[string]interface{}{"hanging": uint8(1), "propagule_stage": int32(0)}},
+			{name: "minecraft:mangrove_propagule_hanging", states: map[string]interface{}{"facing_direction": int32(1), "growth": int32(4)}, newStates: map[string]interface{}{"hanging": uint8(1), "propagule_stage": int32(0)}},
+			{name: "minecraft:mangrove_propagule_hanging", states: map[string]interface{}{"facing_direction": int32(1), "growth": int32(5)}, newStates: map[string]interface{}{"hanging": uint8(1), "propagule_stage": int32(0)}},
+			{name: "minecraft:mangrove_propagule_hanging", states: map[string]interface{}{"facing_direction": int32(1), "growth": int32(6)}, newStates: map[string]interface{}{"hanging": uint8(1), "propagule_stage": int32(0)}},
+			{name: "minecraft:mangrove_propagule_hanging", states: map[string]interface{}{"facing_direction": int32(1), "growth": int32(7)}, newStates: map[string]interface{}{"hanging": uint8(1), "propagule_stage": int32(0)}},
+			{name: "minecraft:mangrove_propagule_hanging", states: map[string]interface{}{"facing_direction": int32(2), "growth": int32(0)}, newStates: map[string]interface{}{"hanging": uint8(1), "propagule_stage": int32(0)}},
+			{name: "minecraft:mangrove_propagule_hanging", states: map[string]interface{}{"facing_direction": int32(2), "growth": int32(1)}, newStates: map[string]interface{}{"hanging": uint8(1), "propagule_stage": int32(0)}},
+			{name: "minecraft:mangrove_propagule_hanging", states: map[string]interface{}{"facing_direction": int32(2), "growth": int32(2)}, newStates: map[string]interface{}{"hanging": uint8(1), "propagule_stage": int32(0)}},
+			{name: "minecraft:mangrove_propagule_hanging", states: map[string]interface{}{"facing_direction": int32(2), "growth": int32(3)}, newStates: map[string]interface{}{"hanging": uint8(1), "propagule_stage": int32(0)}},
+			{name: "minecraft:mangrove_propagule_hanging", states: map[string]interface{}{"facing_direction": int32(2), "growth": int32(4)}, newStates: map[string]interface{}{"hanging": uint8(1), "propagule_stage": int32(0)}},
+			{name: "minecraft:mangrove_propagule_hanging", states: map[string]interface{}{"facing_direction": int32(2), "growth": int32(5)}, newStates: map[string]interface{}{"hanging": uint8(1), "propagule_stage": int32(0)}},
+			{name: "minecraft:mangrove_propagule_hanging", states: map[string]interface{}{"facing_direction": int32(2), "growth": int32(6)}, newStates: map[string]interface{}{"hanging": uint8(1), "propagule_stage": int32(0)}},
+			{name: "minecraft:mangrove_propagule_hanging", states: map[string]interface{}{"facing_direction": int32(2), "growth": int32(7)}, newStates: map[string]interface{}{"hanging": uint8(1), "propagule_stage": int32(0)}},
+			{name: "minecraft:mangrove_propagule_hanging", states: map[string]interface{}{"facing_direction": int32(3), "growth": int32(0)}, newStates: map[string]interface{}{"hanging": uint8(1), "propagule_stage": int32(0)}},
+			{name: "minecraft:mangrove_propagule_hanging", states: map[string]interface{}{"facing_direction": int32(3), "growth": int32(1)}, newStates: map[string]interface{}{"hanging": uint8(1), "propagule_stage": int32(0)}},
+			{name: "minecraft:mangrove_propagule_hanging", states: map[string]interface{}{"facing_direction": int32(3), "growth": int32(2)}, newStates: map[string]interface{}{"hanging": uint8(1), "propagule_stage": int32(0)}},
+			{name: "minecraft:mangrove_propagule_hanging", states: map[string]interface{}{"facing_direction": int32(3), "growth": int32(3)}, newStates: map[string]interface{}{"hanging": uint8(1), "propagule_stage": int32(0)}},
+			{name: "minecraft:mangrove_propagule_hanging", states: map[string]interface{}{"facing_direction": int32(3), "growth": int32(4)}, newStates: map[string]interface{}{"hanging": uint8(1), "propagule_stage": int32(0)}},
+			{name: "minecraft:mangrove_propagule_hanging", states: map[string]interface{}{"facing_direction": int32(3), "growth": int32(5)}, newStates: map[string]interface{}{"hanging": uint8(1), "propagule_stage": int32(0)}},
+			{name: "minecraft:mangrove_propagule_hanging", states: map[string]interface{}{"facing_direction": int32(3), "growth": int32(6)}, newStates: map[string]interface{}{"hanging": uint8(1), "propagule_stage": int32(0)}},
+			{name: "minecraft:mangrove_propagule_hanging", states: map[string]interface{}{"facing_direction": int32(3), "growth": int32(7)}, newStates: map[string]interface{}{"hanging": uint8(1), "propagule_stage": int32(0)}},
+			{name: "minecraft:mangrove_propagule_hanging", states: map[string]interface{}{"facing_direction": int32(4), "growth": int32(0)}, newStates: map[string]interface{}{"hanging": uint8(1), "propagule_stage": int32(0)}},
+			{name: "minecraft:mangrove_propagule_hanging", states: map[string]interface{}{"facing_direction": int32(4), "growth": int32(1)}, newStates: map[string]interface{}{"hanging": uint8(1), "propagule_stage": int32(0)}},
+			{name: "minecraft:mangrove_propagule_hanging", states: map[string]interface{}{"facing_direction": int32(4), "growth": int32(2)}, newStates: map[string]interface{}{"hanging": uint8(1), "propagule_stage": int32(0)}},
+			{name: "minecraft:mangrove_propagule_hanging", states: map[string]interface{}{"facing_direction": int32(4), "growth": int32(3)}, newStates: map[string]interface{}{"hanging": uint8(1), "propagule_stage": int32(0)}},
+			{name: "minecraft:mangrove_propagule_hanging", states: map[string]interface{}{"facing_direction": int32(4), "growth": int32(4)}, newStates: map[string]interface{}{"hanging": uint8(1), "propagule_stage": int32(0)}},
+			{name: "minecraft:mangrove_propagule_hanging", states: map[string]interface{}{"facing_direction": int32(4), "growth": int32(5)}, newStates: map[string]interface{}{"hanging": uint8(1), "propagule_stage": int32(0)}},
+			{name: "minecraft:mangrove_propagule_hanging", states: map[string]interface{}{"facing_direction": int32(4), "growth": int32(6)}, newStates: map[string]interface{}{"hanging": uint8(1), "propagule_stage": int32(0)}},
+			{name: "minecraft:mangrove_propagule_hanging", states: map[string]interface{}{"facing_direction": int32(4), "growth": int32(7)}, newStates: map[string]interface{}{"hanging": uint8(1), "propagule_stage": int32(0)}},
+			{name: "minecraft:mangrove_propagule_hanging", states: map[string]interface{}{"facing_direction": int32(5), "growth": int32(0)}, newStates: map[string]interface{}{"hanging": uint8(1), "propagule_stage": int32(0)}},
+			{name: "minecraft:mangrove_propagule_hanging", states: map[string]interface{}{"facing_direction": int32(5), "growth": int32(1)}, newStates: map[string]interface{}{"hanging": uint8(1), "propagule_stage": int32(0)}},
+			{name: "minecraft:mangrove_propagule_hanging", states: map[string]interface{}{"facing_direction": int32(5), "growth": int32(2)}, newStates: map[string]interface{}{"hanging": uint8(1), "propagule_stage": int32(0)}},
+			{name: "minecraft:mangrove_propagule_hanging", states: map[string]interface{}{"facing_direction": int32(5), "growth": int32(3)}, newStates: map[string]interface{}{"hanging": uint8(1), "propagule_stage": int32(0)}},
+			{name: "minecraft:mangrove_propagule_hanging", states: map[string]interface{}{"facing_direction": int32(5), "growth": int32(4)}, newStates: map[string]interface{}{"hanging": uint8(1), "propagule_stage": int32(0)}},
+			{name: "minecraft:mangrove_propagule_hanging", states: map[string]interface{}{"facing_direction": int32(5), "growth": int32(5)}, newStates: map[string]interface{}{"hanging": uint8(1), "propagule_stage": int32(0)}},
+			{name: "minecraft:mangrove_propagule_hanging", states: map[string]interface{}{"facing_direction": int32(5), "growth": int32(6)}, newStates: map[string]interface{}{"hanging": uint8(1), "propagule_stage": int32(0)}},
+			{name: "minecraft:mangrove_propagule_hanging", states: map[string]interface{}{"facing_direction": int32(5), "growth": int32(7)}, newStates: map[string]interface{}{"hanging": uint8(1), "propagule_stage": int32(0)}},
+		},
+		"minecraft:stone_block_slab": {
+			{name: "minecraft:stone_slab"},
+		},
+		"minecraft:stone_block_slab2": {
+			{name: "minecraft:stone_slab2"},
+		},
+		"minecraft:stone_block_slab3": {
+			{name: "minecraft:stone_slab3"},
+		},
+		"minecraft:stone_block_slab4": {
+			{name: "minecraft:stone_slab4"},
+		},
+	}},
+	{version: version1_19_70_15, renames: map[string][]blockRename{
+		"minecraft:black_wool": {
+			{name: "minecraft:wool", states: map[string]interface{}{"color": "black"}, newStates: map[string]interface{}{}},
+		},
+		"minecraft:blue_wool": {
+			{name: "minecraft:wool", states: map[string]interface{}{"color": "blue"}, newStates: map[string]interface{}{}},
+		},
+		"minecraft:brown_wool": {
+			{name: "minecraft:wool", states: map[string]interface{}{"color": "brown"}, newStates: map[string]interface{}{}},
+		},
+		"minecraft:cyan_wool": {
+			{name: "minecraft:wool", states: map[string]interface{}{"color": "cyan"}, newStates: map[string]interface{}{}},
+		},
+		"minecraft:gray_wool": {
+			{name: "minecraft:wool", states: map[string]interface{}{"color": "gray"}, newStates: map[string]interface{}{}},
+		},
+		"minecraft:green_wool": {
+			{name: "minecraft:wool", states: map[string]interface{}{"color": "green"}, newStates: map[string]interface{}{}},
+		},
+		"minecraft:light_blue_wool": {
+			{name: "minecraft:wool", states: map[string]interface{}{"color": "light_blue"}, newStates: map[string]interface{}{}},
+		},
+		"minecraft:light_gray_wool": {
+			{name: "minecraft:wool", states: map[string]interface{}{"color": "silver"}, newStates: map[string]interface{}{}},
+		},
+		"minecraft:lime_wool": {
+			{name: "minecraft:wool", states: map[string]interface{}{"color": "lime"}, newStates: map[string]interface{}{}},
+		},
+		"minecraft:magenta_wool": {
+			{name: "minecraft:wool", states: map[string]interface{}{"color": "magenta"}, newStates: map[string]interface{}{}},
+		},
+		"minecraft:orange_wool": {
+			{name: "minecraft:wool", states: map[string]interface{}{"color": "orange"}, newStates: map[string]interface{}{}},
+		},
+		"minecraft:pink_wool": {
+			{name: "minecraft:wool", states: map[string]interface{}{"color": "pink"}, newStates: map[string]interface{}{}},
+		},
+		"minecraft:purple_wool": {
+			{name: "minecraft:wool", states: map[string]interface{}{"color": "purple"}, newStates: map[string]interface{}{}},
+		},
+		"minecraft:red_wool": {
+			{name: "minecraft:wool", states: map[string]interface{}{"color": "red"}, newStates: map[string]interface{}{}},
+		},
+		"minecraft:white_wool": {
+			{name: "minecraft:wool", states: map[string]interface{}{"color": "white"}, newStates: map[string]interface{}{}},
+		},
+		"minecraft:yellow_wool": {
+			{name: "minecraft:wool", states: map[string]interface{}{"color": "yellow"}, newStates: map[string]interface{}{}},
+		},
+	}},
+}
@@ -0,0 +1,32 @@
+package structure
+
+import (
+	df "github.com/df-mc/dragonfly/server/block"
+	"testing"
+)
+
+func TestRequiredVersion(t *testing.T) {
+	tests := []struct {
+		name string
+		bl   block
+		want GameVersion
+	}{
+		{"known", block{Name: "minecraft:stone", States: map[string]interface{}{"stone_type": "granite"}, Version: BlockVersion(1, 19, 70, 15)}, GameVersion{1, 10, 0, 50}},
+		{"renamed", block{Name: "minecraft:white_wool", States: map[string]interface{}{}, Version: BlockVersion(1, 19, 70, 15)}, GameVersion{1, 19, 70, 15}},
+		{"unknown", block{Name: "minecraft:deepslate", States: map[string]interface{}{"pillar_axis": "y"}, Version: BlockVersion(1, 18, 0, 0)}, GameVersion{1, 18, 0, 0}},
+	}
+	for _, test := range tests {
+		s := New([3]int{1, 1, 1})
+		s.setIndex(0, s.ptrForEntry(test.bl), nil)
+		if got := s.RequiredVersion(); got != test.want {
+			t.Errorf("%v: RequiredVersion() = %v, want %v", test.name, got, test.want)
+		}
+	}
+
+	// Air is not changed by any schema, but exists in every version.
+	s := New([3]int{1, 1, 1})
+	s.Set(0, 0, 0, df.Air{}, nil)
+	if got := s.RequiredVersion(); got != (GameVersion{}) {
+		t.Errorf("air: RequiredVersion() = %v, want 0.0.0", got)
+	}
+}
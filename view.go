@@ -0,0 +1,105 @@
+package structure
+
+import (
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/world"
+)
+
+// BlockSource is a read-only source of blocks and liquids at world positions. It is implemented by *world.World
+// and by View, so that code that only queries blocks, such as pathfinding or validation code, may be written
+// against a BlockSource and run against both a world and a Structure in isolation.
+type BlockSource interface {
+	// Block returns the block at the position passed. Positions without a block hold air.
+	Block(pos cube.Pos) world.Block
+	// Liquid returns the liquid at the position passed. If the block at the position is itself a liquid, that
+	// liquid is returned. If there is no liquid at the position, false is returned.
+	Liquid(pos cube.Pos) (world.Liquid, bool)
+	// Range returns the range of Y values that may hold blocks.
+	Range() cube.Range
+}
+
+// Check to ensure that both *world.World and View implement the BlockSource interface.
+var (
+	_ BlockSource = (*world.World)(nil)
+	_ BlockSource = View{}
+)
+
+// View is a read-only view of a Structure as if it were built at a position in an otherwise empty world. View
+// implements BlockSource. All positions outside the Structure, and positions in the Structure that do not hold a
+// block, hold air.
+// A View reads from the Structure it was created for directly, so it reflects changes made to the Structure
+// afterwards. It must not be used while the Structure is being modified.
+type View struct {
+	s   Structure
+	pos cube.Pos
+}
+
+// NewView returns a View of the Structure passed, with the origin of the Structure at the position passed.
+func NewView(s Structure, pos cube.Pos) View {
+	return View{s: s, pos: pos}
+}
+
+// Block returns the block at the position passed.
+func (v View) Block(pos cube.Pos) world.Block {
+	if b, _ := v.at(pos); b != nil {
+		return b
+	}
+	return airBlock()
+}
+
+// Liquid returns the liquid at the position passed, or false if the position does not hold a liquid.
+func (v View) Liquid(pos cube.Pos) (world.Liquid, bool) {
+	b, liq := v.at(pos)
+	if l, ok := b.(world.Liquid); ok {
+		return l, true
+	}
+	return liq, liq != nil
+}
+
+// Range returns the range of Y values covered by the Structure.
+func (v View) Range() cube.Range {
+	return cube.Range{v.pos[1], v.pos[1] + v.s.Dimensions()[1] - 1}
+}
+
+// Bounds returns the lowest and highest position covered by the Structure. Both positions are inclusive.
+func (v View) Bounds() (min, max cube.Pos) {
+	dims := v.s.Dimensions()
+	return v.pos, v.pos.Add(cube.Pos{dims[0] - 1, dims[1] - 1, dims[2] - 1})
+}
+
+// Contains checks if the position passed lies within the Structure.
+func (v View) Contains(pos cube.Pos) bool {
+	rel, dims := pos.Sub(v.pos), v.s.Dimensions()
+	return rel[0] >= 0 && rel[1] >= 0 && rel[2] >= 0 && rel[0] < dims[0] && rel[1] < dims[1] && rel[2] < dims[2]
+}
+
+// HighestBlock returns the Y value of the highest position in the column at the x and z passed that holds a block
+// other than air. If the column holds no such block, the lowest Y value of the Range of the View is returned, like
+// world.World.HighestBlock does for empty columns.
+func (v View) HighestBlock(x, z int) int {
+	r := v.Range()
+	for y := r.Max(); y >= r.Min(); y-- {
+		if b, _ := v.at(cube.Pos{x, y, z}); b != nil {
+			if name, _ := b.EncodeBlock(); name != "minecraft:air" {
+				return y
+			}
+		}
+	}
+	return r.Min()
+}
+
+// at returns the block and liquid at the position passed, both of which are nil if the position is outside the
+// Structure or holds no block.
+func (v View) at(pos cube.Pos) (world.Block, world.Liquid) {
+	if !v.Contains(pos) {
+		return nil, nil
+	}
+	rel := pos.Sub(v.pos)
+	return v.s.At(rel[0], rel[1], rel[2], nil)
+}
+
+// airBlock returns the air block.
+func airBlock() world.Block {
+	b, _ := world.BlockByName("minecraft:air", nil)
+	return b
+}
@@ -0,0 +1,93 @@
+package structure
+
+import (
+	"errors"
+	"fmt"
+)
+
+// MaxVolume is the maximum volume, in blocks, of a structure that may be read. Every block of a structure takes
+// 8 bytes of memory for its block and liquid index, so the default of 1<<27 blocks corresponds to 1 GiB of
+// block indices. Read, FromBytes and DecodeCache return an error wrapping ErrTooLarge for structures with a
+// larger volume before allocating memory for their blocks, so that files declaring enormous dimensions, whether
+// by accident or maliciously, cannot exhaust the memory of a server.
+// MaxVolume may be changed to raise or lower the limit, but must not be changed while structures are read.
+// OpenMapped does not hold block indices in memory, so it is not limited by MaxVolume.
+var MaxVolume = 1 << 27
+
+// ErrTooLarge is returned when reading a structure with a volume exceeding MaxVolume.
+var ErrTooLarge = errors.New("structure too large")
+
+// checkVolume checks if a structure with the dimensions passed may be read according to MaxVolume. Negative
+// dimensions are not reported, as they are reported when verifying the structure.
+func checkVolume(x, y, z int64) error {
+	if x < 0 || y < 0 || z < 0 {
+		return nil
+	}
+	// The product of two int32s always fits in an int64, so only multiplying by z may overflow.
+	if volume := x * y; volume > int64(MaxVolume) || (z != 0 && volume > int64(MaxVolume)/z) {
+		return fmt.Errorf("%w: %vx%vx%v exceeds the maximum volume of %v blocks", ErrTooLarge, x, y, z, MaxVolume)
+	}
+	return nil
+}
+
+// scanStructure scans the NBT of a structure using the nbtScanner passed, checking the dimensions of the
+// structure and the length of its block indices against MaxVolume before their data is read. Once scanned, the
+// data of the nbtScanner holds the complete NBT of the structure, which may then be decoded safely.
+func scanStructure(r *nbtScanner) error {
+	if t := r.byte(); t != nbtCompound && r.err == nil {
+		return fmt.Errorf("expected root compound tag, got tag type %v", t)
+	}
+	r.string()
+	for r.err == nil {
+		t, name := r.tag()
+		switch {
+		case t == nbtEnd:
+			return r.err
+		case t == nbtList && name == "size":
+			elem, n := r.byte(), r.length(1)
+			if elem != nbtInt || n != 3 {
+				// An invalid size is reported when verifying the structure.
+				r.skipElements(elem, n)
+				continue
+			}
+			if err := checkVolume(int64(r.int32()), int64(r.int32()), int64(r.int32())); err != nil {
+				return err
+			}
+		case t == nbtCompound && name == "structure":
+			if err := scanStructureData(r); err != nil {
+				return err
+			}
+		default:
+			r.skip(t)
+		}
+	}
+	return r.err
+}
+
+// scanStructureData scans the structure compound of the NBT of a structure, checking the length of every layer
+// of block indices against MaxVolume before reading it.
+func scanStructureData(r *nbtScanner) error {
+	for r.err == nil {
+		t, name := r.tag()
+		if t == nbtEnd {
+			break
+		}
+		if t != nbtList || name != "block_indices" {
+			r.skip(t)
+			continue
+		}
+		elem, layers := r.byte(), r.length(1)
+		if elem != nbtList {
+			r.skipElements(elem, layers)
+			continue
+		}
+		for i := 0; i < layers && r.err == nil; i++ {
+			elem, n := r.byte(), r.length(1)
+			if n > MaxVolume {
+				return fmt.Errorf("%w: %v blocks in storage %v exceeds the maximum volume of %v blocks", ErrTooLarge, n, i, MaxVolume)
+			}
+			r.skipElements(elem, n)
+		}
+	}
+	return r.err
+}
@@ -0,0 +1,106 @@
+package structure
+
+import (
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/world"
+)
+
+// CaptureOption is an option that changes the way a Structure is captured by Capture.
+type CaptureOption func(conf *captureConfig)
+
+// captureConfig holds the configuration of a single call to Capture, as changed by CaptureOptions.
+type captureConfig struct {
+	liquidPolicy LiquidPolicy
+}
+
+// LiquidPolicy returns the liquid that the world.Block passed holds inherently, such as the water that kelp and
+// seagrass always stand in, or nil if the block does not hold one. Capture uses a LiquidPolicy for blocks that
+// have no liquid in the liquid layer of the world, so that the liquid is still recorded in the liquid layer of
+// the Structure, as Minecraft does when exporting a structure.
+type LiquidPolicy func(b world.Block) world.Liquid
+
+// WithLiquidPolicy makes Capture use the LiquidPolicy passed instead of InherentWater, for example to record
+// custom blocks that always hold a liquid. A nil LiquidPolicy records only the liquids actually present in the
+// world.
+func WithLiquidPolicy(policy LiquidPolicy) CaptureOption {
+	return func(conf *captureConfig) {
+		conf.liquidPolicy = policy
+	}
+}
+
+// liquidFor returns the liquid to record in the liquid layer of a captured Structure for a world.Block, given the
+// liquid found at its position in the world, which may be nil.
+func (conf *captureConfig) liquidFor(b world.Block, liq world.Liquid) world.Liquid {
+	if _, ok := b.(world.Liquid); ok {
+		// The liquid is the block itself, so there is no additional liquid.
+		return nil
+	}
+	if liq == nil && conf.liquidPolicy != nil {
+		return conf.liquidPolicy(b)
+	}
+	return liq
+}
+
+// inherentlyWaterlogged holds the names of blocks that can only exist in water.
+var inherentlyWaterlogged = map[string]bool{
+	"minecraft:kelp":          true,
+	"minecraft:seagrass":      true,
+	"minecraft:bubble_column": true,
+}
+
+// InherentWater is the LiquidPolicy used by Capture by default. It returns still water for blocks that can only
+// exist in water: kelp, seagrass and bubble columns. Worlds do not always hold water in the liquid layer at the
+// positions of these blocks, but without it, these blocks would be built without water around them.
+func InherentWater(b world.Block) world.Liquid {
+	if name, _ := b.EncodeBlock(); !inherentlyWaterlogged[name] {
+		return nil
+	}
+	water, ok := world.BlockByName("minecraft:water", map[string]interface{}{"liquid_depth": int32(0)})
+	if !ok {
+		return nil
+	}
+	liq, _ := water.(world.Liquid)
+	return liq
+}
+
+// Capture creates a new Structure holding the blocks and liquids in the cuboid between the two corners passed
+// in the world.World passed. Both corners are inclusive and may be passed in any order. The world origin of
+// the Structure returned is set to the lowest corner of the cuboid.
+// Liquids are recorded the way Minecraft exports them: a liquid that is a block by itself is recorded as the
+// block, and a liquid that a block is waterlogged with is recorded in the liquid layer. Blocks that inherently
+// hold a liquid according to the LiquidPolicy used, InherentWater by default, are recorded with that liquid.
+func Capture(w *world.World, a, b cube.Pos, opts ...CaptureOption) Structure {
+	conf := &captureConfig{liquidPolicy: InherentWater}
+	for _, opt := range opts {
+		opt(conf)
+	}
+	min, max := cornersOf(a, b)
+	s := NewFromFunc([3]int{max[0] - min[0] + 1, max[1] - min[1] + 1, max[2] - min[2] + 1}, func(x, y, z int) (world.Block, world.Liquid) {
+		pos := min.Add(cube.Pos{x, y, z})
+		b := w.Block(pos)
+		liq, _ := w.Liquid(pos)
+		return b, conf.liquidFor(b, liq)
+	})
+	s.Origin = []int32{int32(min[0]), int32(min[1]), int32(min[2])}
+	return s
+}
+
+// Clear sets all blocks in the cuboid between the two corners passed in the world.World passed to air and
+// removes any liquids present. Both corners are inclusive and may be passed in any order. Like Build, Clear
+// replaces blocks chunk by chunk without triggering block updates, so that neighbouring blocks such as sand
+// and water do not react to the cuboid being cleared while it is only partially cleared.
+func Clear(w *world.World, a, b cube.Pos) {
+	min, max := cornersOf(a, b)
+	w.BuildStructure(min, New([3]int{max[0] - min[0] + 1, max[1] - min[1] + 1, max[2] - min[2] + 1}))
+}
+
+// cornersOf returns the lowest and highest corner of the cuboid spanned by the two corners passed.
+func cornersOf(a, b cube.Pos) (min, max cube.Pos) {
+	for i := 0; i < 3; i++ {
+		min[i], max[i] = a[i], b[i]
+		if min[i] > max[i] {
+			min[i], max[i] = max[i], min[i]
+		}
+	}
+	return min, max
+}
@@ -0,0 +1,120 @@
+package structure
+
+import (
+	"github.com/df-mc/dragonfly/server/world/chunk"
+	"github.com/df-mc/worldupgrader/blockupgrader"
+	"reflect"
+)
+
+// WriteOption is an option that changes the way a Structure is written by Write and WriteFile.
+type WriteOption func(conf *writeConfig)
+
+// writeConfig holds the configuration of a single call to Write, as changed by WriteOptions.
+type writeConfig struct {
+	convert func(bl block) block
+}
+
+// BlockVersion returns the block version of the Minecraft version passed, such as BlockVersion(1, 16, 100, 0)
+// for Minecraft 1.16.100. Block versions are stored with every entry of the palette of a Structure.
+func BlockVersion(major, minor, patch, revision int) int32 {
+	return int32(major<<24 | minor<<16 | patch<<8 | revision)
+}
+
+// WithUpgrade makes Write upgrade every entry in the palette of the Structure to the current block version
+// before writing it, rather than writing the entries with the block versions they were stored with. Structures
+// read from very old files are thereby modernised once, so that they no longer need to be upgraded when they are
+// read again. WithUpgrade and WithDowngrade cannot be combined: the option passed last takes effect.
+func WithUpgrade() WriteOption {
+	return func(conf *writeConfig) {
+		conf.convert = upgradeEntry
+	}
+}
+
+// WithDowngrade makes Write convert the palette of the Structure to the block states of the block version
+// passed, so that the Structure may be read by servers and tools pinned to an older version of Minecraft. A
+// block version may be obtained using BlockVersion.
+// Every palette entry is first passed to mapping, if not nil, which may return the BlockState to write in its
+// place in the older version. If mapping returns false, blocks renamed since the older version, such as
+// minecraft:white_wool, which was minecraft:wool with a colour before, are given their name and states in the
+// older version. The entry is then written with the older block version if upgrading it from that version
+// results in the same block. Otherwise, the entry is written unchanged, with its current block version.
+// Because blocks that were added after the older version are indistinguishable from blocks that did not change,
+// mapping should handle such blocks if they may be present in the Structure. WithDowngrade and WithUpgrade cannot
+// be combined: the option passed last takes effect.
+func WithDowngrade(version int32, mapping func(b BlockState) (BlockState, bool)) WriteOption {
+	return func(conf *writeConfig) {
+		conf.convert = func(bl block) block {
+			if mapping != nil {
+				if b, ok := mapping(blockStateOf(bl)); ok {
+					e := b.entry()
+					e.Version = version
+					return e
+				}
+			}
+			current := upgradeEntry(bl)
+			older := downgradeEntry(current, version)
+			upgraded := upgradeEntry(older)
+			if upgraded.Name == current.Name && reflect.DeepEqual(upgraded.States, current.States) {
+				return older
+			}
+			return current
+		}
+	}
+}
+
+// upgradeEntry returns the palette entry passed upgraded to the current block version.
+func upgradeEntry(bl block) block {
+	upgraded := blockupgrader.Upgrade(blockupgrader.BlockState{
+		Name:       bl.Name,
+		Properties: copyStates(bl.States),
+		Version:    bl.Version,
+	})
+	if upgraded.Properties == nil {
+		upgraded.Properties = map[string]interface{}{}
+	}
+	return block{Name: upgraded.Name, States: upgraded.Properties, Version: chunk.CurrentBlockVersion}
+}
+
+// downgradeEntry returns the palette entry passed, in the format of the current block version, renamed to the name
+// and states it had in the block version passed. Renames made by the block upgrade schemas applied when upgrading
+// from that version are undone from the newest to the oldest schema. Other changes to the states of the entry are
+// not undone, so the entry returned should be upgraded again to verify that it results in the same block.
+func downgradeEntry(bl block, version int32) block {
+	name, states := bl.Name, bl.States
+	for i := len(blockRenames) - 1; i >= 0 && blockRenames[i].version >= version; i-- {
+		for _, r := range blockRenames[i].renames[name] {
+			if r.states == nil {
+				name = r.name
+				break
+			}
+			if statesEqual(r.newStates, states) {
+				name, states = r.name, copyStates(r.states)
+				break
+			}
+		}
+	}
+	return block{Name: name, States: states, Version: version}
+}
+
+// copyStates returns a copy of the block states passed. Upgrading block states may modify them, so entries that
+// must remain unchanged are copied before upgrading them.
+func copyStates(states map[string]interface{}) map[string]interface{} {
+	m := make(map[string]interface{}, len(states))
+	for k, v := range states {
+		m[k] = v
+	}
+	return m
+}
+
+// convertPalettes returns a copy of the palettes passed with every entry converted using the function passed.
+func convertPalettes(palettes map[string]palette, convert func(bl block) block) map[string]palette {
+	converted := make(map[string]palette, len(palettes))
+	for name, p := range palettes {
+		entries := make([]block, len(p.BlockPalette))
+		for i, bl := range p.BlockPalette {
+			entries[i] = convert(bl)
+		}
+		converted[name] = palette{BlockPalette: entries, BlockPositionData: p.BlockPositionData}
+	}
+	return converted
+}
@@ -0,0 +1,31 @@
+package structure
+
+import (
+	"bytes"
+	df "github.com/df-mc/dragonfly/server/block"
+	"github.com/df-mc/dragonfly/server/item"
+	"testing"
+)
+
+func TestWriteDowngradeRenamedBlock(t *testing.T) {
+	s := New([3]int{1, 1, 1})
+	s.Set(0, 0, 0, df.Wool{Colour: item.ColourWhite()}, nil)
+
+	// Minecraft 1.19.70 split minecraft:wool into a block for every colour, such as minecraft:white_wool.
+	version := BlockVersion(1, 19, 60, 0)
+	var buf bytes.Buffer
+	if err := Write(&buf, s, WithDowngrade(version, nil)); err != nil {
+		t.Fatalf("write structure: %v", err)
+	}
+	r, err := Read(&buf)
+	if err != nil {
+		t.Fatalf("read structure: %v", err)
+	}
+	entry := r.palette.BlockPalette[r.blocks[0]]
+	if entry.Name != "minecraft:wool" || entry.States["color"] != "white" || entry.Version != version {
+		t.Fatalf("entry written = %v, want minecraft:wool with colour white and version %v", entry, version)
+	}
+	if got, _ := r.At(0, 0, 0, nil); !sameBlock(got, df.Wool{Colour: item.ColourWhite()}) {
+		t.Fatalf("block read = %v, want white wool", got)
+	}
+}
@@ -0,0 +1,116 @@
+package structure
+
+import (
+	"fmt"
+	"github.com/df-mc/dragonfly/server/world"
+)
+
+// YMajorStructure is a read-only structure of which the block indices are ordered Y first, then X, then Z, rather
+// than X first, then Y, then Z like in a Structure. Every horizontal layer of a YMajorStructure is therefore held
+// contiguously in memory. world.World.BuildStructure places blocks chunk by chunk, one horizontal layer of a chunk at
+// a time, so building a YMajorStructure reads its block indices in the order in which they are held in memory.
+// Building a large YMajorStructure is only marginally faster than building the equivalent Structure, as most of the
+// time building is spent resolving blocks rather than reading block indices, but code that reads structures layer
+// by layer itself, such as code that renders or scans one layer at a time, benefits more.
+// A YMajorStructure is obtained using Structure.YMajor. It implements world.Structure, so it may be built directly
+// using world.World.BuildStructure, and is safe to use from multiple goroutines at the same time.
+type YMajorStructure struct {
+	s *structure
+	// w and l are the width and length of the structure. blocks and liquids hold the palette indices of the blocks
+	// and liquids of the structure, with the index of a position x, y, z at (y*w+x)*l+z. The block entity data of
+	// the structure is kept in its palette, keyed by the offsets of a Structure.
+	w, l            int
+	blocks, liquids []int32
+}
+
+// Check to ensure that YMajorStructure implements the world.Structure interface.
+var _ world.Structure = YMajorStructure{}
+
+// YMajor returns a copy of the Structure with its block indices ordered Y first, which holds the same blocks,
+// liquids, entities and palettes. Modifying the Structure afterwards does not affect the YMajorStructure returned.
+// XMajor may be used to obtain a mutable Structure again.
+func (s Structure) YMajor() YMajorStructure {
+	// The palette is parsed in advance, so that concurrent reads do not race to parse it lazily.
+	s.ensureParsed()
+	dims := s.Dimensions()
+	c := s.copyStructure(false)
+	y := YMajorStructure{s: c.structure, w: dims[0], l: dims[2], blocks: make([]int32, len(s.blocks)), liquids: make([]int32, len(s.liquids))}
+	for offset := range s.blocks {
+		i := y.offset(s.posOf(offset))
+		y.blocks[i], y.liquids[i] = s.blocks[offset], s.liquids[offset]
+	}
+	return y
+}
+
+// offset returns the index of the position passed in the block indices of the structure.
+func (y YMajorStructure) offset(pos [3]int) int {
+	return (pos[1]*y.w+pos[0])*y.l + pos[2]
+}
+
+// XMajor returns a mutable copy of the YMajorStructure as a Structure.
+func (y YMajorStructure) XMajor() Structure {
+	s := Structure{structure: y.s}.copyStructure(false)
+	n := len(y.blocks)
+	blocks, liquids := make([]int32, n), make([]int32, n)
+	for offset := 0; offset < n; offset++ {
+		i := y.offset(s.posOf(offset))
+		blocks[offset], liquids[offset] = y.blocks[i], y.liquids[i]
+	}
+	s.Structure.BlockIndices = [][]int32{blocks, liquids}
+	s.prepare()
+	return s
+}
+
+// Dimensions returns the dimensions of the structure.
+func (y YMajorStructure) Dimensions() [3]int {
+	return y.s.Dimensions()
+}
+
+// At returns the block and liquid at the x, y and z passed in the structure. Like Structure.At, it returns nil
+// for positions that do not hold a block or liquid.
+func (y YMajorStructure) At(x, yPos, z int, _ func(x int, y int, z int) world.Block) (world.Block, world.Liquid) {
+	offset := (yPos*y.w+x)*y.l + z
+	b := y.blockAt(offset, x, yPos, z)
+	if index := y.liquids[offset]; index != -1 {
+		return b, y.s.parsedPalette[index].liq
+	}
+	return b, nil
+}
+
+// BlockAt returns the block at the x, y and z passed in the structure, without looking up the liquid at the
+// position.
+func (y YMajorStructure) BlockAt(x, yPos, z int) world.Block {
+	return y.blockAt((yPos*y.w+x)*y.l+z, x, yPos, z)
+}
+
+// blockAt returns the block at the offset passed, which is the offset of the x, y and z passed, decoding its block
+// entity data if it has any.
+func (y YMajorStructure) blockAt(offset, x, yPos, z int) world.Block {
+	index := y.blocks[offset]
+	if index == -1 {
+		return nil
+	}
+	entry := y.s.parsedPalette[index]
+	if entry.hasNBT {
+		if data, ok := y.s.positionData((x * y.s.l * y.s.h) + (yPos * y.s.l) + z); ok {
+			return entry.b.(world.NBTer).DecodeNBT(data.BlockEntityData).(world.Block)
+		}
+	}
+	return entry.b
+}
+
+// PaletteSize returns the number of entries in the palette of the structure. See Structure.PaletteSize.
+func (y YMajorStructure) PaletteSize() int {
+	return y.s.PaletteSize()
+}
+
+// Palette returns all distinct blocks in the palette of the structure. See Structure.Palette.
+func (y YMajorStructure) Palette() []world.Block {
+	return y.s.Palette()
+}
+
+// String returns a summary of the structure, holding its dimensions and the size of its palette.
+func (y YMajorStructure) String() string {
+	dims := y.Dimensions()
+	return fmt.Sprintf("YMajorStructure(%vx%vx%v, palette: %v)", dims[0], dims[1], dims[2], y.PaletteSize())
+}
@@ -0,0 +1,55 @@
+package structure
+
+import (
+	"fmt"
+	"github.com/df-mc/dragonfly/server/block/cube"
+)
+
+// LegacyRange is the range of Y values of the Overworld before Minecraft 1.18 extended it to -64 to 319.
+// Structures captured in worlds created before 1.18 have their world origin within this range.
+var LegacyRange = cube.Range{0, 255}
+
+// RebaseY converts the Y value passed from the range from to the range to, so that it is at the same height above
+// the bottom of the range. RebaseY(5, LegacyRange, world.Overworld.Range()) for example returns -59, which is useful
+// for structures that were placed relative to the bedrock floor of an old world, such as mineshafts and dungeons
+// captured near Y=0. Structures placed relative to the surface or sea level, which did not move in 1.18, should
+// keep their Y value instead.
+func RebaseY(y int, from, to cube.Range) int {
+	return y - from.Min() + to.Min()
+}
+
+// CheckRange checks if the Structure, when built at the position passed, fits within the range of Y values
+// passed, such as that of the dimension it is built in. Blocks built outside the range of a dimension are lost,
+// so if the Structure does not fit, an error describing the number of layers that are cut off is returned. The
+// error may be used to warn about, or reject, such builds.
+func (s Structure) CheckRange(pos cube.Pos, r cube.Range) error {
+	top := pos[1] + s.Dimensions()[1] - 1
+	below, above := r.Min()-pos[1], top-r.Max()
+	switch {
+	case below > 0 && above > 0:
+		return fmt.Errorf("structure spanning Y %v to %v exceeds range %v to %v by %v layers below and %v layers above", pos[1], top, r.Min(), r.Max(), below, above)
+	case below > 0:
+		return fmt.Errorf("structure spanning Y %v to %v exceeds range %v to %v by %v layers below", pos[1], top, r.Min(), r.Max(), below)
+	case above > 0:
+		return fmt.Errorf("structure spanning Y %v to %v exceeds range %v to %v by %v layers above", pos[1], top, r.Min(), r.Max(), above)
+	}
+	return nil
+}
+
+// ClampY returns the position passed moved up or down as little as possible so that the Structure, when built at
+// it, fits within the range of Y values passed. If the Structure is taller than the range, it cannot fit, in which
+// case the position returned aligns the bottom of the Structure with the bottom of the range and false is
+// returned.
+func (s Structure) ClampY(pos cube.Pos, r cube.Range) (cube.Pos, bool) {
+	height := s.Dimensions()[1]
+	if height > r.Height()+1 {
+		pos[1] = r.Min()
+		return pos, false
+	}
+	if pos[1] < r.Min() {
+		pos[1] = r.Min()
+	} else if top := pos[1] + height - 1; top > r.Max() {
+		pos[1] -= top - r.Max()
+	}
+	return pos, true
+}